@@ -16,6 +16,8 @@ import {
 import { DatePicker } from '@mui/x-date-pickers';
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import { roomApi } from '../services/api';
+import { Money } from '../types';
+import { formatAmount, formatMoney, moneyAmount } from '../utils/money';
 
 interface BookingDialogProps {
   open: boolean;
@@ -25,7 +27,7 @@ interface BookingDialogProps {
 interface Room {
   id: string;
   name: string;
-  price_per_night: number;
+  price_per_day: Money;
 }
 
 const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
@@ -34,6 +36,7 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
   const [checkInDate, setCheckInDate] = useState<Date | null>(null);
   const [checkOutDate, setCheckOutDate] = useState<Date | null>(null);
   const [specialRequests, setSpecialRequests] = useState('');
+  const [paymentToken, setPaymentToken] = useState('');
 
   const { data: rooms } = useQuery<Room[]>({
     queryKey: ['rooms'],
@@ -48,7 +51,7 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
   const calculateTotalPrice = () => {
     if (!checkInDate || !checkOutDate || !selectedRoom) return 0;
     const days = Math.ceil((checkOutDate.getTime() - checkInDate.getTime()) / (1000 * 60 * 60 * 24));
-    return days * selectedRoom.price_per_night;
+    return days * moneyAmount(selectedRoom.price_per_day);
   };
 
   const createBooking = useMutation({
@@ -56,8 +59,9 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
       room_id: string;
       check_in_date: string;
       check_out_date: string;
-      total_price: number;
+      total_price: string;
       special_requests?: string;
+      payment_token: string;
     }) => {
       return roomApi.post('/bookings', data);
     },
@@ -72,18 +76,20 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
     setCheckInDate(null);
     setCheckOutDate(null);
     setSpecialRequests('');
+    setPaymentToken('');
     onClose();
   };
 
   const handleSubmit = () => {
-    if (!roomId || !checkInDate || !checkOutDate) return;
+    if (!roomId || !checkInDate || !checkOutDate || !paymentToken) return;
 
     createBooking.mutate({
       room_id: roomId,
       check_in_date: checkInDate.toISOString().split('T')[0],
       check_out_date: checkOutDate.toISOString().split('T')[0],
-      total_price: calculateTotalPrice(),
+      total_price: calculateTotalPrice().toFixed(2),
       special_requests: specialRequests || undefined,
+      payment_token: paymentToken,
     });
   };
 
@@ -101,7 +107,7 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
             >
               {rooms?.map((room) => (
                 <MenuItem key={room.id} value={room.id}>
-                  {room.name} - ${room.price_per_night}/night
+                  {room.name} - {formatMoney(room.price_per_day)}/night
                 </MenuItem>
               ))}
             </Select>
@@ -141,10 +147,19 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
             onChange={(e) => setSpecialRequests(e.target.value)}
           />
 
+          <TextField
+            label="Card Token"
+            fullWidth
+            required
+            value={paymentToken}
+            onChange={(e) => setPaymentToken(e.target.value)}
+            helperText="The stay is held on this card when the booking is confirmed"
+          />
+
           {selectedRoom && checkInDate && checkOutDate && (
             <Box sx={{ mt: 2, p: 2, bgcolor: 'grey.100', borderRadius: 1 }}>
               <Typography variant="subtitle1">
-                Total Price: ${calculateTotalPrice().toFixed(2)}
+                Total Price: {formatAmount(calculateTotalPrice())}
               </Typography>
             </Box>
           )}
@@ -155,7 +170,7 @@ const BookingDialog: React.FC<BookingDialogProps> = ({ open, onClose }) => {
         <Button
           onClick={handleSubmit}
           variant="contained"
-          disabled={!roomId || !checkInDate || !checkOutDate}
+          disabled={!roomId || !checkInDate || !checkOutDate || !paymentToken}
         >
           Create
         </Button>
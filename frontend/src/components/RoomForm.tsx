@@ -9,12 +9,13 @@ import {
   Grid,
   MenuItem,
 } from '@mui/material';
-import { Room } from '../types';
+import { Room, RoomInput } from '../types';
+import { moneyAmount } from '../utils/money';
 
 interface RoomFormProps {
   open: boolean;
   onClose: () => void;
-  onSubmit: (data: Omit<Room, 'id' | 'createdAt'>) => void;
+  onSubmit: (data: RoomInput) => void;
   initialData?: Room;
 }
 
@@ -30,7 +31,7 @@ export const RoomForm: React.FC<RoomFormProps> = ({
     floor: initialData?.floor || 1,
     description: initialData?.description || '',
     capacity: initialData?.capacity || 2,
-    pricePerDay: initialData?.pricePerDay || 0,
+    pricePerDay: moneyAmount(initialData?.pricePerDay),
     status: initialData?.status || 'available',
   });
 
@@ -42,7 +43,7 @@ export const RoomForm: React.FC<RoomFormProps> = ({
         floor: initialData.floor,
         description: initialData.description,
         capacity: initialData.capacity,
-        pricePerDay: initialData.pricePerDay,
+        pricePerDay: moneyAmount(initialData.pricePerDay),
         status: initialData.status,
       });
     }
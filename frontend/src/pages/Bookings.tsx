@@ -4,6 +4,8 @@ import { DataGrid, GridColDef, GridRenderCellParams } from '@mui/x-data-grid';
 import { useQuery } from '@tanstack/react-query';
 import { roomApi } from '../services/api';
 import BookingDialog from '../components/BookingDialog';
+import { Money } from '../types';
+import { formatMoney } from '../utils/money';
 
 interface Booking {
   id: string;
@@ -15,7 +17,7 @@ interface Booking {
   user_id: string;
   check_in_date: string;
   check_out_date: string;
-  total_price: number;
+  total_price: Money;
   status: string;
   special_requests?: string;
   created_at: string;
@@ -48,10 +50,7 @@ const columns: GridColDef[] = [
     field: 'total_price',
     headerName: 'Price',
     width: 100,
-    valueFormatter: (params) => {
-      if (params.value == null) return '';
-      return `$${params.value.toFixed(2)}`;
-    },
+    valueFormatter: (params) => formatMoney(params.value as Money | null),
   },
   {
     field: 'status',
@@ -21,7 +21,7 @@ import {
   TextField,
 } from '@mui/material';
 import { supplyService } from '../services/supply';
-import { InventoryItem, PurchaseOrderItem } from '../types';
+import { InventoryItem, PurchaseOrderItemInput } from '../types';
 
 const Inventory: React.FC = () => {
   const [selectedItem, setSelectedItem] = useState<InventoryItem | null>(null);
@@ -43,7 +43,7 @@ const Inventory: React.FC = () => {
     if (!selectedItem) return;
 
     try {
-      const orderItem: PurchaseOrderItem = {
+      const orderItem: PurchaseOrderItemInput = {
         inventoryItemId: selectedItem.id,
         quantity,
         unitPrice,
@@ -21,13 +21,15 @@ import {
 } from '@mui/material';
 import { Add as AddIcon, Remove as RemoveIcon } from '@mui/icons-material';
 import { foodApi } from '../services/api';
+import { Money } from '../types';
+import { formatAmount, formatMoney, moneyAmount } from '../utils/money';
 
 interface MenuItem {
   id: string;
   name: string;
   description: string;
   category: string;
-  price: number;
+  price: Money;
   preparation_time: number;
   is_available: boolean;
 }
@@ -88,7 +90,7 @@ const Menu = () => {
   };
 
   const getTotalPrice = () => {
-    return cart.reduce((total, item) => total + item.price * item.quantity, 0);
+    return cart.reduce((total, item) => total + moneyAmount(item.price) * item.quantity, 0);
   };
 
   const handlePlaceOrder = async () => {
@@ -105,7 +107,7 @@ const Menu = () => {
           quantity: item.quantity,
           unit_price: item.price,
         })),
-        total_amount: getTotalPrice(),
+        total_amount: getTotalPrice().toFixed(2),
         special_instructions: specialInstructions,
       });
 
@@ -169,7 +171,7 @@ const Menu = () => {
                       </Typography>
                       <Box sx={{ display: 'flex', justifyContent: 'space-between', alignItems: 'center' }}>
                         <Typography variant="h6" color="primary">
-                          ${item.price.toFixed(2)}
+                          {formatMoney(item.price)}
                         </Typography>
                         <Chip
                           label={`${item.preparation_time} mins`}
@@ -214,7 +216,7 @@ const Menu = () => {
                   <Box>
                     <Typography variant="body1">{item.name}</Typography>
                     <Typography variant="body2" color="text.secondary">
-                      ${item.price.toFixed(2)} x {item.quantity}
+                      {formatMoney(item.price)} x {item.quantity}
                     </Typography>
                   </Box>
                   <Box sx={{ display: 'flex', alignItems: 'center' }}>
@@ -230,7 +232,7 @@ const Menu = () => {
               ))}
               <Divider sx={{ my: 2 }} />
               <Typography variant="h6" gutterBottom>
-                Total: ${getTotalPrice().toFixed(2)}
+                Total: {formatAmount(getTotalPrice())}
               </Typography>
               <TextField
                 fullWidth
@@ -15,6 +15,7 @@ import {
 import { format } from 'date-fns';
 import { foodService } from '../services/food';
 import { Order, OrderItem } from '../types';
+import { formatMoney } from '../utils/money';
 
 const Orders: React.FC = () => {
   const { data: orders, isLoading, error } = useQuery({
@@ -66,7 +67,7 @@ const Orders: React.FC = () => {
                     }
                   />
                 </TableCell>
-                <TableCell>${order.totalPrice}</TableCell>
+                <TableCell>{formatMoney(order.totalPrice)}</TableCell>
                 <TableCell>
                   {format(new Date(order.createdAt), 'MMM dd, yyyy HH:mm')}
                 </TableCell>
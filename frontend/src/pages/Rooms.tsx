@@ -14,19 +14,22 @@ import {
   Box,
   Chip,
   Alert,
+  TextField,
 } from '@mui/material';
 import { DatePicker } from '@mui/x-date-pickers/DatePicker';
 import { AdapterDateFns } from '@mui/x-date-pickers/AdapterDateFns';
 import { LocalizationProvider } from '@mui/x-date-pickers/LocalizationProvider';
 import { roomApi } from '../services/api';
 import { format } from 'date-fns';
+import { Money } from '../types';
+import { formatMoney, moneyAmount } from '../utils/money';
 
 interface Room {
   id: string;
   room_number: string;
   room_type: string;
   description: string;
-  price_per_night: number;
+  price_per_day: Money;
   capacity: number;
   amenities: string[];
   status: string;
@@ -37,6 +40,7 @@ const Rooms = () => {
   const [selectedRoom, setSelectedRoom] = useState<Room | null>(null);
   const [checkIn, setCheckIn] = useState<Date | null>(null);
   const [checkOut, setCheckOut] = useState<Date | null>(null);
+  const [paymentToken, setPaymentToken] = useState('');
   const [openDialog, setOpenDialog] = useState(false);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState<string | null>(null);
@@ -59,11 +63,11 @@ const Rooms = () => {
   };
 
   const handleBookRoom = async () => {
-    if (!selectedRoom || !checkIn || !checkOut) return;
+    if (!selectedRoom || !checkIn || !checkOut || !paymentToken) return;
 
     try {
       const nights = Math.ceil((checkOut.getTime() - checkIn.getTime()) / (1000 * 60 * 60 * 24));
-      const totalPrice = selectedRoom.price_per_night * nights;
+      const totalPrice = moneyAmount(selectedRoom.price_per_day) * nights;
 
       // Create booking
       await roomApi.post('/bookings', {
@@ -71,8 +75,9 @@ const Rooms = () => {
         user_id: localStorage.getItem('userId'), // Make sure this is set during login
         check_in_date: format(checkIn, 'yyyy-MM-dd'),
         check_out_date: format(checkOut, 'yyyy-MM-dd'),
-        total_price: totalPrice,
-        status: 'confirmed'
+        total_price: totalPrice.toFixed(2),
+        status: 'confirmed',
+        payment_token: paymentToken,
       });
 
       // Update room status
@@ -84,6 +89,7 @@ const Rooms = () => {
       setTimeout(() => {
         setOpenDialog(false);
         setBookingSuccess(false);
+        setPaymentToken('');
         fetchRooms(); // Refresh rooms list
       }, 2000);
     } catch (err) {
@@ -128,7 +134,7 @@ const Rooms = () => {
                   ))}
                 </Box>
                 <Typography variant="h6" color="primary" gutterBottom>
-                  ${room.price_per_night} per night
+                  {formatMoney(room.price_per_day)} per night
                 </Typography>
                   <Button
                     variant="contained"
@@ -158,7 +164,7 @@ const Rooms = () => {
                 {selectedRoom?.room_type} - Room {selectedRoom?.room_number}
               </Typography>
               <Typography variant="body2" color="text.secondary" gutterBottom>
-                ${selectedRoom?.price_per_night} per night
+                {formatMoney(selectedRoom?.price_per_day)} per night
               </Typography>
               <LocalizationProvider dateAdapter={AdapterDateFns}>
                 <Box sx={{ mt: 2 }}>
@@ -178,6 +184,15 @@ const Rooms = () => {
       />
     </Box>
               </LocalizationProvider>
+              <TextField
+                label="Card Token"
+                fullWidth
+                required
+                value={paymentToken}
+                onChange={(e) => setPaymentToken(e.target.value)}
+                helperText="The stay is held on this card when the booking is confirmed"
+                sx={{ mt: 2 }}
+              />
             </>
           )}
         </DialogContent>
@@ -186,7 +201,7 @@ const Rooms = () => {
           <Button
             onClick={handleBookRoom}
             variant="contained"
-            disabled={!checkIn || !checkOut || bookingSuccess}
+            disabled={!checkIn || !checkOut || !paymentToken || bookingSuccess}
           >
             Confirm Booking
           </Button>
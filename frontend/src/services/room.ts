@@ -1,5 +1,5 @@
 import axios from 'axios';
-import { Room, Booking } from '../types';
+import { Room, RoomInput, Booking } from '../types';
 
 const API_URL = process.env.REACT_APP_API_URL || 'http://localhost:8080';
 
@@ -37,12 +37,12 @@ export const roomService = {
     await axios.delete(`${API_URL}/api/bookings/${bookingId}`);
   },
 
-  createRoom: async (room: Omit<Room, 'id' | 'createdAt'>): Promise<Room> => {
+  createRoom: async (room: RoomInput): Promise<Room> => {
     const response = await axios.post<Room>(`${API_URL}/api/rooms`, room);
     return response.data;
   },
 
-  updateRoom: async (id: string, room: Partial<Room>): Promise<Room> => {
+  updateRoom: async (id: string, room: Partial<RoomInput>): Promise<Room> => {
     const response = await axios.put<Room>(`${API_URL}/api/rooms/${id}`, room);
     return response.data;
   },
@@ -1,3 +1,9 @@
+// Money is an amount as the services send it: a decimal string and its currency
+export interface Money {
+  amount: string;
+  currency: string;
+}
+
 // Room types
 export interface Room {
   id: string;
@@ -6,10 +12,15 @@ export interface Room {
   floor: number;
   description: string;
   capacity: number;
-  pricePerDay: number;
+  pricePerDay: Money;
   status: 'available' | 'occupied' | 'maintenance';
 }
 
+// RoomInput is a room as it is sent to be created; the price may be a plain number
+export type RoomInput = Omit<Room, 'id' | 'pricePerDay'> & {
+  pricePerDay: number;
+};
+
 export interface Booking {
   id: string;
   roomId: string;
@@ -17,7 +28,7 @@ export interface Booking {
   startDate: string;
   endDate: string;
   status: 'pending' | 'confirmed' | 'cancelled' | 'completed';
-  totalPrice: number;
+  totalPrice: Money;
   createdAt: string;
 }
 
@@ -26,7 +37,7 @@ export interface MenuItem {
   id: string;
   name: string;
   description: string;
-  price: number;
+  price: Money;
   category: string;
   imageUrl?: string;
   isAvailable: boolean;
@@ -37,14 +48,14 @@ export interface Order {
   userId: string;
   items: OrderItem[];
   status: 'pending' | 'preparing' | 'ready' | 'delivered' | 'cancelled';
-  totalPrice: number;
+  totalPrice: Money;
   createdAt: string;
 }
 
 export interface OrderItem {
   menuItemId: string;
   quantity: number;
-  price: number;
+  price: Money;
 }
 
 // Supply types
@@ -73,16 +84,21 @@ export interface PurchaseOrder {
   supplierId: string;
   items: PurchaseOrderItem[];
   status: 'pending' | 'approved' | 'ordered' | 'received' | 'cancelled';
-  totalAmount: number;
+  totalAmount: Money;
   createdAt: string;
 }
 
 export interface PurchaseOrderItem {
   inventoryItemId: string;
   quantity: number;
-  unitPrice: number;
+  unitPrice: Money;
 }
 
+// PurchaseOrderItemInput is an item as it is sent to be ordered
+export type PurchaseOrderItemInput = Omit<PurchaseOrderItem, 'unitPrice'> & {
+  unitPrice: number;
+};
+
 // Auth types
 export interface User {
   id: string;
@@ -0,0 +1,17 @@
+import { Money } from '../types';
+
+// The services send amounts as { amount: "12.50", currency: "USD" }, keeping the
+// amount a decimal string so that it is never rounded on the way.
+
+export const moneyAmount = (money: Money | null | undefined): number =>
+  money ? Number(money.amount) : 0;
+
+export const formatMoney = (money: Money | null | undefined): string => {
+  if (!money) return '';
+  const symbol = money.currency === 'USD' ? '$' : `${money.currency} `;
+  return `${symbol}${money.amount}`;
+};
+
+// formatAmount formats a total worked out in the browser, such as a cart total
+export const formatAmount = (amount: number, currency = 'USD'): string =>
+  formatMoney({ amount: amount.toFixed(2), currency });
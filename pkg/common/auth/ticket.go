@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// TicketStore issues short-lived, single-use tickets standing in for a JWT on connections
+// that cannot send an Authorization header, such as EventSource and browser WebSockets.
+// A ticket has to go in the URL, where it may be logged, so it is only good for one
+// connection and only for a few seconds. Tickets are kept in memory and are only valid
+// on the instance that issued them.
+type TicketStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	tickets map[string]ticket
+}
+
+// ticket is an issued ticket and the claims it stands for
+type ticket struct {
+	claims    Claims
+	expiresAt time.Time
+}
+
+// NewTicketStore creates a new TicketStore whose tickets last for ttl
+func NewTicketStore(ttl time.Duration) *TicketStore {
+	return &TicketStore{
+		ttl:     ttl,
+		tickets: make(map[string]ticket),
+	}
+}
+
+// Issue issues a ticket for the holder of a validated token
+func (s *TicketStore) Issue(claims *Claims) (string, time.Time, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	value := hex.EncodeToString(b)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for value, t := range s.tickets {
+		if now.After(t.expiresAt) {
+			delete(s.tickets, value)
+		}
+	}
+
+	expiresAt := now.Add(s.ttl)
+	s.tickets[value] = ticket{claims: *claims, expiresAt: expiresAt}
+
+	return value, expiresAt, nil
+}
+
+// Redeem uses up a ticket, returning the claims it was issued for. It reports false if
+// the ticket is unknown, already used or expired.
+func (s *TicketStore) Redeem(value string) (*Claims, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tickets[value]
+	if !ok {
+		return nil, false
+	}
+	delete(s.tickets, value)
+
+	if time.Now().After(t.expiresAt) {
+		return nil, false
+	}
+
+	return &t.claims, true
+}
@@ -0,0 +1,326 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of amounts that carry none, such as prices stored in
+// the database, which are all kept in the property's base currency
+var DefaultCurrency = "USD"
+
+// Money is an exact amount of a currency, held in the currency's minor units (cents for
+// USD). Amounts of different currencies are never added together.
+type Money struct {
+	Amount   int64  // minor units
+	Currency string // ISO 4217 code
+}
+
+// minorDigits lists the currencies whose minor unit is not a hundredth
+var minorDigits = map[string]int{
+	"BHD": 3, "CLP": 0, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3,
+	"OMR": 3, "TND": 3, "UGX": 0, "VND": 0, "XAF": 0, "XOF": 0,
+}
+
+// Digits returns the number of decimal places of a currency's minor unit
+func Digits(currency string) int {
+	if digits, ok := minorDigits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// New creates an amount from minor units
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromFloat converts an amount in major units held as a float, such as a flat fee set on
+// a rule, rounding it half away from zero to the minor unit
+func FromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(Digits(currency))
+	return Money{Amount: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// Zero returns nothing of a currency
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Parse reads a decimal amount such as "12.50" or "-3" in a currency. Digits beyond the
+// currency's minor unit are only accepted when they are zeros.
+func Parse(s, currency string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return Money{}, errors.New("invalid amount")
+	}
+
+	digits := Digits(currency)
+	if len(fraction) > digits {
+		if strings.Trim(fraction[digits:], "0") != "" {
+			return Money{}, fmt.Errorf("amount has more than %d decimal places", digits)
+		}
+		fraction = fraction[:digits]
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || strings.ContainsAny(whole+fraction, "+-") {
+		return Money{}, errors.New("invalid amount")
+	}
+
+	if negative {
+		minor = -minor
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on an invalid amount. It is meant for constants.
+func MustParse(s, currency string) Money {
+	m, err := Parse(s, currency)
+	if err != nil {
+		panic("money: " + err.Error())
+	}
+	return m
+}
+
+// same returns the currency two amounts share. An amount without a currency, such as
+// the zero value, takes the other's. Amounts only come in through UnmarshalJSON and Scan,
+// which hold them to DefaultCurrency and return an error for any other, and only leave it
+// through Convert, for display, so a mismatch here is a programming error rather than bad
+// input.
+func same(a, b Money) string {
+	switch {
+	case a.Currency == b.Currency, b.Currency == "":
+		return a.Currency
+	case a.Currency == "":
+		return b.Currency
+	default:
+		panic("money: mixed currencies " + a.Currency + " and " + b.Currency)
+	}
+}
+
+// Add returns m + other
+func (m Money) Add(other Money) Money {
+	return Money{Amount: m.Amount + other.Amount, Currency: same(m, other)}
+}
+
+// Sub returns m - other
+func (m Money) Sub(other Money) Money {
+	return Money{Amount: m.Amount - other.Amount, Currency: same(m, other)}
+}
+
+// Mul returns m multiplied by a whole quantity
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}
+}
+
+// Percent returns percent of m, rounded half away from zero to the minor unit
+func (m Money) Percent(percent float64) Money {
+	return m.Scale(percent / 100)
+}
+
+// Scale returns m multiplied by factor, rounded half away from zero to the minor unit
+func (m Money) Scale(factor float64) Money {
+	return Money{Amount: int64(math.Round(float64(m.Amount) * factor)), Currency: m.Currency}
+}
+
+// Div returns m divided by a whole quantity, rounded half away from zero to the minor unit
+func (m Money) Div(quantity int64) Money {
+	if quantity == 0 {
+		return Money{Currency: m.Currency}
+	}
+	return m.Scale(1 / float64(quantity))
+}
+
+// Convert converts m into another currency at rate, the units of currency that one unit
+// of m's currency buys, rounding half away from zero to the target's minor unit
+func (m Money) Convert(currency string, rate float64) Money {
+	shift := math.Pow10(Digits(currency) - Digits(m.currency()))
+	return Money{Amount: int64(math.Round(float64(m.Amount) * rate * shift)), Currency: currency}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// IsZero reports whether m is nothing
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// IsNegative reports whether m is below zero
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// IsPositive reports whether m is above zero
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Cmp compares m with other, returning -1, 0 or +1
+func (m Money) Cmp(other Money) int {
+	same(m, other)
+	switch {
+	case m.Amount < other.Amount:
+		return -1
+	case m.Amount > other.Amount:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min returns the smaller of m and other
+func (m Money) Min(other Money) Money {
+	if m.Cmp(other) > 0 {
+		return other
+	}
+	return m
+}
+
+// Max returns the larger of m and other
+func (m Money) Max(other Money) Money {
+	if m.Cmp(other) < 0 {
+		return other
+	}
+	return m
+}
+
+// Decimal formats m as a plain decimal such as "12.50"
+func (m Money) Decimal() string {
+	digits := Digits(m.currency())
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	s := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// String formats m with its currency, such as "USD 12.50"
+func (m Money) String() string {
+	return m.currency() + " " + m.Decimal()
+}
+
+// currency returns m's currency, or DefaultCurrency when it has none
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// jsonMoney is the JSON form of an amount
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes m as {"amount": "12.50", "currency": "USD"}, keeping the amount a
+// string so that clients do not read it into a float
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Decimal(), Currency: m.currency()})
+}
+
+// UnmarshalJSON decodes an amount written as {"amount": "12.50", "currency": "USD"}, or as
+// a bare number or decimal string in DefaultCurrency. Amounts are only ever held in
+// DefaultCurrency, so any other currency is rejected here rather than left to panic when
+// the amount is later added to or compared with another.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '{' {
+		var v struct {
+			Amount   json.RawMessage `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+
+		currency := strings.ToUpper(strings.TrimSpace(v.Currency))
+		if currency == "" {
+			currency = DefaultCurrency
+		}
+		if currency != DefaultCurrency {
+			return fmt.Errorf("unsupported currency %s; amounts are in %s", currency, DefaultCurrency)
+		}
+
+		parsed, err := Parse(strings.Trim(string(v.Amount), `"`), currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+
+	parsed, err := Parse(strings.Trim(string(data), `"`), DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer so an amount can be stored in a DECIMAL column. Only
+// amounts in DefaultCurrency are stored.
+func (m Money) Value() (driver.Value, error) {
+	if m.currency() != DefaultCurrency {
+		return nil, fmt.Errorf("money: cannot store an amount in %s", m.Currency)
+	}
+	return m.Decimal(), nil
+}
+
+// Scan implements sql.Scanner so an amount can be read from a DECIMAL column, in
+// DefaultCurrency
+func (m *Money) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*m = Zero(DefaultCurrency)
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', Digits(DefaultCurrency), 64)
+	default:
+		return errors.New("unsupported type for money")
+	}
+
+	parsed, err := Parse(s, DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,223 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAndString(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		want     Money
+		str      string
+		wantErr  bool
+	}{
+		{input: "12.50", currency: "USD", want: New(1250, "USD"), str: "USD 12.50"},
+		{input: "12.5", currency: "USD", want: New(1250, "USD"), str: "USD 12.50"},
+		{input: " 12 ", currency: "USD", want: New(1200, "USD"), str: "USD 12.00"},
+		{input: "+2", currency: "USD", want: New(200, "USD"), str: "USD 2.00"},
+		{input: ".5", currency: "USD", want: New(50, "USD"), str: "USD 0.50"},
+		{input: "-3", currency: "USD", want: New(-300, "USD"), str: "USD -3.00"},
+		{input: "-0.05", currency: "USD", want: New(-5, "USD"), str: "USD -0.05"},
+		{input: "12.500", currency: "USD", want: New(1250, "USD"), str: "USD 12.50"},
+		{input: "1000", currency: "JPY", want: New(1000, "JPY"), str: "JPY 1000"},
+		{input: "1.234", currency: "KWD", want: New(1234, "KWD"), str: "KWD 1.234"},
+		{input: "12.505", currency: "USD", wantErr: true},
+		{input: "1.5", currency: "JPY", wantErr: true},
+		{input: "", currency: "USD", wantErr: true},
+		{input: ".", currency: "USD", wantErr: true},
+		{input: "abc", currency: "USD", wantErr: true},
+		{input: "1.-5", currency: "USD", wantErr: true},
+		{input: "--1", currency: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input, tt.currency)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q, %s) = %v, want an error", tt.input, tt.currency, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q, %s) failed: %v", tt.input, tt.currency, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q, %s) = %#v, want %#v", tt.input, tt.currency, got, tt.want)
+		}
+		if got.String() != tt.str {
+			t.Errorf("Parse(%q, %s).String() = %q, want %q", tt.input, tt.currency, got.String(), tt.str)
+		}
+	}
+}
+
+func TestPercentAndScaleRoundHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want int64
+	}{
+		{name: "10% of 12.50", got: New(1250, "USD").Percent(10), want: 125},
+		{name: "15% of 19.99", got: New(1999, "USD").Percent(15), want: 300},
+		{name: "half a cent up", got: New(1005, "USD").Percent(50), want: 503},
+		{name: "half a cent down when negative", got: New(-1005, "USD").Percent(50), want: -503},
+		{name: "a third", got: New(100, "USD").Scale(1.0 / 3), want: 33},
+		{name: "two thirds", got: New(200, "USD").Div(3), want: 67},
+		{name: "divided by nothing", got: New(200, "USD").Div(0), want: 0},
+	}
+
+	for _, tt := range tests {
+		if tt.got.Amount != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got.Amount, tt.want)
+		}
+		if tt.got.Currency != "USD" {
+			t.Errorf("%s changed the currency to %q", tt.name, tt.got.Currency)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		currency string
+		rate     float64
+		want     Money
+	}{
+		{name: "to a currency with cents", amount: New(1000, "USD"), currency: "EUR", rate: 0.9, want: New(900, "EUR")},
+		{name: "to a currency without minor units", amount: New(1000, "USD"), currency: "JPY", rate: 150, want: New(1500, "JPY")},
+		{name: "from a currency without minor units", amount: New(1500, "JPY"), currency: "USD", rate: 0.0067, want: New(1005, "USD")},
+		{name: "to a currency with three decimals", amount: New(1000, "USD"), currency: "KWD", rate: 0.307, want: New(3070, "KWD")},
+		{name: "half a cent", amount: New(1, "USD"), currency: "EUR", rate: 0.5, want: New(1, "EUR")},
+		{name: "without a currency", amount: New(1000, ""), currency: "EUR", rate: 0.9, want: New(900, "EUR")},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.Convert(tt.currency, tt.rate); got != tt.want {
+			t.Errorf("%s: Convert = %#v, want %#v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArithmeticTakesTheCurrencyOfTheZeroValue(t *testing.T) {
+	if got := (Money{}).Add(New(150, "USD")); got != New(150, "USD") {
+		t.Errorf("zero value + USD 1.50 = %#v, want USD 1.50", got)
+	}
+	if got := New(150, "USD").Sub(Money{}); got != New(150, "USD") {
+		t.Errorf("USD 1.50 - zero value = %#v, want USD 1.50", got)
+	}
+	if got := New(150, "USD").Max(Money{}); got != New(150, "USD") {
+		t.Errorf("max of USD 1.50 and the zero value = %#v, want USD 1.50", got)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	data, err := json.Marshal(New(1250, "USD"))
+	if err != nil {
+		t.Fatalf("marshalling failed: %v", err)
+	}
+	if string(data) != `{"amount":"12.50","currency":"USD"}` {
+		t.Errorf("marshalled to %s", data)
+	}
+
+	var decoded Money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshalling failed: %v", err)
+	}
+	if decoded != New(1250, "USD") {
+		t.Errorf("round trip gave %#v", decoded)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: `12.5`, want: New(1250, DefaultCurrency)},
+		{input: `"12.50"`, want: New(1250, DefaultCurrency)},
+		{input: `null`, want: Money{}},
+		{input: `{"amount":12.5,"currency":"usd"}`, want: New(1250, "USD")},
+		{input: `{"amount":"12.50"}`, want: New(1250, DefaultCurrency)},
+		// Amounts are only held in the base currency, so mixed currencies never get in
+		{input: `{"amount":"12.50","currency":"EUR"}`, wantErr: true},
+		{input: `{"amount":"12.505","currency":"USD"}`, wantErr: true},
+		{input: `"twelve"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(tt.input), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("unmarshalling %s gave %#v, want an error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unmarshalling %s failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("unmarshalling %s gave %#v, want %#v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	value, err := New(1250, "USD").Value()
+	if err != nil {
+		t.Fatalf("storing failed: %v", err)
+	}
+	if value != "12.50" {
+		t.Errorf("stored as %#v, want \"12.50\"", value)
+	}
+
+	var scanned Money
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("reading failed: %v", err)
+	}
+	if scanned != New(1250, "USD") {
+		t.Errorf("round trip gave %#v", scanned)
+	}
+
+	if _, err := New(1250, "EUR").Value(); err == nil {
+		t.Error("storing an amount in EUR was accepted")
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    Money
+		wantErr bool
+	}{
+		{src: []byte("12.50"), want: New(1250, DefaultCurrency)},
+		{src: "0.05", want: New(5, DefaultCurrency)},
+		{src: int64(3), want: New(300, DefaultCurrency)},
+		{src: float64(12.5), want: New(1250, DefaultCurrency)},
+		{src: nil, want: Zero(DefaultCurrency)},
+		{src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := got.Scan(tt.src)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("scanning %#v gave %#v, want an error", tt.src, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("scanning %#v failed: %v", tt.src, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("scanning %#v gave %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+}
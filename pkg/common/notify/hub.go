@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// clientBuffer is how many events a connection may fall behind before it is dropped
+const clientBuffer = 32
+
+// Event is a change to something a user owns, such as a booking or a food order
+type Event struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Hub fans events out to the connections of the user they are addressed to. A user may
+// have several connections open at once, one per device or tab.
+type Hub struct {
+	mu             sync.Mutex
+	clients        map[string]map[chan Event]struct{}
+	allowedOrigins []string
+}
+
+// NewHub creates a new Hub accepting WebSockets opened by pages served from the same
+// host or from one of allowedOrigins, such as "https://app.example.com"
+func NewHub(allowedOrigins []string) *Hub {
+	return &Hub{
+		clients:        make(map[string]map[chan Event]struct{}),
+		allowedOrigins: allowedOrigins,
+	}
+}
+
+// Subscribe starts receiving the events of a user. The returned function unsubscribes
+// and may be called more than once.
+func (h *Hub) Subscribe(userID string) (<-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan Event, clientBuffer)
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[chan Event]struct{})
+	}
+	h.clients[userID][ch] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.remove(userID, ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends an event to every connection of a user. It never blocks: a connection too
+// far behind to take the event is dropped, closing its channel.
+func (h *Hub) Publish(userID string, event Event) {
+	if userID == "" {
+		return
+	}
+
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.clients[userID] {
+		select {
+		case ch <- event:
+		default:
+			h.remove(userID, ch)
+		}
+	}
+}
+
+// Broadcast sends an event to every connection of every user, for changes that cannot be
+// traced to the users they concern. Like Publish it never blocks.
+func (h *Hub) Broadcast(event Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userID, clients := range h.clients {
+		for ch := range clients {
+			select {
+			case ch <- event:
+			default:
+				h.remove(userID, ch)
+			}
+		}
+	}
+}
+
+// remove closes a connection's channel and forgets it, along with the user once they have
+// no connections left. The caller holds the lock.
+func (h *Hub) remove(userID string, ch chan Event) {
+	clients, ok := h.clients[userID]
+	if !ok {
+		return
+	}
+
+	if _, ok := clients[ch]; !ok {
+		return
+	}
+
+	delete(clients, ch)
+	close(ch)
+
+	if len(clients) == 0 {
+		delete(h.clients, userID)
+	}
+}
@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// writeTimeout is how long a write may take before the connection is given up on
+	writeTimeout = 10 * time.Second
+
+	// pingInterval is how often an idle connection is pinged to keep proxies from closing it
+	pingInterval = 30 * time.Second
+)
+
+// Handler returns an http.Handler that upgrades a request to a WebSocket and sends it the
+// user's events as JSON until either side hangs up. The caller authenticates the user.
+func (h *Hub) Handler(userID string) http.Handler {
+	return websocket.Server{
+		Handshake: h.checkOrigin,
+		Handler: func(conn *websocket.Conn) {
+			h.serve(conn, userID)
+		},
+	}
+}
+
+// checkOrigin refuses WebSockets opened by pages on other sites, which a browser holding
+// the user's credentials would otherwise let them do. Browsers always send an Origin;
+// clients without one, such as mobile apps, are not at risk and are let through.
+func (h *Hub) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("invalid origin %q", origin)
+	}
+
+	if strings.EqualFold(parsed.Host, req.Host) {
+		return nil
+	}
+
+	for _, allowed := range h.allowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("origin %s is not allowed", origin)
+}
+
+// serve streams a user's events to a connection. Messages from the client are read and
+// discarded so that a close or a dead connection is noticed.
+func (h *Hub) serve(conn *websocket.Conn, userID string) {
+	events, unsubscribe := h.Subscribe(userID)
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+
+		var message []byte
+		for {
+			if err := websocket.Message.Receive(conn, &message); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			// The hub drops connections that fall too far behind; the client reconnects
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := websocket.JSON.Send(conn, event); err != nil {
+				return
+			}
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			conn.PayloadType = websocket.PingFrame
+			_, err := conn.Write(nil)
+			conn.PayloadType = websocket.TextFrame
+			if err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/google/uuid"
+)
+
+// Tokens the fake gateway declines. Any other non-empty token is authorized.
+const (
+	FakeTokenDeclined          = "tok_declined"
+	FakeTokenInsufficientFunds = "tok_insufficient_funds"
+)
+
+// FakeGateway is a Gateway for development and tests that moves no money. It keeps its
+// transactions in memory, so they do not survive a restart.
+type FakeGateway struct {
+	mu           sync.Mutex
+	transactions map[string]Transaction
+}
+
+// NewFakeGateway creates a new FakeGateway
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{
+		transactions: make(map[string]Transaction),
+	}
+}
+
+// Name identifies the fake gateway in stored payments
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+// Authorize holds an amount unless the token is one of the declined test tokens
+func (g *FakeGateway) Authorize(req AuthorizeRequest) (Transaction, error) {
+	if req.Token == "" {
+		return Transaction{}, errors.New("payment token is required")
+	}
+
+	if !req.Amount.IsPositive() {
+		return Transaction{}, errors.New("amount must be positive")
+	}
+
+	switch req.Token {
+	case FakeTokenDeclined:
+		return Transaction{}, fmt.Errorf("%w: card declined", ErrDeclined)
+	case FakeTokenInsufficientFunds:
+		return Transaction{}, fmt.Errorf("%w: insufficient funds", ErrDeclined)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction := Transaction{
+		ID:       "fake_" + uuid.New().String(),
+		Status:   StatusAuthorized,
+		Amount:   req.Amount,
+		Captured: money.Zero(req.Amount.Currency),
+		Refunded: money.Zero(req.Amount.Currency),
+	}
+	g.transactions[transaction.ID] = transaction
+
+	return transaction, nil
+}
+
+// Capture takes an amount of an authorization
+func (g *FakeGateway) Capture(transactionID string, amount money.Money) (Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionID]
+	if !ok {
+		return Transaction{}, errors.New("transaction not found")
+	}
+
+	if transaction.Status != StatusAuthorized {
+		return Transaction{}, errors.New("transaction is not authorized")
+	}
+
+	if !amount.IsPositive() || amount.Cmp(transaction.Amount) > 0 {
+		return Transaction{}, errors.New("capture must be positive and no more than the authorized amount")
+	}
+
+	transaction.Status = StatusCaptured
+	transaction.Captured = amount
+	g.transactions[transactionID] = transaction
+
+	return transaction, nil
+}
+
+// Refund returns an amount of a capture
+func (g *FakeGateway) Refund(transactionID string, amount money.Money) (Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionID]
+	if !ok {
+		return Transaction{}, errors.New("transaction not found")
+	}
+
+	if transaction.Status != StatusCaptured && transaction.Status != StatusPartiallyRefunded {
+		return Transaction{}, errors.New("transaction is not captured")
+	}
+
+	if !amount.IsPositive() || amount.Cmp(transaction.Captured.Sub(transaction.Refunded)) > 0 {
+		return Transaction{}, errors.New("refund must be positive and no more than the captured amount")
+	}
+
+	transaction.Refunded = transaction.Refunded.Add(amount)
+	transaction.Status = StatusPartiallyRefunded
+	if transaction.Refunded.Cmp(transaction.Captured) == 0 {
+		transaction.Status = StatusRefunded
+	}
+	g.transactions[transactionID] = transaction
+
+	return transaction, nil
+}
+
+// Void releases an authorization
+func (g *FakeGateway) Void(transactionID string) (Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	transaction, ok := g.transactions[transactionID]
+	if !ok {
+		return Transaction{}, errors.New("transaction not found")
+	}
+
+	if transaction.Status != StatusAuthorized {
+		return Transaction{}, errors.New("transaction is not authorized")
+	}
+
+	transaction.Status = StatusVoided
+	g.transactions[transactionID] = transaction
+
+	return transaction, nil
+}
@@ -0,0 +1,70 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Payment statuses. An authorization holds funds on the guest's payment method; capturing
+// takes some or all of them, and voiding releases an authorization that was not captured.
+const (
+	StatusUnpaid            = "unpaid"
+	StatusAuthorized        = "authorized"
+	StatusCaptured          = "captured"
+	StatusPartiallyRefunded = "partially_refunded"
+	StatusRefunded          = "refunded"
+	StatusVoided            = "voided"
+	StatusFailed            = "failed"
+)
+
+// ErrDeclined is returned, wrapped with the gateway's reason, when a payment method is declined
+var ErrDeclined = errors.New("payment declined")
+
+// AuthorizeRequest asks a gateway to hold an amount on a payment method
+type AuthorizeRequest struct {
+	Amount    money.Money
+	Token     string // the payment method, as tokenized by the gateway's client library
+	Reference string // the booking or order being paid for
+}
+
+// Transaction is the gateway's view of a payment
+type Transaction struct {
+	ID       string
+	Status   string
+	Amount   money.Money // authorized
+	Captured money.Money
+	Refunded money.Money
+}
+
+// Gateway is a payment provider. Every call either succeeds with the transaction's new
+// state or fails leaving it unchanged.
+type Gateway interface {
+	// Name identifies the gateway in stored payments
+	Name() string
+
+	// Authorize holds an amount on a payment method
+	Authorize(req AuthorizeRequest) (Transaction, error)
+
+	// Capture takes an amount of an authorization, releasing the rest
+	Capture(transactionID string, amount money.Money) (Transaction, error)
+
+	// Refund returns an amount of a capture
+	Refund(transactionID string, amount money.Money) (Transaction, error)
+
+	// Void releases an authorization that was not captured
+	Void(transactionID string) (Transaction, error)
+}
+
+// NewGateway creates the gateway of the given name. Only the fake gateway is built in;
+// a provider's gateway is added here alongside it. There is no default, so that a
+// deployment never falls back to the fake gateway because it was left unconfigured.
+func NewGateway(name string) (Gateway, error) {
+	switch name {
+	case "fake":
+		return NewFakeGateway(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment gateway %q", name)
+	}
+}
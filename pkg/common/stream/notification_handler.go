@@ -0,0 +1,40 @@
+package stream
+
+import (
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/labstack/echo/v4"
+)
+
+// NotificationHandler handles the WebSocket that pushes a user's events from a hub, such
+// as changes to their bookings or food orders
+type NotificationHandler struct {
+	hub       *notify.Hub
+	tickets   *auth.TicketStore
+	jwtSecret string
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(hub *notify.Hub, tickets *auth.TicketStore, jwtSecret string) *NotificationHandler {
+	return &NotificationHandler{
+		hub:       hub,
+		tickets:   tickets,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// Connect handles upgrading to the notification WebSocket
+func (h *NotificationHandler) Connect(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	h.hub.Handler(userID).ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// RegisterRoutes registers the routes for the notification handler
+func (h *NotificationHandler) RegisterRoutes(g *echo.Group) {
+	notifications := g.Group("/notifications")
+	notifications.Use(Middleware(h.tickets, h.jwtSecret))
+
+	notifications.GET("/ws", h.Connect)
+}
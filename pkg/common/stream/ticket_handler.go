@@ -0,0 +1,65 @@
+package stream
+
+import (
+	"net/http"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/labstack/echo/v4"
+)
+
+// TicketHandler handles HTTP requests for stream tickets
+type TicketHandler struct {
+	tickets   *auth.TicketStore
+	jwtSecret string
+}
+
+// NewTicketHandler creates a new TicketHandler
+func NewTicketHandler(tickets *auth.TicketStore, jwtSecret string) *TicketHandler {
+	return &TicketHandler{
+		tickets:   tickets,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// IssueTicket handles exchanging the bearer token for a short-lived, single-use ticket to
+// open a stream with
+func (h *TicketHandler) IssueTicket(c echo.Context) error {
+	claims := &auth.Claims{
+		UserID:   c.Get("user_id").(string),
+		Username: c.Get("username").(string),
+		Role:     c.Get("role").(string),
+	}
+
+	ticket, expiresAt, err := h.tickets.Issue(claims)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to issue ticket",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Ticket issued successfully",
+		"data": map[string]interface{}{
+			"ticket":     ticket,
+			"expires_at": expiresAt,
+		},
+	})
+}
+
+// RegisterRoutes registers the routes for the ticket handler. A ticket is only issued for
+// a bearer token, never for another ticket.
+func (h *TicketHandler) RegisterRoutes(g *echo.Group) {
+	tickets := g.Group("/stream-tickets")
+	tickets.Use(h.authMiddleware)
+
+	tickets.POST("", h.IssueTicket)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *TicketHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return bearerAuth(c, h.jwtSecret, next)
+	}
+}
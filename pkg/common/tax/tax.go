@@ -0,0 +1,158 @@
+package tax
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Rule kinds. Service charges are levied on the price; taxes are levied on the price
+// plus any service charge, as the service charge is itself taxable.
+const (
+	KindTax           = "tax"
+	KindServiceCharge = "service_charge"
+)
+
+// Rule is a percentage tax or service charge in force from EffectiveFrom up to, but not
+// including, EffectiveTo. A rule without an end date stays in force.
+type Rule struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Kind          string     `json:"kind"` // tax, service_charge
+	Rate          float64    `json:"rate"` // percent
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Line is the amount one rule levies on a price
+type Line struct {
+	RuleID string      `json:"rule_id"`
+	Name   string      `json:"name"`
+	Kind   string      `json:"kind"`
+	Rate   float64     `json:"rate"` // percent
+	Base   money.Money `json:"base"`
+	Amount money.Money `json:"amount"`
+}
+
+// Breakdown is the taxes and service charges levied on a price, stored with each priced
+// record so that later rule changes do not alter it
+type Breakdown struct {
+	Lines []Line      `json:"lines"`
+	Total money.Money `json:"total"`
+}
+
+// Validate checks a rule's name, kind, rate and dates
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if r.Kind != KindTax && r.Kind != KindServiceCharge {
+		return errors.New("kind must be tax or service_charge")
+	}
+
+	if r.Rate <= 0 || r.Rate > 100 {
+		return errors.New("rate must be a percentage between 0 and 100")
+	}
+
+	if r.EffectiveFrom.IsZero() {
+		return errors.New("effective from date is required")
+	}
+
+	if r.EffectiveTo != nil && !r.EffectiveTo.After(r.EffectiveFrom) {
+		return errors.New("effective to date must be after effective from date")
+	}
+
+	return nil
+}
+
+// EffectiveOn reports whether the rule is in force on date
+func (r Rule) EffectiveOn(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if day < r.EffectiveFrom.Format("2006-01-02") {
+		return false
+	}
+	return r.EffectiveTo == nil || day < r.EffectiveTo.Format("2006-01-02")
+}
+
+// Apply levies the rules in force on date on price, rounding each levy to the minor unit
+func Apply(rules []Rule, price money.Money, date time.Time) Breakdown {
+	breakdown := Breakdown{Lines: []Line{}, Total: money.Zero(price.Currency)}
+
+	// Service charges first, as they are part of the base the taxes are levied on
+	taxBase := price
+	for _, rule := range rules {
+		if rule.Kind != KindServiceCharge || !rule.EffectiveOn(date) {
+			continue
+		}
+		line := Line{RuleID: rule.ID, Name: rule.Name, Kind: rule.Kind, Rate: rule.Rate, Base: price, Amount: price.Percent(rule.Rate)}
+		breakdown.Lines = append(breakdown.Lines, line)
+		taxBase = taxBase.Add(line.Amount)
+	}
+
+	for _, rule := range rules {
+		if rule.Kind != KindTax || !rule.EffectiveOn(date) {
+			continue
+		}
+		line := Line{RuleID: rule.ID, Name: rule.Name, Kind: rule.Kind, Rate: rule.Rate, Base: taxBase, Amount: taxBase.Percent(rule.Rate)}
+		breakdown.Lines = append(breakdown.Lines, line)
+	}
+
+	for _, line := range breakdown.Lines {
+		breakdown.Total = breakdown.Total.Add(line.Amount)
+	}
+
+	return breakdown
+}
+
+// Add combines two breakdowns, adding up the lines of the same rule, as when the nights
+// of a stay are taxed one by one
+func (b Breakdown) Add(other Breakdown) Breakdown {
+	sum := Breakdown{Lines: append([]Line{}, b.Lines...), Total: b.Total}
+	for _, line := range other.Lines {
+		merged := false
+		for i := range sum.Lines {
+			if sum.Lines[i].RuleID == line.RuleID {
+				sum.Lines[i].Base = sum.Lines[i].Base.Add(line.Base)
+				sum.Lines[i].Amount = sum.Lines[i].Amount.Add(line.Amount)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			sum.Lines = append(sum.Lines, line)
+		}
+	}
+	sum.Total = sum.Total.Add(other.Total)
+
+	return sum
+}
+
+// Value implements driver.Valuer so a breakdown can be stored as JSONB
+func (b Breakdown) Value() (driver.Value, error) {
+	if b.Lines == nil {
+		b.Lines = []Line{}
+	}
+	return json.Marshal(b)
+}
+
+// Scan implements sql.Scanner so a breakdown can be read from JSONB
+func (b *Breakdown) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*b = Breakdown{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, b)
+	case string:
+		return json.Unmarshal([]byte(v), b)
+	default:
+		return errors.New("unsupported type for tax breakdown")
+	}
+}
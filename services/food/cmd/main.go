@@ -4,10 +4,17 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
 	"github.com/flaminshinjan/address.ai/pkg/common/db"
 	"github.com/flaminshinjan/address.ai/pkg/common/logger"
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
 	"github.com/flaminshinjan/address.ai/services/food/internal/handler"
+	"github.com/flaminshinjan/address.ai/services/food/internal/pubsub"
 	"github.com/flaminshinjan/address.ai/services/food/internal/repository"
 	"github.com/flaminshinjan/address.ai/services/food/internal/service"
 	"github.com/joho/godotenv"
@@ -15,6 +22,9 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// streamTicketTTL is how long a stream ticket can be used for
+const streamTicketTTL = 30 * time.Second
+
 // @title Food Management Service API
 // @version 1.0
 // @description This is the food management service API for the hotel management system
@@ -56,6 +66,23 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
 
+	// Prices are stored in the property's base currency
+	if baseCurrency := os.Getenv("BASE_CURRENCY"); baseCurrency != "" {
+		money.DefaultCurrency = strings.ToUpper(baseCurrency)
+	}
+
+	// Card payments go through the configured gateway; the fake gateway moves no money
+	paymentGatewayName := os.Getenv("PAYMENT_GATEWAY")
+	if paymentGatewayName == "" {
+		log.Fatal("PAYMENT_GATEWAY environment variable is required")
+	}
+
+	// Pages on other origins allowed to open WebSockets, comma-separated
+	allowedOrigins := os.Getenv("ALLOWED_ORIGINS")
+	if allowedOrigins == "" {
+		allowedOrigins = "http://localhost:3000" // Default origin of the frontend dev server
+	}
+
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
 		logLevel = "info" // Default log level
@@ -74,13 +101,33 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	paymentGateway, err := payment.NewGateway(paymentGatewayName)
+	if err != nil {
+		log.Fatalf("Failed to set up payment gateway: %v", err)
+	}
+
 	// Initialize repositories
 	menuRepo := repository.NewMenuRepository(database)
 	orderRepo := repository.NewOrderRepository(database)
+	taxRuleRepo := repository.NewTaxRuleRepository(database)
+	currencyRateRepo := repository.NewCurrencyRateRepository(database)
+
+	// Order changes are fanned out in process to the kitchen feed, which remembers the
+	// most recent so that reconnecting clients can resume
+	broker := pubsub.NewBroker(1000)
+
+	// Guests are told over their notification WebSocket when their orders move
+	hub := notify.NewHub(strings.Split(strings.ReplaceAll(allowedOrigins, " ", ""), ","))
+	go broker.Forward(hub)
+
+	// Streams opened by clients that cannot send the Authorization header authenticate
+	// with a single-use ticket rather than the token, which would end up in access logs
+	tickets := auth.NewTicketStore(streamTicketTTL)
 
 	// Initialize services
-	menuService := service.NewMenuService(menuRepo)
-	orderService := service.NewOrderService(orderRepo, menuRepo)
+	menuService := service.NewMenuService(menuRepo, currencyRateRepo)
+	orderService := service.NewOrderService(orderRepo, menuRepo, taxRuleRepo, paymentGateway, broker)
+	taxService := service.NewTaxService(taxRuleRepo)
 
 	// Initialize Echo
 	e := echo.New()
@@ -95,7 +142,7 @@ func main() {
 	e.Use(middleware.CORS())
 
 	// Initialize handlers
-	h := handler.NewHandler(menuService, orderService, jwtSecret)
+	h := handler.NewHandler(menuService, orderService, taxService, broker, hub, tickets, jwtSecret)
 
 	// Register routes
 	api := e.Group("/api/v1")
@@ -1,25 +1,41 @@
 package handler
 
 import (
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/pkg/common/stream"
+	"github.com/flaminshinjan/address.ai/services/food/internal/pubsub"
 	"github.com/flaminshinjan/address.ai/services/food/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 // Handler is the main handler for the food service
 type Handler struct {
-	MenuHandler  *MenuHandler
-	OrderHandler *OrderHandler
+	MenuHandler         *MenuHandler
+	OrderHandler        *OrderHandler
+	TaxHandler          *TaxHandler
+	KitchenHandler      *KitchenHandler
+	NotificationHandler *stream.NotificationHandler
+	TicketHandler       *stream.TicketHandler
 }
 
 // NewHandler creates a new Handler
 func NewHandler(
 	menuService *service.MenuService,
 	orderService *service.OrderService,
+	taxService *service.TaxService,
+	broker *pubsub.Broker,
+	hub *notify.Hub,
+	tickets *auth.TicketStore,
 	jwtSecret string,
 ) *Handler {
 	return &Handler{
-		MenuHandler:  NewMenuHandler(menuService, jwtSecret),
-		OrderHandler: NewOrderHandler(orderService, jwtSecret),
+		MenuHandler:         NewMenuHandler(menuService, jwtSecret),
+		OrderHandler:        NewOrderHandler(orderService, jwtSecret),
+		TaxHandler:          NewTaxHandler(taxService, jwtSecret),
+		KitchenHandler:      NewKitchenHandler(broker, tickets, jwtSecret),
+		NotificationHandler: stream.NewNotificationHandler(hub, tickets, jwtSecret),
+		TicketHandler:       stream.NewTicketHandler(tickets, jwtSecret),
 	}
 }
 
@@ -30,4 +46,16 @@ func (h *Handler) RegisterRoutes(g *echo.Group) {
 
 	// Register order routes
 	h.OrderHandler.RegisterRoutes(g)
+
+	// Register tax rule routes
+	h.TaxHandler.RegisterRoutes(g)
+
+	// Register kitchen feed routes
+	h.KitchenHandler.RegisterRoutes(g)
+
+	// Register notification routes
+	h.NotificationHandler.RegisterRoutes(g)
+
+	// Register stream ticket routes
+	h.TicketHandler.RegisterRoutes(g)
 }
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/stream"
+	"github.com/flaminshinjan/address.ai/services/food/internal/model"
+	"github.com/flaminshinjan/address.ai/services/food/internal/pubsub"
+	"github.com/labstack/echo/v4"
+)
+
+// feedHeartbeat is how often an idle feed sends a comment to keep proxies from closing it
+const feedHeartbeat = 15 * time.Second
+
+// KitchenHandler handles HTTP requests for the live kitchen order feed
+type KitchenHandler struct {
+	broker    *pubsub.Broker
+	tickets   *auth.TicketStore
+	jwtSecret string
+}
+
+// NewKitchenHandler creates a new KitchenHandler
+func NewKitchenHandler(broker *pubsub.Broker, tickets *auth.TicketStore, jwtSecret string) *KitchenHandler {
+	return &KitchenHandler{
+		broker:    broker,
+		tickets:   tickets,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// feedFilter selects the events a feed client asked for
+type feedFilter struct {
+	statuses map[string]bool
+	station  string
+}
+
+// matches reports whether an event passes the filter. An update passes when the order
+// either had or now has one of the statuses asked for, so that a client sees an order
+// leave its list as well as join it. An order is on a station when any of its items is
+// prepared there.
+func (f feedFilter) matches(event pubsub.Event) bool {
+	if len(f.statuses) > 0 && !f.statuses[event.Order.Status] && !f.statuses[event.PreviousStatus] {
+		return false
+	}
+
+	if f.station == "" {
+		return true
+	}
+
+	for _, item := range event.Order.Items {
+		if item.MenuItem.Station == f.station {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OrderFeed handles streaming new and updated orders as Server-Sent Events
+// @Summary Stream food orders
+// @Description Stream new and updated food orders as Server-Sent Events, optionally filtered by status or station. An update is sent when the order had or now has one of the statuses, so an order whose status is no longer asked for should be dropped from the client's list. Reconnecting clients resume after the Last-Event-ID header or last_event_id parameter. A ticket is good for one connection, so an EventSource opened with a ticket cannot reconnect by itself: when it fails, the client should fetch a new ticket and open a new stream with last_event_id set to the last event it received. A reset event means events were missed and the open orders should be refetched.
+// @Tags kitchen
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param ticket query string false "Stream ticket, for clients that cannot set the Authorization header"
+// @Param status query string false "Comma-separated order statuses"
+// @Param station query string false "Kitchen station"
+// @Param last_event_id query int false "Resume after this event ID"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /kitchen/orders/stream [get]
+func (h *KitchenHandler) OrderFeed(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	filter := feedFilter{station: strings.TrimSpace(c.QueryParam("station"))}
+	if statuses := c.QueryParam("status"); statuses != "" {
+		filter.statuses = make(map[string]bool)
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.TrimSpace(status)
+			if !model.ValidOrderStatus(status) {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{
+					"success": false,
+					"error":   "Invalid status: " + status,
+				})
+			}
+			filter.statuses[status] = true
+		}
+	}
+
+	// Clients that authenticate with a header can resume with Last-Event-ID; a ticket is used
+	// up by the first connection, so clients using tickets reconnect with a new ticket and
+	// last_event_id instead
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	var after uint64
+	if lastEventID != "" {
+		parsed, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid last event ID",
+			})
+		}
+		after = parsed
+	}
+
+	backlog, events, unsubscribe := h.broker.Subscribe(after)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	for _, event := range backlog {
+		if event.Type != pubsub.Reset && !filter.matches(event) {
+			continue
+		}
+		if err := writeEvent(res, event); err != nil {
+			return nil
+		}
+	}
+
+	heartbeat := time.NewTicker(feedHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			// The broker drops clients that fall too far behind; they reconnect and resume
+			if !ok {
+				return nil
+			}
+			if !filter.matches(event) {
+				continue
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// writeEvent writes an event in the Server-Sent Events format and flushes it to the client.
+// A reset carries no order.
+func writeEvent(res *echo.Response, event pubsub.Event) error {
+	data := []byte("{}")
+	if event.Type != pubsub.Reset {
+		var err error
+		data, err = json.Marshal(event.Order)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+		return err
+	}
+	res.Flush()
+
+	return nil
+}
+
+// RegisterRoutes registers the routes for the kitchen handler
+func (h *KitchenHandler) RegisterRoutes(g *echo.Group) {
+	kitchen := g.Group("/kitchen")
+	kitchen.Use(stream.Middleware(h.tickets, h.jwtSecret))
+
+	kitchen.GET("/orders/stream", h.OrderFeed)
+}
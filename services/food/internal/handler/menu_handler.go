@@ -196,7 +196,9 @@ func (h *MenuHandler) DeleteMenuItem(c echo.Context) error {
 // @Produce json
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
+// @Param currency query string false "Currency to show prices in"
 // @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
 // @Failure 500 {object} response.Response
 // @Router /menu [get]
 func (h *MenuHandler) ListMenuItems(c echo.Context) error {
@@ -220,7 +222,7 @@ func (h *MenuHandler) ListMenuItems(c echo.Context) error {
 		}
 	}
 
-	var items interface{}
+	var items []model.MenuItemResponse
 	var err error
 
 	if category != "" {
@@ -236,6 +238,13 @@ func (h *MenuHandler) ListMenuItems(c echo.Context) error {
 		})
 	}
 
+	if err := h.service.ConvertPrices(items, c.QueryParam("currency")); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success": true,
 		"message": "Menu items retrieved successfully",
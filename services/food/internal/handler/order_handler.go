@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
 	"github.com/flaminshinjan/address.ai/services/food/internal/model"
 	"github.com/flaminshinjan/address.ai/services/food/internal/service"
 	"github.com/labstack/echo/v4"
@@ -39,7 +42,11 @@ func (h *OrderHandler) CreateOrder(c echo.Context) error {
 
 	createdOrder, err := h.service.CreateOrder(userID, order)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+		status := http.StatusBadRequest
+		if errors.Is(err, payment.ErrDeclined) {
+			status = http.StatusPaymentRequired
+		}
+		return c.JSON(status, map[string]interface{}{
 			"success": false,
 			"error":   err.Error(),
 		})
@@ -69,7 +76,7 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	}
 
 	// Check if user is authorized to view this order
-	if role != "admin" && order.UserID != userID {
+	if role != "admin" && role != "staff" && order.UserID != userID {
 		return c.JSON(http.StatusForbidden, map[string]interface{}{
 			"success": false,
 			"error":   "You are not authorized to view this order",
@@ -113,9 +120,10 @@ func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
 		})
 	}
 
-	err := h.service.UpdateOrderStatus(id, statusUpdate.Status)
+	userID := c.Get("user_id").(string)
+	err := h.service.UpdateOrderStatus(id, statusUpdate.Status, userID, role)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+		return c.JSON(orderErrorStatus(err), map[string]interface{}{
 			"success": false,
 			"error":   err.Error(),
 		})
@@ -137,6 +145,85 @@ func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
 	})
 }
 
+// CancelOrder handles cancelling an order. Guests can cancel their own orders while they
+// are pending; staff and admins as the order's status allows.
+func (h *OrderHandler) CancelOrder(c echo.Context) error {
+	// Get user ID and role from context
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	id := c.Param("id")
+
+	if err := h.service.UpdateOrderStatus(id, model.OrderCancelled, userID, role); err != nil {
+		return c.JSON(orderErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// Get updated order
+	updatedOrder, err := h.service.GetOrderByID(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve updated order",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Order cancelled successfully",
+		"data":    updatedOrder,
+	})
+}
+
+// ListOrderEvents handles listing the status history of an order
+func (h *OrderHandler) ListOrderEvents(c echo.Context) error {
+	// Get user ID and role from context
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	id := c.Param("id")
+
+	order, err := h.service.GetOrderByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Order not found",
+		})
+	}
+
+	// Check if user is authorized to view this order
+	if role != "admin" && role != "staff" && order.UserID != userID {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this order",
+		})
+	}
+
+	events, err := h.service.ListOrderEvents(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve order history",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Order history retrieved successfully",
+		"data":    events,
+	})
+}
+
+// orderErrorStatus maps an error from an order status change to an HTTP status
+func orderErrorStatus(err error) int {
+	if errors.Is(err, model.ErrTransitionForbidden) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
+
 // ListOrders handles listing all orders
 func (h *OrderHandler) ListOrders(c echo.Context) error {
 	// Check if user is admin or staff
@@ -245,6 +332,45 @@ func (h *OrderHandler) ListUserOrders(c echo.Context) error {
 	})
 }
 
+// ListRoomCharges handles listing the orders delivered to a room in a period, for the
+// room service to charge to the folio of the stay
+func (h *OrderHandler) ListRoomCharges(c echo.Context) error {
+	roomID := c.QueryParam("room_id")
+
+	since, err := time.Parse(time.RFC3339, c.QueryParam("since"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid since time, expected RFC 3339",
+		})
+	}
+
+	until := time.Now()
+	if untilStr := c.QueryParam("until"); untilStr != "" {
+		until, err = time.Parse(time.RFC3339, untilStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid until time, expected RFC 3339",
+			})
+		}
+	}
+
+	orders, err := h.service.ListRoomCharges(roomID, since, until)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room charges retrieved successfully",
+		"data":    orders,
+	})
+}
+
 // RegisterRoutes registers the routes for the order handler
 func (h *OrderHandler) RegisterRoutes(g *echo.Group) {
 	// Order routes
@@ -255,12 +381,15 @@ func (h *OrderHandler) RegisterRoutes(g *echo.Group) {
 	orders.POST("", h.CreateOrder)
 	orders.GET("/my-orders", h.ListUserOrders)
 	orders.GET("/:id", h.GetOrder)
+	orders.GET("/:id/events", h.ListOrderEvents)
+	orders.POST("/:id/cancel", h.CancelOrder)
 
 	// Admin/Staff routes
 	adminStaff := orders.Group("")
 	adminStaff.Use(h.adminStaffMiddleware)
 
 	adminStaff.GET("", h.ListOrders)
+	adminStaff.GET("/room-charges", h.ListRoomCharges)
 	adminStaff.PUT("/:id/status", h.UpdateOrderStatus)
 }
 
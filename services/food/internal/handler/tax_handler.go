@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+	"github.com/flaminshinjan/address.ai/services/food/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// TaxHandler handles HTTP requests for food tax and service charge rules
+type TaxHandler struct {
+	service   *service.TaxService
+	jwtSecret string
+}
+
+// NewTaxHandler creates a new TaxHandler
+func NewTaxHandler(service *service.TaxService, jwtSecret string) *TaxHandler {
+	return &TaxHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// CreateTaxRule handles creating a new tax rule
+// @Summary Create a new tax rule
+// @Description Create a VAT or service charge rule levied on food orders from its effective date
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body tax.Rule true "Tax Rule"
+// @Success 201 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /tax-rules [post]
+func (h *TaxHandler) CreateTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var rule tax.Rule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdRule, err := h.service.CreateTaxRule(rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule created successfully",
+		"data":    createdRule,
+	})
+}
+
+// GetTaxRule handles getting a tax rule by ID
+// @Summary Get a tax rule by ID
+// @Description Get a food tax or service charge rule by its ID
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tax Rule ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /tax-rules/{id} [get]
+func (h *TaxHandler) GetTaxRule(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	rule, err := h.service.GetTaxRuleByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Tax rule not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule retrieved successfully",
+		"data":    rule,
+	})
+}
+
+// UpdateTaxRule handles updating a tax rule
+// @Summary Update a tax rule
+// @Description Update a food tax or service charge rule. Orders already placed keep their taxes.
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tax Rule ID"
+// @Param rule body tax.Rule true "Tax Rule"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /tax-rules/{id} [put]
+func (h *TaxHandler) UpdateTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var rule tax.Rule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedRule, err := h.service.UpdateTaxRule(id, rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule updated successfully",
+		"data":    updatedRule,
+	})
+}
+
+// DeleteTaxRule handles deleting a tax rule
+// @Summary Delete a tax rule
+// @Description Delete a food tax or service charge rule
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Tax Rule ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /tax-rules/{id} [delete]
+func (h *TaxHandler) DeleteTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.DeleteTaxRule(id); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Tax rule not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule deleted successfully",
+	})
+}
+
+// ListTaxRules handles listing all tax rules
+// @Summary List tax rules
+// @Description List the food tax and service charge rules, latest first
+// @Tags tax
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 500 {object} response.Response
+// @Router /tax-rules [get]
+func (h *TaxHandler) ListTaxRules(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	rules, err := h.service.ListTaxRules(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve tax rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rules retrieved successfully",
+		"data":    rules,
+	})
+}
+
+// RegisterRoutes registers the routes for the tax handler
+func (h *TaxHandler) RegisterRoutes(g *echo.Group) {
+	// Tax rule routes
+	rules := g.Group("/tax-rules")
+	rules.Use(h.authMiddleware)
+
+	rules.GET("", h.ListTaxRules)
+	rules.POST("", h.CreateTaxRule)
+	rules.GET("/:id", h.GetTaxRule)
+	rules.PUT("/:id", h.UpdateTaxRule)
+	rules.DELETE("/:id", h.DeleteTaxRule)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *TaxHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -1,19 +1,24 @@
 package model
 
 import (
+	"errors"
 	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
 )
 
 // MenuItem represents a food menu item
 type MenuItem struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	Price       float64   `json:"price"`
-	IsAvailable bool      `json:"is_available"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	Station     string      `json:"station,omitempty"` // the kitchen station that prepares it
+	Price       money.Money `json:"price"`
+	IsAvailable bool        `json:"is_available"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 // MenuCategory represents a food menu category
@@ -24,39 +29,101 @@ type MenuCategory struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
-// FoodOrder represents a food order
+// FoodOrder represents a food order. TotalPrice is the price of the items before the
+// taxes and service charges in TaxBreakdown.
 type FoodOrder struct {
+	ID                   string        `json:"id"`
+	UserID               string        `json:"user_id"`
+	RoomID               string        `json:"room_id,omitempty"`
+	Status               string        `json:"status"` // pending, accepted, preparing, ready, out_for_delivery, delivered, cancelled
+	TotalPrice           money.Money   `json:"total_price"`
+	TaxTotal             money.Money   `json:"tax_total"`
+	TaxBreakdown         tax.Breakdown `json:"tax_breakdown"`
+	Notes                string        `json:"notes,omitempty"`
+	PaymentStatus        string        `json:"payment_status"` // unpaid, authorized, captured, voided, failed
+	PaymentGateway       string        `json:"-"`
+	PaymentTransactionID string        `json:"-"`
+	CreatedAt            time.Time     `json:"created_at"`
+	UpdatedAt            time.Time     `json:"updated_at"`
+}
+
+// Food order statuses. An order is placed pending, accepted by the kitchen, prepared,
+// and once ready either delivered to the room by a runner or collected.
+const (
+	OrderPending        = "pending"
+	OrderAccepted       = "accepted"
+	OrderPreparing      = "preparing"
+	OrderReady          = "ready"
+	OrderOutForDelivery = "out_for_delivery"
+	OrderDelivered      = "delivered"
+	OrderCancelled      = "cancelled"
+)
+
+// orderTransitions lists the statuses a food order may move to from each status.
+// Delivered and cancelled orders are final.
+var orderTransitions = map[string][]string{
+	OrderPending:        {OrderAccepted, OrderCancelled},
+	OrderAccepted:       {OrderPreparing, OrderCancelled},
+	OrderPreparing:      {OrderReady, OrderCancelled},
+	OrderReady:          {OrderOutForDelivery, OrderDelivered, OrderCancelled},
+	OrderOutForDelivery: {OrderDelivered},
+	OrderDelivered:      {},
+	OrderCancelled:      {},
+}
+
+// ErrTransitionForbidden is returned, wrapped with the reason, when a user's role does not
+// allow them to make a status change that is otherwise valid
+var ErrTransitionForbidden = errors.New("status change not permitted")
+
+// ValidOrderStatus reports whether status is a food order status
+func ValidOrderStatus(status string) bool {
+	_, ok := orderTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether a food order may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderEvent records a food order moving from one status to another. The first event
+// of an order has no from status.
+type OrderEvent struct {
 	ID         string    `json:"id"`
-	UserID     string    `json:"user_id"`
-	RoomID     string    `json:"room_id,omitempty"`
-	Status     string    `json:"status"` // pending, preparing, delivered, cancelled
-	TotalPrice float64   `json:"total_price"`
-	Notes      string    `json:"notes,omitempty"`
+	OrderID    string    `json:"order_id"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  string    `json:"changed_by"`
 	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 // OrderItem represents an item in a food order
 type OrderItem struct {
-	ID         string    `json:"id"`
-	OrderID    string    `json:"order_id"`
-	MenuItemID string    `json:"menu_item_id"`
-	Quantity   int       `json:"quantity"`
-	Price      float64   `json:"price"`
-	Notes      string    `json:"notes,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID         string      `json:"id"`
+	OrderID    string      `json:"order_id"`
+	MenuItemID string      `json:"menu_item_id"`
+	Quantity   int         `json:"quantity"`
+	Price      money.Money `json:"price"`
+	Notes      string      `json:"notes,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
 }
 
 // MenuItemResponse represents the menu item data returned in responses
 type MenuItemResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Category    string    `json:"category"`
-	Price       float64   `json:"price"`
-	IsAvailable bool      `json:"is_available"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	Station     string      `json:"station,omitempty"`
+	Price       money.Money `json:"price"`
+	IsAvailable bool        `json:"is_available"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
 
 // OrderItemResponse represents the order item data returned in responses
@@ -64,27 +131,31 @@ type OrderItemResponse struct {
 	ID       string           `json:"id"`
 	MenuItem MenuItemResponse `json:"menu_item"`
 	Quantity int              `json:"quantity"`
-	Price    float64          `json:"price"`
+	Price    money.Money      `json:"price"`
 	Notes    string           `json:"notes,omitempty"`
 }
 
 // FoodOrderResponse represents the food order data returned in responses
 type FoodOrderResponse struct {
-	ID         string              `json:"id"`
-	UserID     string              `json:"user_id"`
-	RoomID     string              `json:"room_id,omitempty"`
-	Status     string              `json:"status"`
-	TotalPrice float64             `json:"total_price"`
-	Notes      string              `json:"notes,omitempty"`
-	Items      []OrderItemResponse `json:"items"`
-	CreatedAt  time.Time           `json:"created_at"`
+	ID            string              `json:"id"`
+	UserID        string              `json:"user_id"`
+	RoomID        string              `json:"room_id,omitempty"`
+	Status        string              `json:"status"`
+	TotalPrice    money.Money         `json:"total_price"`
+	TaxTotal      money.Money         `json:"tax_total"`
+	TaxBreakdown  tax.Breakdown       `json:"tax_breakdown"`
+	Notes         string              `json:"notes,omitempty"`
+	PaymentStatus string              `json:"payment_status"`
+	Items         []OrderItemResponse `json:"items"`
+	CreatedAt     time.Time           `json:"created_at"`
 }
 
 // CreateOrderRequest represents a request to create a food order
 type CreateOrderRequest struct {
-	RoomID string             `json:"room_id,omitempty"`
-	Items  []OrderItemRequest `json:"items" validate:"required,min=1"`
-	Notes  string             `json:"notes,omitempty"`
+	RoomID       string             `json:"room_id,omitempty"`
+	Items        []OrderItemRequest `json:"items" validate:"required,min=1"`
+	Notes        string             `json:"notes,omitempty"`
+	PaymentToken string             `json:"payment_token,omitempty"` // card token; left out for orders charged to the room
 }
 
 // OrderItemRequest represents an item in a create order request
@@ -101,6 +172,7 @@ func (m *MenuItem) ToResponse() MenuItemResponse {
 		Name:        m.Name,
 		Description: m.Description,
 		Category:    m.Category,
+		Station:     m.Station,
 		Price:       m.Price,
 		IsAvailable: m.IsAvailable,
 		CreatedAt:   m.CreatedAt,
@@ -0,0 +1,168 @@
+package pubsub
+
+import (
+	"log"
+	"sync"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/services/food/internal/model"
+)
+
+// Event types
+const (
+	OrderCreated = "order.created"
+	OrderUpdated = "order.updated"
+
+	// Reset tells a subscriber resuming from an event the broker no longer remembers that
+	// it has missed events and should refetch the orders it shows
+	Reset = "reset"
+)
+
+// Types of the events sent to guests about their orders
+const (
+	OrderPlaced        = "food_order.created"
+	OrderStatusChanged = "food_order.status"
+
+	// OrdersReset tells every guest that updates to their orders may have been missed and
+	// that they should refetch them
+	OrdersReset = "food_order.reset"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 64
+
+// Event is a change to a food order, numbered in the order it was published
+type Event struct {
+	ID             uint64
+	Type           string
+	Order          model.FoodOrderResponse
+	PreviousStatus string // the order's status before an update; empty for a new order
+}
+
+// Broker fans food order events out to subscribers in process. It keeps the most recent
+// events so that a subscriber that reconnects can pick up where it left off.
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	subscribers map[chan Event]struct{}
+}
+
+// NewBroker creates a new Broker remembering the last historySize events
+func NewBroker(historySize int) *Broker {
+	return &Broker{
+		historySize: historySize,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish numbers an event and sends it to every subscriber. previousStatus is the
+// order's status before an update, and empty for a new order. A subscriber too far
+// behind to take it is dropped, closing its channel, rather than holding up the
+// publisher; it can resubscribe from the last event it saw.
+func (b *Broker) Publish(eventType string, order model.FoodOrderResponse, previousStatus string) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, Order: order, PreviousStatus: previousStatus}
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// Subscribe starts receiving events. Events published after lastEventID are returned as
+// the backlog, and everything published from then on arrives on the channel, with nothing
+// missed in between. A lastEventID of zero returns no backlog. When some of the events
+// after lastEventID are no longer remembered, because they have aged out of the history
+// or the broker has restarted since, the backlog is a single Reset event carrying the
+// latest ID instead. The returned function unsubscribes.
+func (b *Broker) Subscribe(lastEventID uint64) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []Event
+	switch {
+	case lastEventID == 0:
+	case lastEventID > b.lastID, len(b.history) > 0 && b.history[0].ID > lastEventID+1:
+		backlog = []Event{{ID: b.lastID, Type: Reset}}
+	default:
+		for _, event := range b.history {
+			if event.ID > lastEventID {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return backlog, ch, unsubscribe
+}
+
+// Forward passes every order event on to the guest who placed the order through hub, and
+// tells every guest to refetch their orders if events are lost. It runs until the process
+// exits, resubscribing from the last event it forwarded if it is ever dropped.
+func (b *Broker) Forward(hub *notify.Hub) {
+	var lastID uint64
+	for {
+		backlog, events, unsubscribe := b.Subscribe(lastID)
+		for _, event := range backlog {
+			forward(hub, event, lastID)
+			lastID = event.ID
+		}
+		for event := range events {
+			forward(hub, event, lastID)
+			lastID = event.ID
+		}
+		unsubscribe()
+	}
+}
+
+// forward sends an order event to the order's guest, as a new order or a change of status.
+// A reset cannot be traced to the orders it stands for, so every guest is told to refetch.
+func forward(hub *notify.Hub, event Event, lastID uint64) {
+	var eventType string
+	switch event.Type {
+	case OrderCreated:
+		eventType = OrderPlaced
+	case OrderUpdated:
+		eventType = OrderStatusChanged
+	case Reset:
+		log.Printf("Order events %d to %d were lost before they could be forwarded; telling guests to refetch their orders", lastID+1, event.ID)
+		hub.Broadcast(notify.Event{Type: OrdersReset})
+		return
+	default:
+		return
+	}
+
+	hub.Publish(event.Order.UserID, notify.Event{
+		Type:   eventType,
+		ID:     event.Order.ID,
+		Status: event.Order.Status,
+	})
+}
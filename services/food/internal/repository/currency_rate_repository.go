@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// CurrencyRateRepository reads the exchange rates that the room service keeps
+type CurrencyRateRepository struct {
+	db *sql.DB
+}
+
+// NewCurrencyRateRepository creates a new CurrencyRateRepository
+func NewCurrencyRateRepository(db *sql.DB) *CurrencyRateRepository {
+	return &CurrencyRateRepository{db: db}
+}
+
+// GetRate gets the units of a currency that one unit of the base currency buys
+func (r *CurrencyRateRepository) GetRate(currency string) (float64, error) {
+	query := `
+		SELECT rate
+		FROM currency_rates
+		WHERE currency = $1
+	`
+
+	var rate float64
+	err := r.db.QueryRow(query, currency).Scan(&rate)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errors.New("currency rate not found")
+		}
+		return 0, err
+	}
+
+	return rate, nil
+}
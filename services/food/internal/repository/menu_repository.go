@@ -22,9 +22,9 @@ func NewMenuRepository(db *sql.DB) *MenuRepository {
 // CreateMenuItem creates a new menu item
 func (r *MenuRepository) CreateMenuItem(item model.MenuItem) (model.MenuItem, error) {
 	query := `
-		INSERT INTO menu_items (id, name, description, category, price, is_available, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, name, description, category, price, is_available, created_at, updated_at
+		INSERT INTO menu_items (id, name, description, category, station, price, is_available, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, name, description, category, station, price, is_available, created_at, updated_at
 	`
 
 	// Generate UUID if not provided
@@ -43,6 +43,7 @@ func (r *MenuRepository) CreateMenuItem(item model.MenuItem) (model.MenuItem, er
 		item.Name,
 		item.Description,
 		item.Category,
+		item.Station,
 		item.Price,
 		item.IsAvailable,
 		item.CreatedAt,
@@ -52,6 +53,7 @@ func (r *MenuRepository) CreateMenuItem(item model.MenuItem) (model.MenuItem, er
 		&item.Name,
 		&item.Description,
 		&item.Category,
+		&item.Station,
 		&item.Price,
 		&item.IsAvailable,
 		&item.CreatedAt,
@@ -68,7 +70,7 @@ func (r *MenuRepository) CreateMenuItem(item model.MenuItem) (model.MenuItem, er
 // GetMenuItemByID gets a menu item by ID
 func (r *MenuRepository) GetMenuItemByID(id string) (model.MenuItem, error) {
 	query := `
-		SELECT id, name, description, category, price, is_available, created_at, updated_at
+		SELECT id, name, description, category, station, price, is_available, created_at, updated_at
 		FROM menu_items
 		WHERE id = $1
 	`
@@ -79,6 +81,7 @@ func (r *MenuRepository) GetMenuItemByID(id string) (model.MenuItem, error) {
 		&item.Name,
 		&item.Description,
 		&item.Category,
+		&item.Station,
 		&item.Price,
 		&item.IsAvailable,
 		&item.CreatedAt,
@@ -99,9 +102,9 @@ func (r *MenuRepository) GetMenuItemByID(id string) (model.MenuItem, error) {
 func (r *MenuRepository) UpdateMenuItem(item model.MenuItem) (model.MenuItem, error) {
 	query := `
 		UPDATE menu_items
-		SET name = $1, description = $2, category = $3, price = $4, is_available = $5, updated_at = $6
-		WHERE id = $7
-		RETURNING id, name, description, category, price, is_available, created_at, updated_at
+		SET name = $1, description = $2, category = $3, station = $4, price = $5, is_available = $6, updated_at = $7
+		WHERE id = $8
+		RETURNING id, name, description, category, station, price, is_available, created_at, updated_at
 	`
 
 	item.UpdatedAt = time.Now()
@@ -111,6 +114,7 @@ func (r *MenuRepository) UpdateMenuItem(item model.MenuItem) (model.MenuItem, er
 		item.Name,
 		item.Description,
 		item.Category,
+		item.Station,
 		item.Price,
 		item.IsAvailable,
 		item.UpdatedAt,
@@ -120,6 +124,7 @@ func (r *MenuRepository) UpdateMenuItem(item model.MenuItem) (model.MenuItem, er
 		&item.Name,
 		&item.Description,
 		&item.Category,
+		&item.Station,
 		&item.Price,
 		&item.IsAvailable,
 		&item.CreatedAt,
@@ -163,7 +168,7 @@ func (r *MenuRepository) DeleteMenuItem(id string) error {
 // ListMenuItems lists all menu items
 func (r *MenuRepository) ListMenuItems(limit, offset int) ([]model.MenuItem, error) {
 	query := `
-		SELECT id, name, description, category, price, is_available, created_at, updated_at
+		SELECT id, name, description, category, station, price, is_available, created_at, updated_at
 		FROM menu_items
 		ORDER BY category, name
 		LIMIT $1 OFFSET $2
@@ -183,6 +188,7 @@ func (r *MenuRepository) ListMenuItems(limit, offset int) ([]model.MenuItem, err
 			&item.Name,
 			&item.Description,
 			&item.Category,
+			&item.Station,
 			&item.Price,
 			&item.IsAvailable,
 			&item.CreatedAt,
@@ -204,7 +210,7 @@ func (r *MenuRepository) ListMenuItems(limit, offset int) ([]model.MenuItem, err
 // ListMenuItemsByCategory lists menu items by category
 func (r *MenuRepository) ListMenuItemsByCategory(category string, limit, offset int) ([]model.MenuItem, error) {
 	query := `
-		SELECT id, name, description, category, price, is_available, created_at, updated_at
+		SELECT id, name, description, category, station, price, is_available, created_at, updated_at
 		FROM menu_items
 		WHERE category = $1
 		ORDER BY name
@@ -225,6 +231,7 @@ func (r *MenuRepository) ListMenuItemsByCategory(category string, limit, offset
 			&item.Name,
 			&item.Description,
 			&item.Category,
+			&item.Station,
 			&item.Price,
 			&item.IsAvailable,
 			&item.CreatedAt,
@@ -246,7 +253,7 @@ func (r *MenuRepository) ListMenuItemsByCategory(category string, limit, offset
 // ListAvailableMenuItems lists all available menu items
 func (r *MenuRepository) ListAvailableMenuItems(limit, offset int) ([]model.MenuItem, error) {
 	query := `
-		SELECT id, name, description, category, price, is_available, created_at, updated_at
+		SELECT id, name, description, category, station, price, is_available, created_at, updated_at
 		FROM menu_items
 		WHERE is_available = true
 		ORDER BY category, name
@@ -267,6 +274,7 @@ func (r *MenuRepository) ListAvailableMenuItems(limit, offset int) ([]model.Menu
 			&item.Name,
 			&item.Description,
 			&item.Category,
+			&item.Station,
 			&item.Price,
 			&item.IsAvailable,
 			&item.CreatedAt,
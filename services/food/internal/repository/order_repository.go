@@ -21,10 +21,61 @@ func NewOrderRepository(db *sql.DB) *OrderRepository {
 
 // CreateOrder creates a new food order
 func (r *OrderRepository) CreateOrder(order model.FoodOrder) (model.FoodOrder, error) {
+	return insertOrder(r.db, order)
+}
+
+// CreateOrderItem creates a new order item
+func (r *OrderRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem, error) {
+	return insertOrderItem(r.db, item)
+}
+
+// CreateOrderWithItems creates a food order and its items in one transaction, so an order
+// is never left without its items or its total. The items are given the order's ID.
+func (r *OrderRepository) CreateOrderWithItems(order model.FoodOrder, items []model.OrderItem) (model.FoodOrder, []model.OrderItem, error) {
+	var createdOrder model.FoodOrder
+	var createdItems []model.OrderItem
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		var err error
+		createdOrder, err = insertOrder(tx, order)
+		if err != nil {
+			return err
+		}
+
+		_, err = insertOrderEvent(tx, model.OrderEvent{
+			OrderID:   createdOrder.ID,
+			ToStatus:  createdOrder.Status,
+			ChangedBy: createdOrder.UserID,
+			CreatedAt: createdOrder.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			item.OrderID = createdOrder.ID
+			createdItem, err := insertOrderItem(tx, item)
+			if err != nil {
+				return err
+			}
+			createdItems = append(createdItems, createdItem)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return model.FoodOrder{}, nil, err
+	}
+
+	return createdOrder, createdItems, nil
+}
+
+// insertOrder inserts a food order using q
+func insertOrder(q querier, order model.FoodOrder) (model.FoodOrder, error) {
 	query := `
-		INSERT INTO food_orders (id, user_id, room_id, status, total_price, notes, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, user_id, room_id, status, total_price, notes, created_at, updated_at
+		INSERT INTO food_orders (id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at, payment_status
 	`
 
 	// Generate UUID if not provided
@@ -42,13 +93,15 @@ func (r *OrderRepository) CreateOrder(order model.FoodOrder) (model.FoodOrder, e
 		order.Status = "pending"
 	}
 
-	err := r.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		order.ID,
 		order.UserID,
 		order.RoomID,
 		order.Status,
 		order.TotalPrice,
+		order.TaxTotal,
+		order.TaxBreakdown,
 		order.Notes,
 		order.CreatedAt,
 		order.UpdatedAt,
@@ -58,9 +111,12 @@ func (r *OrderRepository) CreateOrder(order model.FoodOrder) (model.FoodOrder, e
 		&order.RoomID,
 		&order.Status,
 		&order.TotalPrice,
+		&order.TaxTotal,
+		&order.TaxBreakdown,
 		&order.Notes,
 		&order.CreatedAt,
 		&order.UpdatedAt,
+		&order.PaymentStatus,
 	)
 
 	if err != nil {
@@ -70,8 +126,8 @@ func (r *OrderRepository) CreateOrder(order model.FoodOrder) (model.FoodOrder, e
 	return order, nil
 }
 
-// CreateOrderItem creates a new order item
-func (r *OrderRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem, error) {
+// insertOrderItem inserts an order item using q
+func insertOrderItem(q querier, item model.OrderItem) (model.OrderItem, error) {
 	query := `
 		INSERT INTO order_items (id, order_id, menu_item_id, quantity, price, notes, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
@@ -88,7 +144,7 @@ func (r *OrderRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem
 	item.CreatedAt = now
 	item.UpdatedAt = now
 
-	err := r.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		item.ID,
 		item.OrderID,
@@ -119,7 +175,8 @@ func (r *OrderRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem
 // GetOrderByID gets a food order by ID
 func (r *OrderRepository) GetOrderByID(id string) (model.FoodOrder, error) {
 	query := `
-		SELECT id, user_id, room_id, status, total_price, notes, created_at, updated_at
+		SELECT id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
 		FROM food_orders
 		WHERE id = $1
 	`
@@ -131,9 +188,14 @@ func (r *OrderRepository) GetOrderByID(id string) (model.FoodOrder, error) {
 		&order.RoomID,
 		&order.Status,
 		&order.TotalPrice,
+		&order.TaxTotal,
+		&order.TaxBreakdown,
 		&order.Notes,
 		&order.CreatedAt,
 		&order.UpdatedAt,
+		&order.PaymentStatus,
+		&order.PaymentGateway,
+		&order.PaymentTransactionID,
 	)
 
 	if err != nil {
@@ -187,22 +249,187 @@ func (r *OrderRepository) GetOrderItemsByOrderID(orderID string) ([]model.OrderI
 	return items, nil
 }
 
-// UpdateOrderStatus updates a food order's status
-func (r *OrderRepository) UpdateOrderStatus(id, status string) error {
+// UpdateOrder updates a food order's room, status, totals and notes
+func (r *OrderRepository) UpdateOrder(order model.FoodOrder) (model.FoodOrder, error) {
 	query := `
 		UPDATE food_orders
-		SET status = $1, updated_at = $2
-		WHERE id = $3
+		SET room_id = $1, status = $2, total_price = $3, tax_total = $4, tax_breakdown = $5, notes = $6, updated_at = $7
+		WHERE id = $8
+		RETURNING id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
+	`
+
+	order.UpdatedAt = time.Now()
+
+	err := r.db.QueryRow(
+		query,
+		order.RoomID,
+		order.Status,
+		order.TotalPrice,
+		order.TaxTotal,
+		order.TaxBreakdown,
+		order.Notes,
+		order.UpdatedAt,
+		order.ID,
+	).Scan(
+		&order.ID,
+		&order.UserID,
+		&order.RoomID,
+		&order.Status,
+		&order.TotalPrice,
+		&order.TaxTotal,
+		&order.TaxBreakdown,
+		&order.Notes,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.PaymentStatus,
+		&order.PaymentGateway,
+		&order.PaymentTransactionID,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.FoodOrder{}, errors.New("order not found")
+		}
+		return model.FoodOrder{}, err
+	}
+
+	return order, nil
+}
+
+// TransitionOrder moves a food order from one status to another and records the change
+// in its event history, in one transaction. The move only happens if the order is still
+// in the from status, so two concurrent changes cannot both apply.
+func (r *OrderRepository) TransitionOrder(id, from, to, changedBy string) (model.OrderEvent, error) {
+	var event model.OrderEvent
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		result, err := tx.Exec(`
+			UPDATE food_orders
+			SET status = $1, updated_at = $2
+			WHERE id = $3
+			AND status = $4
+		`, to, now, id, from)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errors.New("order is no longer " + from)
+		}
+
+		event, err = insertOrderEvent(tx, model.OrderEvent{
+			OrderID:    id,
+			FromStatus: from,
+			ToStatus:   to,
+			ChangedBy:  changedBy,
+			CreatedAt:  now,
+		})
+		return err
+	})
+
+	if err != nil {
+		return model.OrderEvent{}, err
+	}
+
+	return event, nil
+}
+
+// insertOrderEvent inserts a food order event using q
+func insertOrderEvent(q querier, event model.OrderEvent) (model.OrderEvent, error) {
+	query := `
+		INSERT INTO food_order_events (id, order_id, from_status, to_status, changed_by, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6)
 	`
 
-	_, err := r.db.Exec(query, status, time.Now(), id)
+	// Generate UUID if not provided
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	// Set timestamp if not provided
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	_, err := q.Exec(
+		query,
+		event.ID,
+		event.OrderID,
+		event.FromStatus,
+		event.ToStatus,
+		event.ChangedBy,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return model.OrderEvent{}, err
+	}
+
+	return event, nil
+}
+
+// ListOrderEvents lists the status changes of a food order, oldest first
+func (r *OrderRepository) ListOrderEvents(orderID string) ([]model.OrderEvent, error) {
+	query := `
+		SELECT id, order_id, COALESCE(from_status, ''), to_status, changed_by, created_at
+		FROM food_order_events
+		WHERE order_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.OrderEvent
+	for rows.Next() {
+		var event model.OrderEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.ChangedBy,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// UpdateOrderPayment records the state of a food order's card payment
+func (r *OrderRepository) UpdateOrderPayment(id, status, gateway, transactionID string) error {
+	query := `
+		UPDATE food_orders
+		SET payment_status = $1, payment_gateway = NULLIF($2, ''), payment_transaction_id = NULLIF($3, ''), updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.Exec(query, status, gateway, transactionID, time.Now(), id)
 	return err
 }
 
 // ListOrdersByUserID lists food orders by user ID
 func (r *OrderRepository) ListOrdersByUserID(userID string, limit, offset int) ([]model.FoodOrder, error) {
 	query := `
-		SELECT id, user_id, room_id, status, total_price, notes, created_at, updated_at
+		SELECT id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
 		FROM food_orders
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -224,9 +451,14 @@ func (r *OrderRepository) ListOrdersByUserID(userID string, limit, offset int) (
 			&order.RoomID,
 			&order.Status,
 			&order.TotalPrice,
+			&order.TaxTotal,
+			&order.TaxBreakdown,
 			&order.Notes,
 			&order.CreatedAt,
 			&order.UpdatedAt,
+			&order.PaymentStatus,
+			&order.PaymentGateway,
+			&order.PaymentTransactionID,
 		)
 		if err != nil {
 			return nil, err
@@ -244,7 +476,8 @@ func (r *OrderRepository) ListOrdersByUserID(userID string, limit, offset int) (
 // ListOrders lists all food orders
 func (r *OrderRepository) ListOrders(limit, offset int) ([]model.FoodOrder, error) {
 	query := `
-		SELECT id, user_id, room_id, status, total_price, notes, created_at, updated_at
+		SELECT id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
 		FROM food_orders
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -265,9 +498,14 @@ func (r *OrderRepository) ListOrders(limit, offset int) ([]model.FoodOrder, erro
 			&order.RoomID,
 			&order.Status,
 			&order.TotalPrice,
+			&order.TaxTotal,
+			&order.TaxBreakdown,
 			&order.Notes,
 			&order.CreatedAt,
 			&order.UpdatedAt,
+			&order.PaymentStatus,
+			&order.PaymentGateway,
+			&order.PaymentTransactionID,
 		)
 		if err != nil {
 			return nil, err
@@ -285,7 +523,8 @@ func (r *OrderRepository) ListOrders(limit, offset int) ([]model.FoodOrder, erro
 // ListOrdersByStatus lists food orders by status
 func (r *OrderRepository) ListOrdersByStatus(status string, limit, offset int) ([]model.FoodOrder, error) {
 	query := `
-		SELECT id, user_id, room_id, status, total_price, notes, created_at, updated_at
+		SELECT id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
 		FROM food_orders
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -307,9 +546,67 @@ func (r *OrderRepository) ListOrdersByStatus(status string, limit, offset int) (
 			&order.RoomID,
 			&order.Status,
 			&order.TotalPrice,
+			&order.TaxTotal,
+			&order.TaxBreakdown,
+			&order.Notes,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&order.PaymentStatus,
+			&order.PaymentGateway,
+			&order.PaymentTransactionID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// ListRoomCharges lists the orders delivered to a room that were placed in a period,
+// oldest first, for charging to the folio of the stay in the room. Orders paid by card
+// are captured on delivery and are left out so that they are not charged twice.
+func (r *OrderRepository) ListRoomCharges(roomID string, since, until time.Time) ([]model.FoodOrder, error) {
+	query := `
+		SELECT id, user_id, room_id, status, total_price, tax_total, tax_breakdown, notes, created_at, updated_at,
+			payment_status, COALESCE(payment_gateway, ''), COALESCE(payment_transaction_id, '')
+		FROM food_orders
+		WHERE room_id = $1
+		AND status = 'delivered'
+		AND payment_status = 'unpaid'
+		AND created_at >= $2
+		AND created_at <= $3
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, roomID, since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []model.FoodOrder
+	for rows.Next() {
+		var order model.FoodOrder
+		err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.RoomID,
+			&order.Status,
+			&order.TotalPrice,
+			&order.TaxTotal,
+			&order.TaxBreakdown,
 			&order.Notes,
 			&order.CreatedAt,
 			&order.UpdatedAt,
+			&order.PaymentStatus,
+			&order.PaymentGateway,
+			&order.PaymentTransactionID,
 		)
 		if err != nil {
 			return nil, err
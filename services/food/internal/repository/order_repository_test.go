@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestListRoomChargesLeavesOutCardPaidOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	since := time.Date(2026, 3, 8, 14, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 12, 11, 0, 0, 0, time.UTC)
+
+	columns := []string{"id", "user_id", "room_id", "status", "total_price", "tax_total", "tax_breakdown", "notes", "created_at", "updated_at",
+		"payment_status", "payment_gateway", "payment_transaction_id"}
+	mock.ExpectQuery(regexp.QuoteMeta(`AND status = 'delivered'
+		AND payment_status = 'unpaid'`)).
+		WithArgs("room-1", since, until).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("order-1", "user-1", "room-1", "delivered", "24.00", "2.40", `{"lines":[],"total":"2.40"}`, "", since, since, "unpaid", "", ""))
+
+	orders, err := NewOrderRepository(db).ListRoomCharges("room-1", since, until)
+	if err != nil {
+		t.Fatalf("listing room charges failed: %v", err)
+	}
+
+	if len(orders) != 1 || orders[0].ID != "order-1" || orders[0].PaymentStatus != "unpaid" {
+		t.Errorf("got %+v, want only the unpaid order", orders)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
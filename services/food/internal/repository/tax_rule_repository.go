@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+)
+
+// TaxRuleRepository handles database operations for the taxes and service charges levied on food orders
+type TaxRuleRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRuleRepository creates a new TaxRuleRepository
+func NewTaxRuleRepository(db *sql.DB) *TaxRuleRepository {
+	return &TaxRuleRepository{db: db}
+}
+
+// CreateTaxRule creates a new tax rule
+func (r *TaxRuleRepository) CreateTaxRule(rule tax.Rule) (tax.Rule, error) {
+	query := `
+		INSERT INTO food_tax_rules (id, name, kind, rate, effective_from, effective_to, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, name, kind, rate, effective_from, effective_to, created_at, updated_at
+	`
+
+	// Generate UUID if not provided
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	err := r.db.QueryRow(
+		query,
+		rule.ID,
+		rule.Name,
+		rule.Kind,
+		rule.Rate,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	).Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Kind,
+		&rule.Rate,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	if err != nil {
+		return tax.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// GetTaxRuleByID gets a tax rule by ID
+func (r *TaxRuleRepository) GetTaxRuleByID(id string) (tax.Rule, error) {
+	query := `
+		SELECT id, name, kind, rate, effective_from, effective_to, created_at, updated_at
+		FROM food_tax_rules
+		WHERE id = $1
+	`
+
+	var rule tax.Rule
+	err := r.db.QueryRow(query, id).Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Kind,
+		&rule.Rate,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tax.Rule{}, errors.New("tax rule not found")
+		}
+		return tax.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// UpdateTaxRule updates a tax rule
+func (r *TaxRuleRepository) UpdateTaxRule(rule tax.Rule) (tax.Rule, error) {
+	query := `
+		UPDATE food_tax_rules
+		SET name = $1, kind = $2, rate = $3, effective_from = $4, effective_to = $5, updated_at = $6
+		WHERE id = $7
+		RETURNING id, name, kind, rate, effective_from, effective_to, created_at, updated_at
+	`
+
+	rule.UpdatedAt = time.Now()
+
+	err := r.db.QueryRow(
+		query,
+		rule.Name,
+		rule.Kind,
+		rule.Rate,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+		rule.UpdatedAt,
+		rule.ID,
+	).Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Kind,
+		&rule.Rate,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tax.Rule{}, errors.New("tax rule not found")
+		}
+		return tax.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// DeleteTaxRule deletes a tax rule
+func (r *TaxRuleRepository) DeleteTaxRule(id string) error {
+	query := `
+		DELETE FROM food_tax_rules
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("tax rule not found")
+	}
+
+	return nil
+}
+
+// ListTaxRules lists all tax rules, latest first
+func (r *TaxRuleRepository) ListTaxRules(limit, offset int) ([]tax.Rule, error) {
+	query := `
+		SELECT id, name, kind, rate, effective_from, effective_to, created_at, updated_at
+		FROM food_tax_rules
+		ORDER BY effective_from DESC, name
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTaxRules(rows)
+}
+
+// ListEffectiveTaxRules lists the tax rules in force on date
+func (r *TaxRuleRepository) ListEffectiveTaxRules(date time.Time) ([]tax.Rule, error) {
+	query := `
+		SELECT id, name, kind, rate, effective_from, effective_to, created_at, updated_at
+		FROM food_tax_rules
+		WHERE effective_from <= $1::date
+		AND (effective_to IS NULL OR effective_to > $1::date)
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTaxRules(rows)
+}
+
+// scanTaxRules scans tax rule rows
+func scanTaxRules(rows *sql.Rows) ([]tax.Rule, error) {
+	defer rows.Close()
+
+	var rules []tax.Rule
+	for rows.Next() {
+		var rule tax.Rule
+		err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&rule.Kind,
+			&rule.Rate,
+			&rule.EffectiveFrom,
+			&rule.EffectiveTo,
+			&rule.CreatedAt,
+			&rule.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
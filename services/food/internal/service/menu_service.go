@@ -2,21 +2,26 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
 	"github.com/flaminshinjan/address.ai/services/food/internal/model"
 	"github.com/flaminshinjan/address.ai/services/food/internal/repository"
 )
 
 // MenuService handles business logic for menu items
 type MenuService struct {
-	menuRepo *repository.MenuRepository
+	menuRepo         *repository.MenuRepository
+	currencyRateRepo *repository.CurrencyRateRepository
 }
 
 // NewMenuService creates a new MenuService
-func NewMenuService(menuRepo *repository.MenuRepository) *MenuService {
+func NewMenuService(menuRepo *repository.MenuRepository, currencyRateRepo *repository.CurrencyRateRepository) *MenuService {
 	return &MenuService{
-		menuRepo: menuRepo,
+		menuRepo:         menuRepo,
+		currencyRateRepo: currencyRateRepo,
 	}
 }
 
@@ -31,7 +36,7 @@ func (s *MenuService) CreateMenuItem(item model.MenuItem) (model.MenuItemRespons
 		return model.MenuItemResponse{}, errors.New("category is required")
 	}
 
-	if item.Price <= 0 {
+	if !item.Price.IsPositive() {
 		return model.MenuItemResponse{}, errors.New("price must be greater than zero")
 	}
 
@@ -66,6 +71,7 @@ func (s *MenuService) UpdateMenuItem(id string, item model.MenuItem) (model.Menu
 	existingItem.Name = item.Name
 	existingItem.Description = item.Description
 	existingItem.Category = item.Category
+	existingItem.Station = item.Station
 	existingItem.Price = item.Price
 	existingItem.IsAvailable = item.IsAvailable
 	existingItem.UpdatedAt = time.Now()
@@ -129,6 +135,26 @@ func (s *MenuService) ListAvailableMenuItems(limit, offset int) ([]model.MenuIte
 	return itemResponses, nil
 }
 
+// ConvertPrices converts the prices of menu items into currency for display. No
+// currency, or the base currency itself, leaves them as they are.
+func (s *MenuService) ConvertPrices(items []model.MenuItemResponse, currency string) error {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == money.DefaultCurrency {
+		return nil
+	}
+
+	rate, err := s.currencyRateRepo.GetRate(currency)
+	if err != nil {
+		return fmt.Errorf("currency %s is not supported", currency)
+	}
+
+	for i := range items {
+		items[i].Price = items[i].Price.Convert(currency, rate)
+	}
+
+	return nil
+}
+
 // ListCategories lists all menu categories
 func (s *MenuService) ListCategories() ([]string, error) {
 	return s.menuRepo.ListCategories()
@@ -2,51 +2,112 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"log"
+	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
 	"github.com/flaminshinjan/address.ai/services/food/internal/model"
+	"github.com/flaminshinjan/address.ai/services/food/internal/pubsub"
 	"github.com/flaminshinjan/address.ai/services/food/internal/repository"
 )
 
 // OrderService handles business logic for food orders
 type OrderService struct {
-	orderRepo *repository.OrderRepository
-	menuRepo  *repository.MenuRepository
+	orderRepo   *repository.OrderRepository
+	menuRepo    *repository.MenuRepository
+	taxRuleRepo *repository.TaxRuleRepository
+	gateway     payment.Gateway
+	broker      *pubsub.Broker
 }
 
-// NewOrderService creates a new OrderService
-func NewOrderService(orderRepo *repository.OrderRepository, menuRepo *repository.MenuRepository) *OrderService {
+// NewOrderService creates a new OrderService taking card payments through gateway and
+// publishing new and changed orders to broker
+func NewOrderService(orderRepo *repository.OrderRepository, menuRepo *repository.MenuRepository, taxRuleRepo *repository.TaxRuleRepository, gateway payment.Gateway, broker *pubsub.Broker) *OrderService {
 	return &OrderService{
-		orderRepo: orderRepo,
-		menuRepo:  menuRepo,
+		orderRepo:   orderRepo,
+		menuRepo:    menuRepo,
+		taxRuleRepo: taxRuleRepo,
+		gateway:     gateway,
+		broker:      broker,
 	}
 }
 
-// CreateOrder creates a new food order
-func (s *OrderService) CreateOrder(userID string, req model.CreateOrderRequest) (model.FoodOrderResponse, error) {
-	// Validate request
-	if len(req.Items) == 0 {
-		return model.FoodOrderResponse{}, errors.New("at least one item is required")
+// authorizePayment holds an order's total on the card it was placed with. A declined
+// card cancels the order.
+func (s *OrderService) authorizePayment(order *model.FoodOrder, token string) error {
+	tx, err := s.gateway.Authorize(payment.AuthorizeRequest{
+		Amount:    order.TotalPrice.Add(order.TaxTotal),
+		Token:     token,
+		Reference: order.ID,
+	})
+	if err != nil {
+		if updateErr := s.orderRepo.UpdateOrderPayment(order.ID, payment.StatusFailed, s.gateway.Name(), ""); updateErr != nil {
+			log.Printf("Failed to record declined payment for order %s: %v", order.ID, updateErr)
+		}
+		if _, updateErr := s.orderRepo.TransitionOrder(order.ID, order.Status, model.OrderCancelled, "system"); updateErr != nil {
+			log.Printf("Failed to cancel order %s: %v", order.ID, updateErr)
+		}
+		return err
 	}
 
-	// Create order
-	order := model.FoodOrder{
-		UserID:     userID,
-		RoomID:     req.RoomID,
-		Status:     "pending",
-		TotalPrice: 0,
-		Notes:      req.Notes,
+	if err := s.orderRepo.UpdateOrderPayment(order.ID, tx.Status, s.gateway.Name(), tx.ID); err != nil {
+		if _, voidErr := s.gateway.Void(tx.ID); voidErr != nil {
+			log.Printf("Failed to void payment for order %s: %v", order.ID, voidErr)
+		}
+		return err
 	}
 
-	createdOrder, err := s.orderRepo.CreateOrder(order)
+	order.PaymentStatus = tx.Status
+	order.PaymentGateway = s.gateway.Name()
+	order.PaymentTransactionID = tx.ID
+	return nil
+}
+
+// settlePayment captures an order's authorization once it is delivered and releases it
+// when the order is cancelled
+func (s *OrderService) settlePayment(order model.FoodOrder, status string) error {
+	if order.PaymentStatus != payment.StatusAuthorized {
+		return nil
+	}
+
+	var tx payment.Transaction
+	var err error
+	switch status {
+	case model.OrderDelivered:
+		tx, err = s.gateway.Capture(order.PaymentTransactionID, order.TotalPrice.Add(order.TaxTotal))
+	case model.OrderCancelled:
+		tx, err = s.gateway.Void(order.PaymentTransactionID)
+	default:
+		return nil
+	}
 	if err != nil {
-		return model.FoodOrderResponse{}, err
+		return fmt.Errorf("failed to settle payment: %w", err)
 	}
 
-	// Create order items
-	var totalPrice float64
-	var orderItems []model.OrderItemResponse
+	return s.orderRepo.UpdateOrderPayment(order.ID, tx.Status, order.PaymentGateway, tx.ID)
+}
+
+// CreateOrder creates a new food order. The items are priced and the order totalled
+// before anything is written, and the order is stored with its items in one transaction.
+func (s *OrderService) CreateOrder(userID string, req model.CreateOrderRequest) (model.FoodOrderResponse, error) {
+	// Validate request
+	if len(req.Items) == 0 {
+		return model.FoodOrderResponse{}, errors.New("at least one item is required")
+	}
+
+	// Price the items
+	totalPrice := money.Zero(money.DefaultCurrency)
+	var items []model.OrderItem
+	var menuItems []model.MenuItem
 
 	for _, itemReq := range req.Items {
+		if itemReq.Quantity <= 0 {
+			return model.FoodOrderResponse{}, errors.New("quantity must be greater than zero")
+		}
+
 		// Get menu item
 		menuItem, err := s.menuRepo.GetMenuItemByID(itemReq.MenuItemID)
 		if err != nil {
@@ -59,52 +120,77 @@ func (s *OrderService) CreateOrder(userID string, req model.CreateOrderRequest)
 		}
 
 		// Calculate price
-		itemPrice := menuItem.Price * float64(itemReq.Quantity)
-		totalPrice += itemPrice
+		itemPrice := menuItem.Price.Mul(int64(itemReq.Quantity))
+		totalPrice = totalPrice.Add(itemPrice)
 
-		// Create order item
-		orderItem := model.OrderItem{
-			OrderID:    createdOrder.ID,
+		items = append(items, model.OrderItem{
 			MenuItemID: itemReq.MenuItemID,
 			Quantity:   itemReq.Quantity,
 			Price:      itemPrice,
 			Notes:      itemReq.Notes,
-		}
+		})
+		menuItems = append(menuItems, menuItem)
+	}
 
-		createdItem, err := s.orderRepo.CreateOrderItem(orderItem)
-		if err != nil {
+	// Levy the taxes and service charges in force when the order is placed
+	now := time.Now()
+	rules, err := s.taxRuleRepo.ListEffectiveTaxRules(now)
+	if err != nil {
+		return model.FoodOrderResponse{}, err
+	}
+	taxes := tax.Apply(rules, totalPrice, now)
+
+	// Create order
+	order := model.FoodOrder{
+		UserID:       userID,
+		RoomID:       req.RoomID,
+		Status:       model.OrderPending,
+		TotalPrice:   totalPrice,
+		TaxTotal:     taxes.Total,
+		TaxBreakdown: taxes,
+		Notes:        req.Notes,
+	}
+
+	createdOrder, createdItems, err := s.orderRepo.CreateOrderWithItems(order, items)
+	if err != nil {
+		return model.FoodOrderResponse{}, err
+	}
+
+	// Orders paid by card are authorized now and captured on delivery
+	if req.PaymentToken != "" {
+		if err := s.authorizePayment(&createdOrder, req.PaymentToken); err != nil {
 			return model.FoodOrderResponse{}, err
 		}
+	}
 
-		// Add to response
+	// Create response
+	var orderItems []model.OrderItemResponse
+	for i, createdItem := range createdItems {
 		orderItems = append(orderItems, model.OrderItemResponse{
 			ID:       createdItem.ID,
-			MenuItem: menuItem.ToResponse(),
+			MenuItem: menuItems[i].ToResponse(),
 			Quantity: createdItem.Quantity,
 			Price:    createdItem.Price,
 			Notes:    createdItem.Notes,
 		})
 	}
 
-	// Update order total price
-	createdOrder.TotalPrice = totalPrice
-	_, err = s.orderRepo.CreateOrder(createdOrder)
-	if err != nil {
-		return model.FoodOrderResponse{}, err
-	}
-
-	// Create response
 	response := model.FoodOrderResponse{
-		ID:         createdOrder.ID,
-		UserID:     createdOrder.UserID,
-		RoomID:     createdOrder.RoomID,
-		Status:     createdOrder.Status,
-		TotalPrice: totalPrice,
-		Notes:      createdOrder.Notes,
-		Items:      orderItems,
-		CreatedAt:  createdOrder.CreatedAt,
+		ID:            createdOrder.ID,
+		UserID:        createdOrder.UserID,
+		RoomID:        createdOrder.RoomID,
+		Status:        createdOrder.Status,
+		TotalPrice:    createdOrder.TotalPrice,
+		TaxTotal:      createdOrder.TaxTotal,
+		TaxBreakdown:  createdOrder.TaxBreakdown,
+		Notes:         createdOrder.Notes,
+		PaymentStatus: createdOrder.PaymentStatus,
+		Items:         orderItems,
+		CreatedAt:     createdOrder.CreatedAt,
 	}
 
+	s.broker.Publish(pubsub.OrderCreated, response, "")
+
 	return response, nil
 }
 
@@ -141,40 +227,90 @@ func (s *OrderService) GetOrderByID(id string) (model.FoodOrderResponse, error)
 	}
 
 	response := model.FoodOrderResponse{
-		ID:         order.ID,
-		UserID:     order.UserID,
-		RoomID:     order.RoomID,
-		Status:     order.Status,
-		TotalPrice: order.TotalPrice,
-		Notes:      order.Notes,
-		Items:      orderItems,
-		CreatedAt:  order.CreatedAt,
+		ID:            order.ID,
+		UserID:        order.UserID,
+		RoomID:        order.RoomID,
+		Status:        order.Status,
+		TotalPrice:    order.TotalPrice,
+		TaxTotal:      order.TaxTotal,
+		TaxBreakdown:  order.TaxBreakdown,
+		Notes:         order.Notes,
+		PaymentStatus: order.PaymentStatus,
+		Items:         orderItems,
+		CreatedAt:     order.CreatedAt,
 	}
 
 	return response, nil
 }
 
-// UpdateOrderStatus updates a food order's status
-func (s *OrderService) UpdateOrderStatus(id, status string) error {
-	// Validate status
-	validStatuses := map[string]bool{
-		"pending":   true,
-		"preparing": true,
-		"delivered": true,
-		"cancelled": true,
+// checkTransitionRole checks that a user's role lets them move an order to a status.
+// Kitchen staff run the order through the kitchen and may cancel it until cooking starts;
+// after that only an admin can. Guests can only cancel their own order while it is pending.
+func checkTransitionRole(order model.FoodOrder, status, userID, role string) error {
+	switch role {
+	case "admin":
+		return nil
+	case "staff":
+		if status == model.OrderCancelled && order.Status != model.OrderPending && order.Status != model.OrderAccepted {
+			return fmt.Errorf("%w: only an admin can cancel an order once it is being prepared", model.ErrTransitionForbidden)
+		}
+		return nil
+	default:
+		if order.UserID != userID || status != model.OrderCancelled {
+			return fmt.Errorf("%w: guests can only cancel their own orders", model.ErrTransitionForbidden)
+		}
+		if order.Status != model.OrderPending {
+			return fmt.Errorf("%w: orders can only be cancelled while pending", model.ErrTransitionForbidden)
+		}
+		return nil
 	}
+}
 
-	if !validStatuses[status] {
+// UpdateOrderStatus moves a food order to a new status on behalf of a user, if the
+// order's current status allows it and the user's role permits it. The change is
+// recorded in the order's event history.
+func (s *OrderService) UpdateOrderStatus(id, status, userID, role string) error {
+	// Validate status
+	if !model.ValidOrderStatus(status) {
 		return errors.New("invalid status")
 	}
 
 	// Check if order exists
-	_, err := s.orderRepo.GetOrderByID(id)
+	order, err := s.orderRepo.GetOrderByID(id)
 	if err != nil {
 		return err
 	}
 
-	return s.orderRepo.UpdateOrderStatus(id, status)
+	if !model.CanTransition(order.Status, status) {
+		return fmt.Errorf("cannot move an order from %s to %s", order.Status, status)
+	}
+
+	if err := checkTransitionRole(order, status, userID, role); err != nil {
+		return err
+	}
+
+	if _, err := s.orderRepo.TransitionOrder(id, order.Status, status, userID); err != nil {
+		return err
+	}
+
+	// The order has moved on either way; a failed settlement is left for staff to resolve
+	if err := s.settlePayment(order, status); err != nil {
+		log.Printf("Failed to settle payment for order %s: %v", id, err)
+	}
+
+	updated, err := s.GetOrderByID(id)
+	if err != nil {
+		log.Printf("Failed to publish update to order %s: %v", id, err)
+		return nil
+	}
+	s.broker.Publish(pubsub.OrderUpdated, updated, order.Status)
+
+	return nil
+}
+
+// ListOrderEvents lists the status changes of a food order, oldest first
+func (s *OrderService) ListOrderEvents(id string) ([]model.OrderEvent, error) {
+	return s.orderRepo.ListOrderEvents(id)
 }
 
 // ListOrdersByUserID lists food orders by user ID
@@ -213,14 +349,17 @@ func (s *OrderService) ListOrdersByUserID(userID string, limit, offset int) ([]m
 		}
 
 		response := model.FoodOrderResponse{
-			ID:         order.ID,
-			UserID:     order.UserID,
-			RoomID:     order.RoomID,
-			Status:     order.Status,
-			TotalPrice: order.TotalPrice,
-			Notes:      order.Notes,
-			Items:      orderItems,
-			CreatedAt:  order.CreatedAt,
+			ID:            order.ID,
+			UserID:        order.UserID,
+			RoomID:        order.RoomID,
+			Status:        order.Status,
+			TotalPrice:    order.TotalPrice,
+			TaxTotal:      order.TaxTotal,
+			TaxBreakdown:  order.TaxBreakdown,
+			Notes:         order.Notes,
+			PaymentStatus: order.PaymentStatus,
+			Items:         orderItems,
+			CreatedAt:     order.CreatedAt,
 		}
 
 		responses = append(responses, response)
@@ -265,14 +404,17 @@ func (s *OrderService) ListOrders(limit, offset int) ([]model.FoodOrderResponse,
 		}
 
 		response := model.FoodOrderResponse{
-			ID:         order.ID,
-			UserID:     order.UserID,
-			RoomID:     order.RoomID,
-			Status:     order.Status,
-			TotalPrice: order.TotalPrice,
-			Notes:      order.Notes,
-			Items:      orderItems,
-			CreatedAt:  order.CreatedAt,
+			ID:            order.ID,
+			UserID:        order.UserID,
+			RoomID:        order.RoomID,
+			Status:        order.Status,
+			TotalPrice:    order.TotalPrice,
+			TaxTotal:      order.TaxTotal,
+			TaxBreakdown:  order.TaxBreakdown,
+			Notes:         order.Notes,
+			PaymentStatus: order.PaymentStatus,
+			Items:         orderItems,
+			CreatedAt:     order.CreatedAt,
 		}
 
 		responses = append(responses, response)
@@ -284,14 +426,7 @@ func (s *OrderService) ListOrders(limit, offset int) ([]model.FoodOrderResponse,
 // ListOrdersByStatus lists food orders by status
 func (s *OrderService) ListOrdersByStatus(status string, limit, offset int) ([]model.FoodOrderResponse, error) {
 	// Validate status
-	validStatuses := map[string]bool{
-		"pending":   true,
-		"preparing": true,
-		"delivered": true,
-		"cancelled": true,
-	}
-
-	if !validStatuses[status] {
+	if !model.ValidOrderStatus(status) {
 		return nil, errors.New("invalid status")
 	}
 
@@ -329,14 +464,17 @@ func (s *OrderService) ListOrdersByStatus(status string, limit, offset int) ([]m
 		}
 
 		response := model.FoodOrderResponse{
-			ID:         order.ID,
-			UserID:     order.UserID,
-			RoomID:     order.RoomID,
-			Status:     order.Status,
-			TotalPrice: order.TotalPrice,
-			Notes:      order.Notes,
-			Items:      orderItems,
-			CreatedAt:  order.CreatedAt,
+			ID:            order.ID,
+			UserID:        order.UserID,
+			RoomID:        order.RoomID,
+			Status:        order.Status,
+			TotalPrice:    order.TotalPrice,
+			TaxTotal:      order.TaxTotal,
+			TaxBreakdown:  order.TaxBreakdown,
+			Notes:         order.Notes,
+			PaymentStatus: order.PaymentStatus,
+			Items:         orderItems,
+			CreatedAt:     order.CreatedAt,
 		}
 
 		responses = append(responses, response)
@@ -344,3 +482,31 @@ func (s *OrderService) ListOrdersByStatus(status string, limit, offset int) ([]m
 
 	return responses, nil
 }
+
+// ListRoomCharges lists the orders delivered to a room between two times that are to be
+// charged to the folio of the stay in the room
+func (s *OrderService) ListRoomCharges(roomID string, since, until time.Time) ([]model.FoodOrderResponse, error) {
+	if roomID == "" {
+		return nil, errors.New("room ID is required")
+	}
+
+	if until.Before(since) {
+		return nil, errors.New("until must not be before since")
+	}
+
+	orders, err := s.orderRepo.ListRoomCharges(roomID, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []model.FoodOrderResponse
+	for _, order := range orders {
+		response, err := s.GetOrderByID(order.ID)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
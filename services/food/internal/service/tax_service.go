@@ -0,0 +1,60 @@
+package service
+
+import (
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+	"github.com/flaminshinjan/address.ai/services/food/internal/repository"
+)
+
+// TaxService handles business logic for the taxes and service charges levied on food orders
+type TaxService struct {
+	taxRuleRepo *repository.TaxRuleRepository
+}
+
+// NewTaxService creates a new TaxService
+func NewTaxService(taxRuleRepo *repository.TaxRuleRepository) *TaxService {
+	return &TaxService{
+		taxRuleRepo: taxRuleRepo,
+	}
+}
+
+// CreateTaxRule creates a new tax rule
+func (s *TaxService) CreateTaxRule(rule tax.Rule) (tax.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return tax.Rule{}, err
+	}
+
+	return s.taxRuleRepo.CreateTaxRule(rule)
+}
+
+// GetTaxRuleByID gets a tax rule by ID
+func (s *TaxService) GetTaxRuleByID(id string) (tax.Rule, error) {
+	return s.taxRuleRepo.GetTaxRuleByID(id)
+}
+
+// UpdateTaxRule updates a tax rule. Orders already placed keep the taxes they were charged.
+func (s *TaxService) UpdateTaxRule(id string, rule tax.Rule) (tax.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return tax.Rule{}, err
+	}
+
+	// Check if rule exists
+	existingRule, err := s.taxRuleRepo.GetTaxRuleByID(id)
+	if err != nil {
+		return tax.Rule{}, err
+	}
+
+	rule.ID = existingRule.ID
+	rule.CreatedAt = existingRule.CreatedAt
+
+	return s.taxRuleRepo.UpdateTaxRule(rule)
+}
+
+// DeleteTaxRule deletes a tax rule
+func (s *TaxService) DeleteTaxRule(id string) error {
+	return s.taxRuleRepo.DeleteTaxRule(id)
+}
+
+// ListTaxRules lists all tax rules
+func (s *TaxService) ListTaxRules(limit, offset int) ([]tax.Rule, error) {
+	return s.taxRuleRepo.ListTaxRules(limit, offset)
+}
@@ -0,0 +1,4 @@
+ALTER TABLE food_orders DROP COLUMN IF EXISTS tax_breakdown;
+ALTER TABLE food_orders DROP COLUMN IF EXISTS tax_total;
+
+DROP TABLE IF EXISTS food_tax_rules;
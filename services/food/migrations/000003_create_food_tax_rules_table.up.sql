@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS food_tax_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('tax', 'service_charge')),
+    rate DECIMAL(5,2) NOT NULL CHECK (rate > 0 AND rate <= 100),
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_food_tax_rule_dates CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_food_tax_rules_effective ON food_tax_rules(effective_from, effective_to);
+
+-- The taxes and service charges an order was placed with
+ALTER TABLE food_orders ADD COLUMN IF NOT EXISTS tax_total DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE food_orders ADD COLUMN IF NOT EXISTS tax_breakdown JSONB;
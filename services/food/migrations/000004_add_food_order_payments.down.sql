@@ -0,0 +1,3 @@
+ALTER TABLE food_orders DROP COLUMN IF EXISTS payment_transaction_id;
+ALTER TABLE food_orders DROP COLUMN IF EXISTS payment_gateway;
+ALTER TABLE food_orders DROP COLUMN IF EXISTS payment_status;
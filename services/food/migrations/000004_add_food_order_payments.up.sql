@@ -0,0 +1,5 @@
+-- An order's card payment, authorized when it is placed and captured once it is delivered.
+-- Orders charged to a room's folio are paid at check-out and stay unpaid here.
+ALTER TABLE food_orders ADD COLUMN IF NOT EXISTS payment_status VARCHAR(20) NOT NULL DEFAULT 'unpaid';
+ALTER TABLE food_orders ADD COLUMN IF NOT EXISTS payment_gateway VARCHAR(50);
+ALTER TABLE food_orders ADD COLUMN IF NOT EXISTS payment_transaction_id VARCHAR(255);
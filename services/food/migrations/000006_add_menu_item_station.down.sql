@@ -0,0 +1 @@
+ALTER TABLE menu_items DROP COLUMN IF EXISTS station;
@@ -0,0 +1,2 @@
+-- The kitchen station that prepares an item, such as grill, pastry or bar
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS station VARCHAR(50) NOT NULL DEFAULT '';
@@ -4,17 +4,39 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
 	"github.com/flaminshinjan/address.ai/pkg/common/db"
 	"github.com/flaminshinjan/address.ai/pkg/common/logger"
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
+	"github.com/flaminshinjan/address.ai/services/room/internal/foodclient"
 	"github.com/flaminshinjan/address.ai/services/room/internal/handler"
 	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+	"github.com/flaminshinjan/address.ai/services/room/internal/scheduler"
 	"github.com/flaminshinjan/address.ai/services/room/internal/service"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+const (
+	// bookingHoldTTL is how long a room is held while a guest checks out
+	bookingHoldTTL = 10 * time.Minute
+
+	// waitlistOfferTTL is how long a room offered to the waitlist is held for the guest
+	waitlistOfferTTL = 2 * time.Hour
+
+	// streamTicketTTL is how long a stream ticket can be used for
+	streamTicketTTL = 30 * time.Second
+
+	// foodServiceTimeout is how long a call to the food service may take
+	foodServiceTimeout = 10 * time.Second
+)
+
 // @title Room Management Service API
 // @version 1.0
 // @description This is the room management service API for the hotel management system
@@ -56,6 +78,35 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
 
+	// Invoices are numbered in a separate sequence per property
+	propertyCode := os.Getenv("PROPERTY_CODE")
+	if propertyCode == "" {
+		propertyCode = "MAIN" // Default property code
+	}
+
+	// Card payments go through the configured gateway; the fake gateway moves no money
+	paymentGatewayName := os.Getenv("PAYMENT_GATEWAY")
+	if paymentGatewayName == "" {
+		log.Fatal("PAYMENT_GATEWAY environment variable is required")
+	}
+
+	// Room-service orders are read from the food service to charge them to folios
+	foodServiceURL := os.Getenv("FOOD_SERVICE_URL")
+	if foodServiceURL == "" {
+		foodServiceURL = "http://localhost:8082" // Default URL of the food service
+	}
+
+	// Prices are stored in the property's base currency
+	if baseCurrency := os.Getenv("BASE_CURRENCY"); baseCurrency != "" {
+		money.DefaultCurrency = strings.ToUpper(baseCurrency)
+	}
+
+	// Pages on other origins allowed to open WebSockets, comma-separated
+	allowedOrigins := os.Getenv("ALLOWED_ORIGINS")
+	if allowedOrigins == "" {
+		allowedOrigins = "http://localhost:3000" // Default origin of the frontend dev server
+	}
+
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
 		logLevel = "info" // Default log level
@@ -74,13 +125,63 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
+	paymentGateway, err := payment.NewGateway(paymentGatewayName)
+	if err != nil {
+		log.Fatalf("Failed to set up payment gateway: %v", err)
+	}
+
 	// Initialize repositories
 	roomRepo := repository.NewRoomRepository(database)
 	bookingRepo := repository.NewBookingRepository(database)
+	bookingGroupRepo := repository.NewBookingGroupRepository(database)
+	roomTypeRepo := repository.NewRoomTypeRepository(database)
+	rateRuleRepo := repository.NewRateRuleRepository(database)
+	taxRuleRepo := repository.NewTaxRuleRepository(database)
+	policyRepo := repository.NewCancellationPolicyRepository(database)
+	waitlistRepo := repository.NewWaitlistRepository(database)
+	maintenanceRepo := repository.NewMaintenanceRepository(database)
+	housekeepingRepo := repository.NewHousekeepingRepository(database)
+	folioRepo := repository.NewFolioRepository(database)
+	invoiceRepo := repository.NewInvoiceRepository(database)
+	currencyRateRepo := repository.NewCurrencyRateRepository(database)
+	paymentRepo := repository.NewPaymentRepository(database)
+
+	// Calls to the food service are signed with the shared JWT secret
+	foodClient := foodclient.New(strings.TrimRight(foodServiceURL, "/"), jwtSecret, foodServiceTimeout)
+
+	// Guests are told over their notification WebSocket when their bookings move
+	hub := notify.NewHub(strings.Split(strings.ReplaceAll(allowedOrigins, " ", ""), ","))
+
+	// Streams opened by clients that cannot send the Authorization header authenticate
+	// with a single-use ticket rather than the token, which would end up in access logs
+	tickets := auth.NewTicketStore(streamTicketTTL)
 
 	// Initialize services
-	roomService := service.NewRoomService(roomRepo, bookingRepo)
-	bookingService := service.NewBookingService(bookingRepo, roomRepo)
+	pricingService := service.NewPricingService(rateRuleRepo, roomRepo, taxRuleRepo)
+	policyService := service.NewCancellationPolicyService(policyRepo)
+	folioService := service.NewFolioService(folioRepo, foodClient)
+	paymentService := service.NewPaymentService(paymentRepo, folioRepo, folioService, paymentGateway)
+	waitlistService := service.NewWaitlistService(waitlistRepo, bookingRepo, roomRepo, roomTypeRepo, pricingService, paymentService, hub, waitlistOfferTTL)
+	invoiceService := service.NewInvoiceService(invoiceRepo, folioRepo, bookingRepo, roomRepo, foodClient, propertyCode)
+	currencyService := service.NewCurrencyService(currencyRateRepo)
+	roomService := service.NewRoomService(roomRepo, bookingRepo, roomTypeRepo, housekeepingRepo)
+	bookingService := service.NewBookingService(bookingRepo, roomRepo, roomTypeRepo, pricingService, policyService, waitlistService, invoiceService, currencyService, paymentService, folioService, hub, bookingHoldTTL)
+	bookingGroupService := service.NewBookingGroupService(bookingGroupRepo, roomRepo, roomTypeRepo, pricingService, policyService, waitlistService, paymentService, hub)
+	roomTypeService := service.NewRoomTypeService(roomTypeRepo)
+	calendarService := service.NewCalendarService(bookingRepo, maintenanceRepo, roomRepo, roomTypeRepo)
+	maintenanceService := service.NewMaintenanceService(maintenanceRepo, roomRepo)
+	housekeepingService := service.NewHousekeepingService(housekeepingRepo, roomRepo)
+
+	// Start background jobs
+	jobs := scheduler.NewScheduler()
+	jobs.Add("no-show sweep", time.Hour, bookingService.MarkNoShows)
+	jobs.Add("booking hold reaper", time.Minute, bookingService.ReapHolds)
+	jobs.Add("unpaid deposit cancellation", 5*time.Minute, bookingService.CancelUnpaidDeposits)
+	jobs.Add("waitlist offer expiry", time.Minute, waitlistService.ExpireOffers)
+	jobs.Add("maintenance status sync", 15*time.Minute, maintenanceService.SyncRoomStatuses)
+	jobs.Add("daily housekeeping tasks", time.Hour, housekeepingService.GenerateDailyTasks)
+	jobs.Start()
+	defer jobs.Stop()
 
 	// Initialize Echo
 	e := echo.New()
@@ -95,7 +196,7 @@ func main() {
 	e.Use(middleware.CORS())
 
 	// Initialize handlers
-	h := handler.NewHandler(roomService, bookingService, jwtSecret)
+	h := handler.NewHandler(roomService, bookingService, bookingGroupService, roomTypeService, pricingService, policyService, waitlistService, calendarService, maintenanceService, housekeepingService, folioService, invoiceService, currencyService, paymentService, hub, tickets, jwtSecret)
 
 	// Register routes
 	api := e.Group("/api/v1")
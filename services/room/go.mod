@@ -3,6 +3,7 @@ module github.com/shinjan/address.ai/services/room
 go 1.18
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
 	github.com/flaminshinjan/address.ai v0.0.0-20250311055815-875e761969d1
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/mux v1.8.1
@@ -0,0 +1,112 @@
+// Package foodclient reads room-service orders from the food service, which keeps them in
+// its own database, so that they can be charged to folios and invoiced.
+package foodclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// ErrOrderNotFound is returned when the food service has no order with the requested ID
+var ErrOrderNotFound = errors.New("order not found")
+
+// Client calls the food service's order API
+type Client struct {
+	baseURL   string
+	jwtSecret string
+	http      *http.Client
+}
+
+// New creates a new Client for the food service at baseURL. Requests are signed with a
+// staff token made from jwtSecret, which the services share.
+func New(baseURL, jwtSecret string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:   baseURL,
+		jwtSecret: jwtSecret,
+		http:      &http.Client{Timeout: timeout},
+	}
+}
+
+// envelope is the body of every food service response
+type envelope struct {
+	Success bool            `json:"success"`
+	Error   string          `json:"error"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// get calls path on the food service and decodes the data of its response into dest
+func (c *Client) get(path string, query url.Values, dest interface{}) error {
+	token, err := auth.GenerateToken("room-service", "room-service", "staff", c.jwtSecret)
+	if err != nil {
+		return err
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("food service unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrOrderNotFound
+	}
+
+	var body envelope
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("invalid response from food service: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK || !body.Success {
+		return fmt.Errorf("food service returned %d: %s", resp.StatusCode, body.Error)
+	}
+
+	if len(body.Data) == 0 || string(body.Data) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(body.Data, dest)
+}
+
+// RoomCharges lists the orders delivered to a room that were placed between since and
+// until and are to be charged to the folio of the stay in the room
+func (c *Client) RoomCharges(roomID string, since, until time.Time) ([]model.FoodOrder, error) {
+	query := url.Values{}
+	query.Set("room_id", roomID)
+	query.Set("since", since.Format(time.RFC3339Nano))
+	query.Set("until", until.Format(time.RFC3339Nano))
+
+	var orders []model.FoodOrder
+	if err := c.get("/api/v1/orders/room-charges", query, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// GetOrder gets a food order with its items
+func (c *Client) GetOrder(id string) (model.FoodOrder, error) {
+	var order model.FoodOrder
+	if err := c.get("/api/v1/orders/"+url.PathEscape(id), nil, &order); err != nil {
+		return model.FoodOrder{}, err
+	}
+
+	return order, nil
+}
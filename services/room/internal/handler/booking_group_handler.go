@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// BookingGroupHandler handles HTTP requests for group bookings
+type BookingGroupHandler struct {
+	service   *service.BookingGroupService
+	jwtSecret string
+}
+
+// NewBookingGroupHandler creates a new BookingGroupHandler
+func NewBookingGroupHandler(service *service.BookingGroupService, jwtSecret string) *BookingGroupHandler {
+	return &BookingGroupHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// CreateGroupBooking handles booking several rooms at once
+func (h *BookingGroupHandler) CreateGroupBooking(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	var req model.GroupBookingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	group, err := h.service.CreateGroupBooking(userID, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Group booking created successfully",
+		"data":    group,
+	})
+}
+
+// GetGroup handles getting a booking group by ID
+func (h *BookingGroupHandler) GetGroup(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	group, err := h.service.GetGroupByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking group not found",
+		})
+	}
+
+	// Check if user is authorized to view this group
+	if group.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this booking group",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking group retrieved successfully",
+		"data":    group,
+	})
+}
+
+// ListUserGroups handles listing the booking groups of the current user
+func (h *BookingGroupHandler) ListUserGroups(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	groups, err := h.service.ListGroupsByUserID(userID, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve booking groups",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking groups retrieved successfully",
+		"data":    groups,
+	})
+}
+
+// AmendGroup handles moving every booking in a group to new dates
+func (h *BookingGroupHandler) AmendGroup(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	// Check if group exists and user is authorized
+	existingGroup, err := h.service.GetGroupByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking group not found",
+		})
+	}
+
+	if existingGroup.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to amend this booking group",
+		})
+	}
+
+	var req model.GroupAmendRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	group, err := h.service.AmendGroup(id, userID, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking group amended successfully",
+		"data":    group,
+	})
+}
+
+// CancelGroup handles cancelling every booking in a group
+func (h *BookingGroupHandler) CancelGroup(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	// Check if group exists and user is authorized
+	existingGroup, err := h.service.GetGroupByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking group not found",
+		})
+	}
+
+	if existingGroup.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to cancel this booking group",
+		})
+	}
+
+	cancellations, err := h.service.CancelGroup(id, userID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking group canceled successfully",
+		"data":    cancellations,
+	})
+}
+
+// RegisterRoutes registers the routes for the booking group handler
+func (h *BookingGroupHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	groups := g.Group("/bookings/groups")
+	groups.Use(h.authMiddleware)
+
+	groups.POST("", h.CreateGroupBooking)
+	groups.GET("", h.ListUserGroups)
+	groups.GET("/:id", h.GetGroup)
+	groups.PUT("/:id", h.AmendGroup)
+	groups.DELETE("/:id", h.CancelGroup)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *BookingGroupHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
 	"github.com/flaminshinjan/address.ai/services/room/internal/model"
 	"github.com/flaminshinjan/address.ai/services/room/internal/service"
 	"github.com/labstack/echo/v4"
@@ -39,7 +41,7 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 
 	booking, err := h.service.CreateBooking(userID, req)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
 			"success": false,
 			"error":   err.Error(),
 		})
@@ -52,6 +54,166 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 	})
 }
 
+// CreateHold handles holding a room while the guest checks out
+func (h *BookingHandler) CreateHold(c echo.Context) error {
+	// Get user ID from context
+	userID := c.Get("user_id").(string)
+
+	var req model.BookingRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	hold, err := h.service.HoldRoom(userID, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Room held successfully",
+		"data":    hold,
+	})
+}
+
+// ConfirmHold handles turning a hold into a confirmed booking
+func (h *BookingHandler) ConfirmHold(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+
+	hold, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Hold not found",
+		})
+	}
+
+	// Only the guest who placed the hold can confirm it
+	if hold.UserID != userID {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to confirm this hold",
+		})
+	}
+
+	var req model.PaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	booking, err := h.service.ConfirmHold(id, req)
+	if err != nil {
+		status := http.StatusConflict
+		if errors.Is(err, payment.ErrDeclined) {
+			status = http.StatusPaymentRequired
+		}
+		return c.JSON(status, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking confirmed successfully",
+		"data":    booking,
+	})
+}
+
+// PayDeposit handles paying a booking's deposit
+func (h *BookingHandler) PayDeposit(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	booking, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking not found",
+		})
+	}
+
+	// Check if user is authorized to pay for this booking
+	if booking.UserID != userID && role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to pay for this booking",
+		})
+	}
+
+	var req model.PaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedBooking, err := h.service.PayDeposit(id, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, payment.ErrDeclined) {
+			status = http.StatusPaymentRequired
+		}
+		return c.JSON(status, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Deposit paid successfully",
+		"data":    updatedBooking,
+	})
+}
+
+// ReleaseHold handles giving up a hold before it runs out
+func (h *BookingHandler) ReleaseHold(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	hold, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Hold not found",
+		})
+	}
+
+	// Check if user is authorized to release this hold
+	if hold.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to release this hold",
+		})
+	}
+
+	if err := h.service.ReleaseHold(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Hold released successfully",
+	})
+}
+
 // GetBooking handles getting a booking by ID
 func (h *BookingHandler) GetBooking(c echo.Context) error {
 	id := c.Param("id")
@@ -104,7 +266,8 @@ func (h *BookingHandler) CancelBooking(c echo.Context) error {
 		})
 	}
 
-	if err := h.service.CancelBooking(id); err != nil {
+	cancellation, err := h.service.CancelBooking(id, userID)
+	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"success": false,
 			"error":   err.Error(),
@@ -114,6 +277,183 @@ func (h *BookingHandler) CancelBooking(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success": true,
 		"message": "Booking canceled successfully",
+		"data":    cancellation,
+	})
+}
+
+// GetCancellation handles getting the refund and penalty recorded for a cancelled booking
+func (h *BookingHandler) GetCancellation(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	// Check if booking exists and user is authorized
+	existingBooking, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking not found",
+		})
+	}
+
+	if existingBooking.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this booking",
+		})
+	}
+
+	cancellation, err := h.service.GetCancellation(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Cancellation not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation retrieved successfully",
+		"data":    cancellation,
+	})
+}
+
+// AmendBooking handles changing a booking's dates or room
+func (h *BookingHandler) AmendBooking(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	// Check if booking exists and user is authorized
+	existingBooking, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking not found",
+		})
+	}
+
+	// Check if user is authorized to amend this booking
+	if existingBooking.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to amend this booking",
+		})
+	}
+
+	var req model.BookingAmendRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	booking, err := h.service.AmendBooking(id, userID, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking amended successfully",
+		"data":    booking,
+	})
+}
+
+// GetBookingHistory handles listing the changes made to a booking
+func (h *BookingHandler) GetBookingHistory(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	// Check if booking exists and user is authorized
+	existingBooking, err := h.service.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking not found",
+		})
+	}
+
+	if existingBooking.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this booking",
+		})
+	}
+
+	history, err := h.service.GetBookingHistory(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve booking history",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Booking history retrieved successfully",
+		"data":    history,
+	})
+}
+
+// CheckIn handles checking a guest in
+func (h *BookingHandler) CheckIn(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	booking, err := h.service.CheckIn(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Guest checked in successfully",
+		"data":    booking,
+	})
+}
+
+// CheckOut handles checking a guest out
+func (h *BookingHandler) CheckOut(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	booking, err := h.service.CheckOut(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Guest checked out successfully",
+		"data":    booking,
 	})
 }
 
@@ -208,9 +548,18 @@ func (h *BookingHandler) RegisterRoutes(g *echo.Group) {
 	bookings.Use(h.authMiddleware)
 
 	bookings.POST("", h.CreateBooking)
+	bookings.POST("/holds", h.CreateHold)
+	bookings.POST("/holds/:id/confirm", h.ConfirmHold)
+	bookings.DELETE("/holds/:id", h.ReleaseHold)
 	bookings.GET("/my", h.ListUserBookings)
 	bookings.GET("/:id", h.GetBooking)
+	bookings.PUT("/:id", h.AmendBooking)
+	bookings.GET("/:id/history", h.GetBookingHistory)
+	bookings.POST("/:id/deposit", h.PayDeposit)
+	bookings.POST("/:id/check-in", h.CheckIn)
+	bookings.POST("/:id/check-out", h.CheckOut)
 	bookings.DELETE("/:id", h.CancelBooking)
+	bookings.GET("/:id/cancellation", h.GetCancellation)
 
 	// Admin routes
 	admin := g.Group("/admin/bookings")
@@ -264,3 +613,14 @@ func (h *BookingHandler) adminMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 		return next(c)
 	}
 }
+
+// bookingErrorStatus maps a booking service error to an HTTP status code
+func bookingErrorStatus(err error) int {
+	if errors.Is(err, model.ErrBookingConflict) || errors.Is(err, model.ErrBookingChanged) {
+		return http.StatusConflict
+	}
+	if errors.Is(err, payment.ErrDeclined) {
+		return http.StatusPaymentRequired
+	}
+	return http.StatusBadRequest
+}
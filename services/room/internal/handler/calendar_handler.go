@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// calendarContentType is the media type of iCalendar feeds
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// maxCalendarUpload caps the size of an uploaded iCalendar file
+const maxCalendarUpload = 5 << 20
+
+// CalendarHandler handles HTTP requests for iCalendar feeds and imports
+type CalendarHandler struct {
+	service   *service.CalendarService
+	jwtSecret string
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(service *service.CalendarService, jwtSecret string) *CalendarHandler {
+	return &CalendarHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// ExportRoom handles getting the iCalendar feed of a room
+func (h *CalendarHandler) ExportRoom(c echo.Context) error {
+	id := c.Param("id")
+
+	feed, err := h.service.ExportRoom(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Room not found",
+		})
+	}
+
+	return c.Blob(http.StatusOK, calendarContentType, feed)
+}
+
+// ExportRoomType handles getting the iCalendar feed of every room of a type
+func (h *CalendarHandler) ExportRoomType(c echo.Context) error {
+	id := c.Param("id")
+
+	feed, err := h.service.ExportRoomType(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Room type not found",
+		})
+	}
+
+	return c.Blob(http.StatusOK, calendarContentType, feed)
+}
+
+// ImportRoom handles importing external blocks for a room from an iCalendar file, sent
+// either as the "file" field of a multipart form or as the request body
+func (h *CalendarHandler) ImportRoom(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var body io.Reader = http.MaxBytesReader(c.Response(), c.Request().Body, maxCalendarUpload)
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid calendar file",
+			})
+		}
+		defer src.Close()
+		body = io.LimitReader(src, maxCalendarUpload)
+	}
+
+	result, err := h.service.ImportRoom(id, c.QueryParam("source"), body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Calendar imported successfully",
+		"data":    result,
+	})
+}
+
+// RegisterRoutes registers the routes for the calendar handler
+func (h *CalendarHandler) RegisterRoutes(g *echo.Group) {
+	// Public routes
+	g.GET("/rooms/:id/calendar.ics", h.ExportRoom)
+	g.GET("/room-types/:id/calendar.ics", h.ExportRoomType)
+
+	// Protected routes
+	admin := g.Group("/admin/rooms")
+	admin.Use(h.authMiddleware)
+
+	admin.POST("/:id/calendar/import", h.ImportRoom)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *CalendarHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// CancellationPolicyHandler handles HTTP requests for cancellation policies
+type CancellationPolicyHandler struct {
+	service   *service.CancellationPolicyService
+	jwtSecret string
+}
+
+// NewCancellationPolicyHandler creates a new CancellationPolicyHandler
+func NewCancellationPolicyHandler(service *service.CancellationPolicyService, jwtSecret string) *CancellationPolicyHandler {
+	return &CancellationPolicyHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// ListPolicies handles listing all cancellation policies
+func (h *CancellationPolicyHandler) ListPolicies(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	policies, err := h.service.ListPolicies(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve cancellation policies",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation policies retrieved successfully",
+		"data":    policies,
+	})
+}
+
+// GetPolicy handles getting a cancellation policy by ID
+func (h *CancellationPolicyHandler) GetPolicy(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	policy, err := h.service.GetPolicyByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Cancellation policy not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation policy retrieved successfully",
+		"data":    policy,
+	})
+}
+
+// CreatePolicy handles creating a new cancellation policy
+func (h *CancellationPolicyHandler) CreatePolicy(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var policy model.CancellationPolicy
+	if err := c.Bind(&policy); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdPolicy, err := h.service.CreatePolicy(policy)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation policy created successfully",
+		"data":    createdPolicy,
+	})
+}
+
+// UpdatePolicy handles updating a cancellation policy
+func (h *CancellationPolicyHandler) UpdatePolicy(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var policy model.CancellationPolicy
+	if err := c.Bind(&policy); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedPolicy, err := h.service.UpdatePolicy(id, policy)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation policy updated successfully",
+		"data":    updatedPolicy,
+	})
+}
+
+// DeletePolicy handles deleting a cancellation policy
+func (h *CancellationPolicyHandler) DeletePolicy(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.DeletePolicy(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cancellation policy deleted successfully",
+	})
+}
+
+// RegisterRoutes registers the routes for the cancellation policy handler
+func (h *CancellationPolicyHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	admin := g.Group("/admin/cancellation-policies")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("", h.ListPolicies)
+	admin.GET("/:id", h.GetPolicy)
+	admin.POST("", h.CreatePolicy)
+	admin.PUT("/:id", h.UpdatePolicy)
+	admin.DELETE("/:id", h.DeletePolicy)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *CancellationPolicyHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
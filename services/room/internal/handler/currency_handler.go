@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// maxRatesUpload caps the size of an uploaded CSV file of exchange rates
+const maxRatesUpload = 1 << 20
+
+// CurrencyHandler handles HTTP requests for exchange rates
+type CurrencyHandler struct {
+	service   *service.CurrencyService
+	jwtSecret string
+}
+
+// NewCurrencyHandler creates a new CurrencyHandler
+func NewCurrencyHandler(service *service.CurrencyService, jwtSecret string) *CurrencyHandler {
+	return &CurrencyHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// ListRates handles listing the currencies prices can be shown in
+func (h *CurrencyHandler) ListRates(c echo.Context) error {
+	rates, err := h.service.ListRates()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve currency rates",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Currency rates retrieved successfully",
+		"data":    rates,
+	})
+}
+
+// SetRate handles setting the rate of a currency
+func (h *CurrencyHandler) SetRate(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+	currency := c.Param("currency")
+
+	var req model.CurrencyRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	rate, err := h.service.SetRate(currency, userID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Currency rate updated successfully",
+		"data":    rate,
+	})
+}
+
+// DeleteRate handles removing a currency
+func (h *CurrencyHandler) DeleteRate(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	currency := c.Param("currency")
+
+	if err := h.service.DeleteRate(currency); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Currency rate deleted successfully",
+	})
+}
+
+// ImportRates handles importing rates from a CSV file of currency,rate rows, sent either
+// as the "file" field of a multipart form or as the request body
+func (h *CurrencyHandler) ImportRates(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	var body io.Reader = http.MaxBytesReader(c.Response(), c.Request().Body, maxRatesUpload)
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid CSV file",
+			})
+		}
+		defer src.Close()
+		body = io.LimitReader(src, maxRatesUpload)
+	}
+
+	result, err := h.service.ImportCSV(body, userID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Currency rates imported successfully",
+		"data":    result,
+	})
+}
+
+// RegisterRoutes registers the routes for the currency handler
+func (h *CurrencyHandler) RegisterRoutes(g *echo.Group) {
+	// Public routes
+	g.GET("/currency-rates", h.ListRates)
+
+	// Protected routes
+	admin := g.Group("/admin/currency-rates")
+	admin.Use(h.authMiddleware)
+
+	admin.PUT("/:currency", h.SetRate)
+	admin.DELETE("/:currency", h.DeleteRate)
+	admin.POST("/import", h.ImportRates)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *CurrencyHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// FolioHandler handles HTTP requests for guest folios
+type FolioHandler struct {
+	service   *service.FolioService
+	jwtSecret string
+}
+
+// NewFolioHandler creates a new FolioHandler
+func NewFolioHandler(service *service.FolioService, jwtSecret string) *FolioHandler {
+	return &FolioHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// GetBookingFolio handles getting the running bill of a booking
+func (h *FolioHandler) GetBookingFolio(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	folio, err := h.service.GetFolio(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Folio not found",
+		})
+	}
+
+	// Check if user is authorized to view this folio
+	if folio.UserID != userID && role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this folio",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Folio retrieved successfully",
+		"data":    folio,
+	})
+}
+
+// ListFolios handles listing folios, optionally by status
+func (h *FolioHandler) ListFolios(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	folios, err := h.service.ListFolios(c.QueryParam("status"), limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Folios retrieved successfully",
+		"data":    folios,
+	})
+}
+
+// GetFolio handles getting a folio by ID
+func (h *FolioHandler) GetFolio(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	folio, err := h.service.GetFolioByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Folio not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Folio retrieved successfully",
+		"data":    folio,
+	})
+}
+
+// ChargeMinibar handles charging minibar consumption to a folio
+func (h *FolioHandler) ChargeMinibar(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	var req model.MinibarChargeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	line, err := h.service.ChargeMinibar(id, userID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Minibar charge posted successfully",
+		"data":    line,
+	})
+}
+
+// RecordPayment handles recording a payment against a folio
+func (h *FolioHandler) RecordPayment(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	var req model.FolioPaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	line, err := h.service.RecordPayment(id, userID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Payment recorded successfully",
+		"data":    line,
+	})
+}
+
+// AdjustFolio handles posting a correction to a folio
+func (h *FolioHandler) AdjustFolio(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	var req model.FolioAdjustmentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	line, err := h.service.AdjustFolio(id, userID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Adjustment posted successfully",
+		"data":    line,
+	})
+}
+
+// RegisterRoutes registers the routes for the folio handler
+func (h *FolioHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	bookings := g.Group("/bookings")
+	bookings.Use(h.authMiddleware)
+
+	bookings.GET("/:id/folio", h.GetBookingFolio)
+
+	admin := g.Group("/admin/folios")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("", h.ListFolios)
+	admin.GET("/:id", h.GetFolio)
+	admin.POST("/:id/minibar", h.ChargeMinibar)
+	admin.POST("/:id/payments", h.RecordPayment)
+	admin.POST("/:id/adjustments", h.AdjustFolio)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *FolioHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
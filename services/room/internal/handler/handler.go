@@ -1,25 +1,73 @@
 package handler
 
 import (
+	"errors"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/pkg/common/stream"
 	"github.com/flaminshinjan/address.ai/services/room/internal/service"
 	"github.com/labstack/echo/v4"
 )
 
 // Handler is the main handler for the room service
 type Handler struct {
-	RoomHandler    *RoomHandler
-	BookingHandler *BookingHandler
+	RoomHandler         *RoomHandler
+	BookingHandler      *BookingHandler
+	BookingGroupHandler *BookingGroupHandler
+	RoomTypeHandler     *RoomTypeHandler
+	PricingHandler      *PricingHandler
+	PolicyHandler       *CancellationPolicyHandler
+	WaitlistHandler     *WaitlistHandler
+	CalendarHandler     *CalendarHandler
+	MaintenanceHandler  *MaintenanceHandler
+	HousekeepingHandler *HousekeepingHandler
+	FolioHandler        *FolioHandler
+	InvoiceHandler      *InvoiceHandler
+	CurrencyHandler     *CurrencyHandler
+	PaymentHandler      *PaymentHandler
+	NotificationHandler *stream.NotificationHandler
+	TicketHandler       *stream.TicketHandler
 }
 
 // NewHandler creates a new Handler
 func NewHandler(
 	roomService *service.RoomService,
 	bookingService *service.BookingService,
+	bookingGroupService *service.BookingGroupService,
+	roomTypeService *service.RoomTypeService,
+	pricingService *service.PricingService,
+	policyService *service.CancellationPolicyService,
+	waitlistService *service.WaitlistService,
+	calendarService *service.CalendarService,
+	maintenanceService *service.MaintenanceService,
+	housekeepingService *service.HousekeepingService,
+	folioService *service.FolioService,
+	invoiceService *service.InvoiceService,
+	currencyService *service.CurrencyService,
+	paymentService *service.PaymentService,
+	hub *notify.Hub,
+	tickets *auth.TicketStore,
 	jwtSecret string,
 ) *Handler {
 	return &Handler{
-		RoomHandler:    NewRoomHandler(roomService, bookingService, jwtSecret),
-		BookingHandler: NewBookingHandler(bookingService, jwtSecret),
+		RoomHandler:         NewRoomHandler(roomService, bookingService, currencyService, jwtSecret),
+		BookingHandler:      NewBookingHandler(bookingService, jwtSecret),
+		BookingGroupHandler: NewBookingGroupHandler(bookingGroupService, jwtSecret),
+		RoomTypeHandler:     NewRoomTypeHandler(roomTypeService, currencyService, jwtSecret),
+		PricingHandler:      NewPricingHandler(pricingService, currencyService, jwtSecret),
+		PolicyHandler:       NewCancellationPolicyHandler(policyService, jwtSecret),
+		WaitlistHandler:     NewWaitlistHandler(waitlistService, jwtSecret),
+		CalendarHandler:     NewCalendarHandler(calendarService, jwtSecret),
+		MaintenanceHandler:  NewMaintenanceHandler(maintenanceService, jwtSecret),
+		HousekeepingHandler: NewHousekeepingHandler(housekeepingService, jwtSecret),
+		FolioHandler:        NewFolioHandler(folioService, jwtSecret),
+		InvoiceHandler:      NewInvoiceHandler(invoiceService, jwtSecret),
+		CurrencyHandler:     NewCurrencyHandler(currencyService, jwtSecret),
+		PaymentHandler:      NewPaymentHandler(paymentService, bookingService, jwtSecret),
+		NotificationHandler: stream.NewNotificationHandler(hub, tickets, jwtSecret),
+		TicketHandler:       stream.NewTicketHandler(tickets, jwtSecret),
 	}
 }
 
@@ -30,4 +78,65 @@ func (h *Handler) RegisterRoutes(g *echo.Group) {
 
 	// Register booking routes
 	h.BookingHandler.RegisterRoutes(g)
+
+	// Register group booking routes
+	h.BookingGroupHandler.RegisterRoutes(g)
+
+	// Register room type routes
+	h.RoomTypeHandler.RegisterRoutes(g)
+
+	// Register pricing routes
+	h.PricingHandler.RegisterRoutes(g)
+
+	// Register cancellation policy routes
+	h.PolicyHandler.RegisterRoutes(g)
+
+	// Register waitlist routes
+	h.WaitlistHandler.RegisterRoutes(g)
+
+	// Register iCalendar routes
+	h.CalendarHandler.RegisterRoutes(g)
+
+	// Register maintenance routes
+	h.MaintenanceHandler.RegisterRoutes(g)
+
+	// Register housekeeping routes
+	h.HousekeepingHandler.RegisterRoutes(g)
+
+	// Register folio routes
+	h.FolioHandler.RegisterRoutes(g)
+
+	// Register invoice routes
+	h.InvoiceHandler.RegisterRoutes(g)
+
+	// Register currency routes
+	h.CurrencyHandler.RegisterRoutes(g)
+
+	// Register payment routes
+	h.PaymentHandler.RegisterRoutes(g)
+
+	// Register notification routes
+	h.NotificationHandler.RegisterRoutes(g)
+
+	// Register stream ticket routes
+	h.TicketHandler.RegisterRoutes(g)
+}
+
+// parseDateParam parses a date query parameter given either as YYYY-MM-DD or RFC 3339
+func parseDateParam(c echo.Context, name string) (time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return time.Time{}, errors.New(name + " is required")
+	}
+
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New(name + " must be a date in YYYY-MM-DD format")
+	}
+
+	return date, nil
 }
@@ -0,0 +1,335 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// HousekeepingHandler handles HTTP requests for housekeeping tasks
+type HousekeepingHandler struct {
+	service   *service.HousekeepingService
+	jwtSecret string
+}
+
+// NewHousekeepingHandler creates a new HousekeepingHandler
+func NewHousekeepingHandler(service *service.HousekeepingService, jwtSecret string) *HousekeepingHandler {
+	return &HousekeepingHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// ListTasks handles listing housekeeping tasks, optionally filtered by status and room
+func (h *HousekeepingHandler) ListTasks(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	tasks, err := h.service.ListTasks(c.QueryParam("status"), c.QueryParam("room_id"), limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve housekeeping tasks",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping tasks retrieved successfully",
+		"data":    tasks,
+	})
+}
+
+// ListMyTasks handles listing the pending tasks assigned to the current user
+func (h *HousekeepingHandler) ListMyTasks(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	tasks, err := h.service.ListMyTasks(userID, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve housekeeping tasks",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping tasks retrieved successfully",
+		"data":    tasks,
+	})
+}
+
+// GetTask handles getting a housekeeping task by ID
+func (h *HousekeepingHandler) GetTask(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	task, err := h.service.GetTask(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Housekeeping task not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping task retrieved successfully",
+		"data":    task,
+	})
+}
+
+// CreateTask handles creating a housekeeping task
+func (h *HousekeepingHandler) CreateTask(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var task model.HousekeepingTask
+	if err := c.Bind(&task); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdTask, err := h.service.CreateTask(task)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping task created successfully",
+		"data":    createdTask,
+	})
+}
+
+// UpdateTask handles reassigning or reprioritising a housekeeping task
+func (h *HousekeepingHandler) UpdateTask(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var task model.HousekeepingTask
+	if err := c.Bind(&task); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedTask, err := h.service.UpdateTask(id, task)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping task updated successfully",
+		"data":    updatedTask,
+	})
+}
+
+// CancelTask handles cancelling a housekeeping task
+func (h *HousekeepingHandler) CancelTask(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.CancelTask(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping task cancelled successfully",
+	})
+}
+
+// CompleteTask handles marking a housekeeping task done
+func (h *HousekeepingHandler) CompleteTask(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+	id := c.Param("id")
+
+	// The body is optional
+	var completion model.TaskCompletion
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(&completion); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid request payload",
+			})
+		}
+	}
+
+	task, err := h.service.CompleteTask(id, userID, role == "admin", completion)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Housekeeping task completed successfully",
+		"data":    task,
+	})
+}
+
+// RegisterRoutes registers the routes for the housekeeping handler
+func (h *HousekeepingHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	tasks := g.Group("/housekeeping/tasks")
+	tasks.Use(h.authMiddleware)
+
+	tasks.GET("/my", h.ListMyTasks)
+	tasks.GET("/:id", h.GetTask)
+	tasks.POST("/:id/done", h.CompleteTask)
+
+	admin := g.Group("/admin/housekeeping/tasks")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("", h.ListTasks)
+	admin.POST("", h.CreateTask)
+	admin.PUT("/:id", h.UpdateTask)
+	admin.DELETE("/:id", h.CancelTask)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *HousekeepingHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
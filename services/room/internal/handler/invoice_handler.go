@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// InvoiceHandler handles HTTP requests for invoices
+type InvoiceHandler struct {
+	service   *service.InvoiceService
+	jwtSecret string
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler
+func NewInvoiceHandler(service *service.InvoiceService, jwtSecret string) *InvoiceHandler {
+	return &InvoiceHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// GetBookingInvoice handles getting the invoice of a completed stay
+func (h *InvoiceHandler) GetBookingInvoice(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	invoice, err := h.service.IssueForBooking(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// Check if user is authorized to view this invoice
+	if invoice.UserID != userID && role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this invoice",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Invoice retrieved successfully",
+		"data":    invoice,
+	})
+}
+
+// GetFoodOrderInvoice handles getting the invoice of a delivered food order
+func (h *InvoiceHandler) GetFoodOrderInvoice(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	invoice, err := h.service.IssueForFoodOrder(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// Check if user is authorized to view this invoice
+	if invoice.UserID != userID && role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this invoice",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Invoice retrieved successfully",
+		"data":    invoice,
+	})
+}
+
+// authorizedInvoice gets an invoice the current user may see, writing the error response
+// and returning false otherwise
+func (h *InvoiceHandler) authorizedInvoice(c echo.Context) (model.Invoice, bool, error) {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	invoice, err := h.service.GetInvoice(id)
+	if err != nil {
+		return model.Invoice{}, false, c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Invoice not found",
+		})
+	}
+
+	// Check if user is authorized to view this invoice
+	if invoice.UserID != userID && role != "admin" && role != "staff" {
+		return model.Invoice{}, false, c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view this invoice",
+		})
+	}
+
+	return invoice, true, nil
+}
+
+// GetInvoice handles getting an invoice by ID
+func (h *InvoiceHandler) GetInvoice(c echo.Context) error {
+	invoice, ok, err := h.authorizedInvoice(c)
+	if !ok {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Invoice retrieved successfully",
+		"data":    invoice,
+	})
+}
+
+// DownloadInvoice handles downloading an invoice as a PDF
+func (h *InvoiceHandler) DownloadInvoice(c echo.Context) error {
+	invoice, ok, err := h.authorizedInvoice(c)
+	if !ok {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", invoice.Number+".pdf"))
+	return c.Blob(http.StatusOK, "application/pdf", h.service.RenderPDF(invoice))
+}
+
+// ListMyInvoices handles listing the current user's invoices
+func (h *InvoiceHandler) ListMyInvoices(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	invoices, err := h.service.ListUserInvoices(userID, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve invoices",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Invoices retrieved successfully",
+		"data":    invoices,
+	})
+}
+
+// ListInvoices handles listing all invoices
+func (h *InvoiceHandler) ListInvoices(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	invoices, err := h.service.ListInvoices(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve invoices",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Invoices retrieved successfully",
+		"data":    invoices,
+	})
+}
+
+// RegisterRoutes registers the routes for the invoice handler
+func (h *InvoiceHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	bookings := g.Group("/bookings")
+	bookings.Use(h.authMiddleware)
+	bookings.GET("/:id/invoice", h.GetBookingInvoice)
+
+	foodOrders := g.Group("/food-orders")
+	foodOrders.Use(h.authMiddleware)
+	foodOrders.GET("/:id/invoice", h.GetFoodOrderInvoice)
+
+	invoices := g.Group("/invoices")
+	invoices.Use(h.authMiddleware)
+
+	invoices.GET("/my", h.ListMyInvoices)
+	invoices.GET("/:id", h.GetInvoice)
+	invoices.GET("/:id/pdf", h.DownloadInvoice)
+
+	admin := g.Group("/admin/invoices")
+	admin.Use(h.authMiddleware)
+	admin.GET("", h.ListInvoices)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *InvoiceHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
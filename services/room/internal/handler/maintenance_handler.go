@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// MaintenanceHandler handles HTTP requests for room maintenance windows
+type MaintenanceHandler struct {
+	service   *service.MaintenanceService
+	jwtSecret string
+}
+
+// NewMaintenanceHandler creates a new MaintenanceHandler
+func NewMaintenanceHandler(service *service.MaintenanceService, jwtSecret string) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// ListMaintenance handles listing maintenance windows, optionally for one room
+func (h *MaintenanceHandler) ListMaintenance(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	windows, err := h.service.ListMaintenance(c.QueryParam("room_id"), limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve maintenance windows",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance windows retrieved successfully",
+		"data":    windows,
+	})
+}
+
+// ListMyMaintenance handles listing the open maintenance windows assigned to the current user
+func (h *MaintenanceHandler) ListMyMaintenance(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	windows, err := h.service.ListAssignedMaintenance(userID, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve maintenance windows",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance windows retrieved successfully",
+		"data":    windows,
+	})
+}
+
+// GetMaintenance handles getting a maintenance window by ID
+func (h *MaintenanceHandler) GetMaintenance(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	window, err := h.service.GetMaintenanceByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Maintenance window not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance window retrieved successfully",
+		"data":    window,
+	})
+}
+
+// ScheduleMaintenance handles scheduling a maintenance window
+func (h *MaintenanceHandler) ScheduleMaintenance(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	userID := c.Get("user_id").(string)
+
+	var req model.MaintenanceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	window, err := h.service.ScheduleMaintenance(userID, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance scheduled successfully",
+		"data":    window,
+	})
+}
+
+// UpdateMaintenance handles rescheduling or reassigning a maintenance window
+func (h *MaintenanceHandler) UpdateMaintenance(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var req model.MaintenanceRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	window, err := h.service.UpdateMaintenance(id, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance updated successfully",
+		"data":    window,
+	})
+}
+
+// CancelMaintenance handles cancelling a maintenance window that has not started
+func (h *MaintenanceHandler) CancelMaintenance(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.CancelMaintenance(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance cancelled successfully",
+	})
+}
+
+// CompleteMaintenance handles finishing an active maintenance window early
+func (h *MaintenanceHandler) CompleteMaintenance(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	window, err := h.service.CompleteMaintenance(id)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Maintenance completed successfully",
+		"data":    window,
+	})
+}
+
+// RegisterRoutes registers the routes for the maintenance handler
+func (h *MaintenanceHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	maintenance := g.Group("/maintenance")
+	maintenance.Use(h.authMiddleware)
+	maintenance.GET("/my", h.ListMyMaintenance)
+
+	admin := g.Group("/admin/maintenance")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("", h.ListMaintenance)
+	admin.GET("/:id", h.GetMaintenance)
+	admin.POST("", h.ScheduleMaintenance)
+	admin.PUT("/:id", h.UpdateMaintenance)
+	admin.DELETE("/:id", h.CancelMaintenance)
+	admin.POST("/:id/complete", h.CompleteMaintenance)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *MaintenanceHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
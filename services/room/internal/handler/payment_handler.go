@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// PaymentHandler handles HTTP requests for booking payments
+type PaymentHandler struct {
+	service        *service.PaymentService
+	bookingService *service.BookingService
+	jwtSecret      string
+}
+
+// NewPaymentHandler creates a new PaymentHandler
+func NewPaymentHandler(service *service.PaymentService, bookingService *service.BookingService, jwtSecret string) *PaymentHandler {
+	return &PaymentHandler{
+		service:        service,
+		bookingService: bookingService,
+		jwtSecret:      jwtSecret,
+	}
+}
+
+// ListBookingPayments handles listing the payments of a booking
+func (h *PaymentHandler) ListBookingPayments(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	booking, err := h.bookingService.GetBookingByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Booking not found",
+		})
+	}
+
+	// Check if user is authorized to view this booking's payments
+	if booking.UserID != userID && role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to view these payments",
+		})
+	}
+
+	payments, err := h.service.ListBookingPayments(id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve payments",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Payments retrieved successfully",
+		"data":    payments,
+	})
+}
+
+// GetPayment handles getting a payment by ID
+func (h *PaymentHandler) GetPayment(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	payment, err := h.service.GetPayment(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Payment not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Payment retrieved successfully",
+		"data":    payment,
+	})
+}
+
+// RefundPayment handles refunding some or all of a captured payment
+func (h *PaymentHandler) RefundPayment(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var req model.RefundRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	payment, err := h.service.RefundPayment(id, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Payment refunded successfully",
+		"data":    payment,
+	})
+}
+
+// RegisterRoutes registers the routes for the payment handler
+func (h *PaymentHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	bookings := g.Group("/bookings")
+	bookings.Use(h.authMiddleware)
+
+	bookings.GET("/:id/payments", h.ListBookingPayments)
+
+	admin := g.Group("/admin/payments")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("/:id", h.GetPayment)
+	admin.POST("/:id/refund", h.RefundPayment)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *PaymentHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -0,0 +1,473 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// PricingHandler handles HTTP requests for price quotes, rate rules and tax rules
+type PricingHandler struct {
+	service         *service.PricingService
+	currencyService *service.CurrencyService
+	jwtSecret       string
+}
+
+// NewPricingHandler creates a new PricingHandler
+func NewPricingHandler(service *service.PricingService, currencyService *service.CurrencyService, jwtSecret string) *PricingHandler {
+	return &PricingHandler{
+		service:         service,
+		currencyService: currencyService,
+		jwtSecret:       jwtSecret,
+	}
+}
+
+// GetQuote handles pricing a prospective stay in a room night by night
+func (h *PricingHandler) GetQuote(c echo.Context) error {
+	id := c.Param("id")
+
+	startDate, err := parseDateParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	endDate, err := parseDateParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	quote, err := h.service.Quote(id, startDate, endDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// The quote stays in the base currency, with its total also shown in the guest's
+	if currency := c.QueryParam("currency"); currency != "" {
+		rate, err := h.currencyService.GetRate(currency)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+		}
+		quote.ShowIn(rate)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Quote retrieved successfully",
+		"data":    quote,
+	})
+}
+
+// ListRateRules handles listing all rate rules
+func (h *PricingHandler) ListRateRules(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	rules, err := h.service.ListRateRules(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve rate rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Rate rules retrieved successfully",
+		"data":    rules,
+	})
+}
+
+// GetRateRule handles getting a rate rule by ID
+func (h *PricingHandler) GetRateRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	rule, err := h.service.GetRateRuleByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Rate rule not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Rate rule retrieved successfully",
+		"data":    rule,
+	})
+}
+
+// CreateRateRule handles creating a new rate rule
+func (h *PricingHandler) CreateRateRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var rule model.RateRule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdRule, err := h.service.CreateRateRule(rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Rate rule created successfully",
+		"data":    createdRule,
+	})
+}
+
+// UpdateRateRule handles updating a rate rule
+func (h *PricingHandler) UpdateRateRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var rule model.RateRule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedRule, err := h.service.UpdateRateRule(id, rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Rate rule updated successfully",
+		"data":    updatedRule,
+	})
+}
+
+// DeleteRateRule handles deleting a rate rule
+func (h *PricingHandler) DeleteRateRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.DeleteRateRule(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Rate rule deleted successfully",
+	})
+}
+
+// ListTaxRules handles listing all tax rules
+func (h *PricingHandler) ListTaxRules(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	rules, err := h.service.ListTaxRules(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve tax rules",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rules retrieved successfully",
+		"data":    rules,
+	})
+}
+
+// GetTaxRule handles getting a tax rule by ID
+func (h *PricingHandler) GetTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	rule, err := h.service.GetTaxRuleByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Tax rule not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule retrieved successfully",
+		"data":    rule,
+	})
+}
+
+// CreateTaxRule handles creating a new tax rule
+func (h *PricingHandler) CreateTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var rule tax.Rule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdRule, err := h.service.CreateTaxRule(rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule created successfully",
+		"data":    createdRule,
+	})
+}
+
+// UpdateTaxRule handles updating a tax rule
+func (h *PricingHandler) UpdateTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var rule tax.Rule
+	if err := c.Bind(&rule); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedRule, err := h.service.UpdateTaxRule(id, rule)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule updated successfully",
+		"data":    updatedRule,
+	})
+}
+
+// DeleteTaxRule handles deleting a tax rule
+func (h *PricingHandler) DeleteTaxRule(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.DeleteTaxRule(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rule deleted successfully",
+	})
+}
+
+// RegisterRoutes registers the routes for the pricing handler
+func (h *PricingHandler) RegisterRoutes(g *echo.Group) {
+	// Public routes
+	g.GET("/rooms/:id/quote", h.GetQuote)
+
+	// Protected routes
+	admin := g.Group("/admin/rate-rules")
+	admin.Use(h.authMiddleware)
+
+	admin.GET("", h.ListRateRules)
+	admin.GET("/:id", h.GetRateRule)
+	admin.POST("", h.CreateRateRule)
+	admin.PUT("/:id", h.UpdateRateRule)
+	admin.DELETE("/:id", h.DeleteRateRule)
+
+	taxes := g.Group("/admin/tax-rules")
+	taxes.Use(h.authMiddleware)
+
+	taxes.GET("", h.ListTaxRules)
+	taxes.GET("/:id", h.GetTaxRule)
+	taxes.POST("", h.CreateTaxRule)
+	taxes.PUT("/:id", h.UpdateTaxRule)
+	taxes.DELETE("/:id", h.DeleteTaxRule)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *PricingHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -12,22 +12,32 @@ import (
 
 // RoomHandler handles HTTP requests for rooms
 type RoomHandler struct {
-	roomService    *service.RoomService
-	bookingService *service.BookingService
-	jwtSecret      string
+	roomService     *service.RoomService
+	bookingService  *service.BookingService
+	currencyService *service.CurrencyService
+	jwtSecret       string
 }
 
 // NewRoomHandler creates a new RoomHandler
-func NewRoomHandler(roomService *service.RoomService, bookingService *service.BookingService, jwtSecret string) *RoomHandler {
+func NewRoomHandler(roomService *service.RoomService, bookingService *service.BookingService, currencyService *service.CurrencyService, jwtSecret string) *RoomHandler {
 	return &RoomHandler{
-		roomService:    roomService,
-		bookingService: bookingService,
-		jwtSecret:      jwtSecret,
+		roomService:     roomService,
+		bookingService:  bookingService,
+		currencyService: currencyService,
+		jwtSecret:       jwtSecret,
 	}
 }
 
-// ListRooms handles listing all rooms
+// ListRooms handles listing all rooms, with prices in the currency query parameter if given
 func (h *RoomHandler) ListRooms(c echo.Context) error {
+	rate, err := h.currencyService.GetRate(c.QueryParam("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
 	// Get query parameters
 	limitStr := c.QueryParam("limit")
 	offsetStr := c.QueryParam("offset")
@@ -56,6 +66,10 @@ func (h *RoomHandler) ListRooms(c echo.Context) error {
 		})
 	}
 
+	for i := range rooms {
+		rooms[i].PricePerDay = rate.Convert(rooms[i].PricePerDay)
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success": true,
 		"message": "Rooms retrieved successfully",
@@ -63,10 +77,18 @@ func (h *RoomHandler) ListRooms(c echo.Context) error {
 	})
 }
 
-// GetRoom handles getting a room by ID
+// GetRoom handles getting a room by ID, with its price in the currency query parameter if given
 func (h *RoomHandler) GetRoom(c echo.Context) error {
 	id := c.Param("id")
 
+	rate, err := h.currencyService.GetRate(c.QueryParam("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
 	room, err := h.roomService.GetRoomByID(id)
 	if err != nil {
 		return c.JSON(http.StatusNotFound, map[string]interface{}{
@@ -75,6 +97,8 @@ func (h *RoomHandler) GetRoom(c echo.Context) error {
 		})
 	}
 
+	room.PricePerDay = rate.Convert(room.PricePerDay)
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"success": true,
 		"message": "Room retrieved successfully",
@@ -221,6 +245,48 @@ func (h *RoomHandler) UpdateRoomStatus(c echo.Context) error {
 	})
 }
 
+// GetOccupancyCalendar handles getting the rooms × dates occupancy matrix
+func (h *RoomHandler) GetOccupancyCalendar(c echo.Context) error {
+	// Check if user is admin or staff
+	role := c.Get("role").(string)
+	if role != "admin" && role != "staff" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin or staff access required",
+		})
+	}
+
+	startDate, err := parseDateParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	endDate, err := parseDateParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	calendar, err := h.roomService.GetOccupancyCalendar(startDate, endDate, c.QueryParam("room_type_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Occupancy calendar retrieved successfully",
+		"data":    calendar,
+	})
+}
+
 // RegisterRoutes registers the routes for the room handler
 func (h *RoomHandler) RegisterRoutes(g *echo.Group) {
 	// Public routes
@@ -231,6 +297,7 @@ func (h *RoomHandler) RegisterRoutes(g *echo.Group) {
 	admin := g.Group("/admin/rooms")
 	admin.Use(h.authMiddleware)
 
+	admin.GET("/calendar", h.GetOccupancyCalendar)
 	admin.POST("", h.CreateRoom)
 	admin.PUT("/:id", h.UpdateRoom)
 	admin.DELETE("/:id", h.DeleteRoom)
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// RoomTypeHandler handles HTTP requests for room types
+type RoomTypeHandler struct {
+	service         *service.RoomTypeService
+	currencyService *service.CurrencyService
+	jwtSecret       string
+}
+
+// NewRoomTypeHandler creates a new RoomTypeHandler
+func NewRoomTypeHandler(service *service.RoomTypeService, currencyService *service.CurrencyService, jwtSecret string) *RoomTypeHandler {
+	return &RoomTypeHandler{
+		service:         service,
+		currencyService: currencyService,
+		jwtSecret:       jwtSecret,
+	}
+}
+
+// ListRoomTypes handles listing all room types, with prices in the currency query parameter if given
+func (h *RoomTypeHandler) ListRoomTypes(c echo.Context) error {
+	rate, err := h.currencyService.GetRate(c.QueryParam("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	roomTypes, err := h.service.ListRoomTypes(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve room types",
+		})
+	}
+
+	for i := range roomTypes {
+		roomTypes[i].BasePrice = rate.Convert(roomTypes[i].BasePrice)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room types retrieved successfully",
+		"data":    roomTypes,
+	})
+}
+
+// GetRoomType handles getting a room type by ID, with its price in the currency query parameter if given
+func (h *RoomTypeHandler) GetRoomType(c echo.Context) error {
+	id := c.Param("id")
+
+	rate, err := h.currencyService.GetRate(c.QueryParam("currency"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	roomType, err := h.service.GetRoomTypeByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Room type not found",
+		})
+	}
+
+	roomType.BasePrice = rate.Convert(roomType.BasePrice)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room type retrieved successfully",
+		"data":    roomType,
+	})
+}
+
+// GetAvailability handles counting the free rooms of a type for a date range
+func (h *RoomTypeHandler) GetAvailability(c echo.Context) error {
+	startDate, err := parseDateParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	endDate, err := parseDateParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	availability, err := h.service.GetAvailability(c.QueryParam("type_id"), c.QueryParam("name"), startDate, endDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room type availability retrieved successfully",
+		"data":    availability,
+	})
+}
+
+// CreateRoomType handles creating a new room type
+func (h *RoomTypeHandler) CreateRoomType(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	var roomType model.RoomType
+	if err := c.Bind(&roomType); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	createdRoomType, err := h.service.CreateRoomType(roomType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Room type created successfully",
+		"data":    createdRoomType,
+	})
+}
+
+// UpdateRoomType handles updating a room type
+func (h *RoomTypeHandler) UpdateRoomType(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	var roomType model.RoomType
+	if err := c.Bind(&roomType); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	updatedRoomType, err := h.service.UpdateRoomType(id, roomType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room type updated successfully",
+		"data":    updatedRoomType,
+	})
+}
+
+// DeleteRoomType handles deleting a room type
+func (h *RoomTypeHandler) DeleteRoomType(c echo.Context) error {
+	// Check if user is admin
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	id := c.Param("id")
+
+	if err := h.service.DeleteRoomType(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Room type deleted successfully",
+	})
+}
+
+// RegisterRoutes registers the routes for the room type handler
+func (h *RoomTypeHandler) RegisterRoutes(g *echo.Group) {
+	// Public routes
+	g.GET("/room-types", h.ListRoomTypes)
+	g.GET("/room-types/availability", h.GetAvailability)
+	g.GET("/room-types/:id", h.GetRoomType)
+
+	// Protected routes
+	admin := g.Group("/admin/room-types")
+	admin.Use(h.authMiddleware)
+
+	admin.POST("", h.CreateRoomType)
+	admin.PUT("/:id", h.UpdateRoomType)
+	admin.DELETE("/:id", h.DeleteRoomType)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *RoomTypeHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/auth"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+// WaitlistHandler handles HTTP requests for the waitlist
+type WaitlistHandler struct {
+	service   *service.WaitlistService
+	jwtSecret string
+}
+
+// NewWaitlistHandler creates a new WaitlistHandler
+func NewWaitlistHandler(service *service.WaitlistService, jwtSecret string) *WaitlistHandler {
+	return &WaitlistHandler{
+		service:   service,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// JoinWaitlist handles joining the waitlist for a fully booked room or room type
+func (h *WaitlistHandler) JoinWaitlist(c echo.Context) error {
+	// Get user ID from context
+	userID := c.Get("user_id").(string)
+
+	var req model.WaitlistRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	entry, err := h.service.JoinWaitlist(userID, req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"message": "Joined waitlist successfully",
+		"data":    entry,
+	})
+}
+
+// ListUserEntries handles listing the waitlist entries of the current user
+func (h *WaitlistHandler) ListUserEntries(c echo.Context) error {
+	userID := c.Get("user_id").(string)
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	entries, err := h.service.ListUserEntries(userID, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve waitlist entries",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Waitlist entries retrieved successfully",
+		"data":    entries,
+	})
+}
+
+// ListAllEntries handles listing all waitlist entries (admin only)
+func (h *WaitlistHandler) ListAllEntries(c echo.Context) error {
+	role := c.Get("role").(string)
+	if role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "Admin access required",
+		})
+	}
+
+	// Get query parameters
+	limitStr := c.QueryParam("limit")
+	offsetStr := c.QueryParam("offset")
+
+	limit := 10 // Default limit
+	if limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Default offset
+	if offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	entries, err := h.service.ListEntries(limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"success": false,
+			"error":   "Failed to retrieve waitlist entries",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Waitlist entries retrieved successfully",
+		"data":    entries,
+	})
+}
+
+// CancelEntry handles leaving the waitlist
+func (h *WaitlistHandler) CancelEntry(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	entry, err := h.service.GetEntryByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Waitlist entry not found",
+		})
+	}
+
+	// Check if user is authorized to cancel this entry
+	if entry.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to cancel this waitlist entry",
+		})
+	}
+
+	if err := h.service.CancelEntry(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Waitlist entry cancelled successfully",
+	})
+}
+
+// AcceptOffer handles accepting the room offered to a waitlist entry
+func (h *WaitlistHandler) AcceptOffer(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+
+	entry, err := h.service.GetEntryByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Waitlist entry not found",
+		})
+	}
+
+	// Only the guest on the waitlist can accept the offer
+	if entry.UserID != userID {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to accept this offer",
+		})
+	}
+
+	var req model.PaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   "Invalid request payload",
+		})
+	}
+
+	booking, err := h.service.AcceptOffer(id, req)
+	if err != nil {
+		return c.JSON(bookingErrorStatus(err), map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Offer accepted successfully",
+		"data":    booking,
+	})
+}
+
+// DeclineOffer handles declining the room offered to a waitlist entry
+func (h *WaitlistHandler) DeclineOffer(c echo.Context) error {
+	id := c.Param("id")
+	userID := c.Get("user_id").(string)
+	role := c.Get("role").(string)
+
+	entry, err := h.service.GetEntryByID(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"success": false,
+			"error":   "Waitlist entry not found",
+		})
+	}
+
+	// Check if user is authorized to decline this offer
+	if entry.UserID != userID && role != "admin" {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"success": false,
+			"error":   "You are not authorized to decline this offer",
+		})
+	}
+
+	if err := h.service.DeclineOffer(id); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Offer declined successfully",
+	})
+}
+
+// RegisterRoutes registers the routes for the waitlist handler
+func (h *WaitlistHandler) RegisterRoutes(g *echo.Group) {
+	// Protected routes
+	waitlist := g.Group("/waitlist")
+	waitlist.Use(h.authMiddleware)
+
+	waitlist.POST("", h.JoinWaitlist)
+	waitlist.GET("/my", h.ListUserEntries)
+	waitlist.DELETE("/:id", h.CancelEntry)
+	waitlist.POST("/:id/accept", h.AcceptOffer)
+	waitlist.POST("/:id/decline", h.DeclineOffer)
+
+	// Admin routes
+	admin := g.Group("/admin/waitlist")
+	admin.Use(h.authMiddleware)
+	admin.GET("", h.ListAllEntries)
+}
+
+// authMiddleware is a middleware to check if the user is authenticated
+func (h *WaitlistHandler) authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token := c.Request().Header.Get("Authorization")
+		if token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Authorization token is required",
+			})
+		}
+
+		// Remove "Bearer " prefix if present
+		if len(token) > 7 && token[:7] == "Bearer " {
+			token = token[7:]
+		}
+
+		claims, err := auth.ValidateToken(token, h.jwtSecret)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"success": false,
+				"error":   "Invalid or expired token",
+			})
+		}
+
+		// Set user info in context
+		c.Set("user_id", claims.UserID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+
+		return next(c)
+	}
+}
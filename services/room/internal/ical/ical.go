@@ -0,0 +1,243 @@
+// Package ical reads and writes the subset of iCalendar (RFC 5545) used to exchange
+// room availability: all-day VEVENTs inside a single VCALENDAR.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a calendar event blocking a room from Start up to, but not including, End
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+}
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405"
+	maxLineOctets  = 75
+)
+
+// Write encodes events as an iCalendar feed named name
+func Write(w io.Writer, name string, events []Event) error {
+	bw := bufio.NewWriter(w)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//address.ai//Room Service//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escapeText(name),
+	}
+
+	for _, event := range events {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeText(event.UID),
+			"DTSTAMP:"+event.Stamp.UTC().Format(dateTimeFormat)+"Z",
+			"DTSTART;VALUE=DATE:"+event.Start.Format(dateFormat),
+			"DTEND;VALUE=DATE:"+event.End.Format(dateFormat),
+			"SUMMARY:"+escapeText(event.Summary),
+		)
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+		}
+		lines = append(lines,
+			"TRANSP:OPAQUE",
+			"END:VEVENT",
+		)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := bw.WriteString(foldLine(line)); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Parse decodes the VEVENTs of an iCalendar file. Events must have a UID and a DTSTART;
+// an event without DTEND lasts one day. Cancelled events are skipped.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var event *Event
+	var cancelled bool
+	for number, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &Event{}
+			cancelled = false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", number+1)
+			}
+			if event.UID == "" {
+				return nil, fmt.Errorf("line %d: event has no UID", number+1)
+			}
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %s has no DTSTART", number+1, event.UID)
+			}
+			if event.End.IsZero() {
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+			if !event.Start.Before(event.End) {
+				return nil, fmt.Errorf("line %d: event %s ends before it starts", number+1, event.UID)
+			}
+			if !cancelled {
+				events = append(events, *event)
+			}
+			event = nil
+		case event == nil:
+			// Calendar properties and other components are ignored
+		case name == "UID":
+			event.UID = unescapeText(value)
+		case name == "SUMMARY":
+			event.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			event.Description = unescapeText(value)
+		case name == "STATUS":
+			cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART", name == "DTEND":
+			date, err := parseDate(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", number+1, err)
+			}
+			if name == "DTSTART" {
+				event.Start = date
+			} else {
+				event.End = date
+			}
+		}
+	}
+
+	if event != nil {
+		return nil, errors.New("unterminated VEVENT")
+	}
+
+	return events, nil
+}
+
+// unfoldLines reads content lines, joining continuation lines that start with a space or tab
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// splitProperty splits a content line into its upper-cased name, its parameters and its value
+func splitProperty(line string) (string, map[string]string, string, bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string)
+	for _, param := range parts[1:] {
+		if eq := strings.Index(param, "="); eq >= 0 {
+			params[strings.ToUpper(param[:eq])] = strings.Trim(param[eq+1:], `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseDate parses a DATE or DATE-TIME value to the calendar date it falls on. UTC
+// times and times with a TZID are converted; floating times are taken as they are.
+func parseDate(params map[string]string, value string) (time.Time, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		date, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		return date, nil
+	}
+
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+
+	var moment time.Time
+	var err error
+	if strings.HasSuffix(value, "Z") {
+		moment, err = time.Parse(dateTimeFormat+"Z", value)
+	} else {
+		moment, err = time.ParseInLocation(dateTimeFormat, value, location)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+	}
+
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// escapeText escapes a TEXT value
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// unescapeText reverses escapeText
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+// foldLine terminates a content line with CRLF, folding it so that no physical line
+// exceeds 75 octets without splitting a UTF-8 sequence
+func foldLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+)
+
+// CalendarImportResult reports what an iCalendar import changed. Blocks that clash
+// with a booking are not imported and are listed as conflicts.
+type CalendarImportResult struct {
+	RoomID    string                   `json:"room_id"`
+	Source    string                   `json:"source"`
+	Created   int                      `json:"created"`
+	Updated   int                      `json:"updated"`
+	Removed   int                      `json:"removed"`
+	Conflicts []CalendarImportConflict `json:"conflicts"`
+}
+
+// CalendarImportConflict is an external block that could not be imported
+type CalendarImportConflict struct {
+	UID       string    `json:"uid"`
+	Summary   string    `json:"summary"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Reason    string    `json:"reason"`
+}
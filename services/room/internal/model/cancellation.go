@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Cancellation penalty types
+const (
+	PenaltyNone    = "none"
+	PenaltyNights  = "nights"
+	PenaltyPercent = "percent"
+	PenaltyAmount  = "amount"
+)
+
+// CancellationTier is one step of a cancellation policy. It applies when a booking is
+// cancelled at least HoursBeforeStart hours before the stay starts and no tier with a
+// higher threshold applies.
+type CancellationTier struct {
+	HoursBeforeStart int     `json:"hours_before_start"`
+	PenaltyType      string  `json:"penalty_type"` // none, nights, percent, amount
+	PenaltyValue     float64 `json:"penalty_value"`
+}
+
+// CancellationTiers is the ordered list of tiers of a policy, stored as JSONB
+type CancellationTiers []CancellationTier
+
+// Value implements driver.Valuer so tiers can be stored as JSONB
+func (t CancellationTiers) Value() (driver.Value, error) {
+	if t == nil {
+		t = CancellationTiers{}
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements sql.Scanner so tiers can be read from JSONB
+func (t *CancellationTiers) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = CancellationTiers{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return errors.New("unsupported type for cancellation tiers")
+	}
+}
+
+// CancellationPolicy describes what a guest is charged for cancelling a booking,
+// e.g. "free until 48h before, then one night charged". Policies are attached to
+// room types and rate rules; the default policy covers everything else.
+type CancellationPolicy struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tiers       CancellationTiers `json:"tiers"`
+	IsDefault   bool              `json:"is_default"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// BookingCancellation records the outcome of cancelling a booking
+type BookingCancellation struct {
+	BookingID        string      `json:"booking_id"`
+	PolicyID         string      `json:"policy_id,omitempty"`
+	PolicyName       string      `json:"policy_name"`
+	RuleApplied      string      `json:"rule_applied"`
+	HoursBeforeStart float64     `json:"hours_before_start"`
+	Penalty          money.Money `json:"penalty"`
+	RefundAmount     money.Money `json:"refund_amount"`
+	CancelledBy      string      `json:"cancelled_by"`
+	CreatedAt        time.Time   `json:"created_at"`
+}
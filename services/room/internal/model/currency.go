@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// CurrencyRate is the exchange rate of a currency guests may view prices in, as the units
+// of the currency that one unit of the base currency buys
+type CurrencyRate struct {
+	Currency  string    `json:"currency"`
+	Rate      float64   `json:"rate"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Convert converts an amount in the base currency at the rate
+func (r CurrencyRate) Convert(amount money.Money) money.Money {
+	return amount.Convert(r.Currency, r.Rate)
+}
+
+// CurrencyRateRequest represents a request to set a currency's rate
+type CurrencyRateRequest struct {
+	Rate float64 `json:"rate" validate:"required"`
+}
+
+// CurrencyImportResult reports the rates a CSV import set
+type CurrencyImportResult struct {
+	Imported int            `json:"imported"`
+	Rates    []CurrencyRate `json:"rates"`
+}
+
+// ShowIn sets the quote's total with taxes in the currency of rate
+func (q *PriceQuote) ShowIn(rate CurrencyRate) {
+	total := rate.Convert(q.TotalPrice.Add(q.TaxTotal))
+	q.DisplayCurrency = rate.Currency
+	q.ExchangeRate = rate.Rate
+	q.DisplayTotal = &total
+}
+
+// ShowIn sets the booking's total with taxes in the currency of rate
+func (b *Booking) ShowIn(rate CurrencyRate) {
+	total := rate.Convert(b.TotalPrice.Add(b.TaxTotal))
+	b.DisplayCurrency = rate.Currency
+	b.ExchangeRate = rate.Rate
+	b.DisplayTotal = &total
+}
@@ -0,0 +1,10 @@
+package model
+
+import "errors"
+
+// ErrBookingConflict is returned when a booking would overlap another booking for the same room
+var ErrBookingConflict = errors.New("room is not available for the given dates")
+
+// ErrBookingChanged is returned when a booking has changed since a change to it was worked
+// out, as when it is cancelled or checked out while being amended
+var ErrBookingChanged = errors.New("booking has changed, please try again")
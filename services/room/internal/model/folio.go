@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Folio line types
+const (
+	LineRoom       = "room"
+	LineFood       = "food"
+	LineMinibar    = "minibar"
+	LineTax        = "tax"
+	LinePayment    = "payment"
+	LineAdjustment = "adjustment"
+)
+
+// Folio is the running bill of a stay. It is opened at check-in with the booked room
+// nights and their taxes, collects room-service orders, minibar charges, payments and
+// adjustments while the guest is in house, and is closed at check-out, when it becomes
+// the final invoice.
+type Folio struct {
+	ID        string      `json:"id"`
+	BookingID string      `json:"booking_id"`
+	UserID    string      `json:"user_id"`
+	RoomID    string      `json:"room_id"`
+	Status    string      `json:"status"` // open, closed
+	Lines     []FolioLine `json:"lines"`
+	Charges   money.Money `json:"charges"`
+	Payments  money.Money `json:"payments"`
+	Balance   money.Money `json:"balance"`
+	OpenedAt  time.Time   `json:"opened_at"`
+	ClosedAt  *time.Time  `json:"closed_at,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// FolioLine is a single entry on a folio. Payments are recorded as positive amounts and
+// reduce the balance; adjustments may be negative to credit the guest.
+type FolioLine struct {
+	ID          string      `json:"id"`
+	FolioID     string      `json:"folio_id"`
+	Type        string      `json:"type"` // room, food, minibar, tax, payment, adjustment
+	Description string      `json:"description"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Amount      money.Money `json:"amount"`
+	Rate        float64     `json:"rate,omitempty"`      // the percentage a tax line was levied at
+	Base        money.Money `json:"base"`                // the amount a tax line was levied on
+	SourceID    string      `json:"source_id,omitempty"` // the food order or inventory item charged
+	ServiceDate *time.Time  `json:"service_date,omitempty"`
+	Method      string      `json:"method,omitempty"` // how a payment was made
+	Reference   string      `json:"reference,omitempty"`
+	PostedBy    string      `json:"posted_by,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// MinibarChargeRequest represents a request to charge minibar consumption to a folio
+type MinibarChargeRequest struct {
+	ItemID      string      `json:"item_id,omitempty"` // the supply inventory item consumed
+	Description string      `json:"description"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+}
+
+// FolioPaymentRequest represents a request to record a payment against a folio
+type FolioPaymentRequest struct {
+	Amount    money.Money `json:"amount"`
+	Method    string      `json:"method"` // cash, card, transfer
+	Reference string      `json:"reference,omitempty"`
+}
+
+// FolioAdjustmentRequest represents a request to correct a folio. A negative amount
+// credits the guest.
+type FolioAdjustmentRequest struct {
+	Amount money.Money `json:"amount"`
+	Reason string      `json:"reason"`
+}
+
+// Total adds up a folio's lines into its charges, payments and balance
+func (f *Folio) Total() {
+	f.Charges = money.Zero(money.DefaultCurrency)
+	f.Payments = money.Zero(money.DefaultCurrency)
+	for _, line := range f.Lines {
+		if line.Type == LinePayment {
+			f.Payments = f.Payments.Add(line.Amount)
+		} else {
+			f.Charges = f.Charges.Add(line.Amount)
+		}
+	}
+	f.Balance = f.Charges.Sub(f.Payments)
+}
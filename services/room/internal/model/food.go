@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+)
+
+// FoodOrder is a room-service order as reported by the food service, which keeps orders
+// in its own database. TotalPrice is the price of the items before the taxes and service
+// charges in TaxBreakdown.
+type FoodOrder struct {
+	ID            string          `json:"id"`
+	UserID        string          `json:"user_id"`
+	RoomID        string          `json:"room_id,omitempty"`
+	Status        string          `json:"status"`
+	TotalPrice    money.Money     `json:"total_price"`
+	TaxBreakdown  tax.Breakdown   `json:"tax_breakdown"`
+	PaymentStatus string          `json:"payment_status"`
+	Items         []FoodOrderItem `json:"items"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// FoodOrderItem is an item of a food order. Price is the line total.
+type FoodOrderItem struct {
+	MenuItem struct {
+		Name string `json:"name"`
+	} `json:"menu_item"`
+	Quantity int         `json:"quantity"`
+	Price    money.Money `json:"price"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// Housekeeping task types
+const (
+	TaskClean    = "clean"
+	TaskInspect  = "inspect"
+	TaskTurndown = "turndown"
+)
+
+// Housekeeping task priorities, from most to least urgent
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+// HousekeepingTask is a piece of housekeeping work on a room. A room that has been
+// checked out stays in cleaning until its clean task is done and then passes inspection.
+type HousekeepingTask struct {
+	ID               string     `json:"id"`
+	RoomID           string     `json:"room_id"`
+	BookingID        string     `json:"booking_id,omitempty"`
+	Type             string     `json:"type"` // clean, inspect, turndown
+	AssignedTo       string     `json:"assigned_to,omitempty"`
+	Priority         string     `json:"priority"` // high, normal, low
+	Status           string     `json:"status"`   // pending, done, cancelled
+	Notes            string     `json:"notes,omitempty"`
+	DueDate          time.Time  `json:"due_date"`
+	InspectionPassed *bool      `json:"inspection_passed,omitempty"`
+	CompletedBy      string     `json:"completed_by,omitempty"`
+	CompletedAt      *time.Time `json:"completed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// TaskCompletion represents a request to mark a housekeeping task done. Passed is only
+// read for inspections; a failed inspection sends the room back for cleaning.
+type TaskCompletion struct {
+	Notes  string `json:"notes"`
+	Passed *bool  `json:"passed,omitempty"`
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Invoice sources
+const (
+	InvoiceBooking   = "booking"
+	InvoiceFoodOrder = "food_order"
+)
+
+// Invoice is the final bill of a completed stay or food order. Invoices are numbered
+// from a gap-free sequence per property and never change once issued.
+type Invoice struct {
+	ID         string       `json:"id"`
+	Number     string       `json:"number"`
+	Property   string       `json:"property"`
+	Sequence   int64        `json:"sequence"`
+	SourceType string       `json:"source_type"` // booking, food_order
+	SourceID   string       `json:"source_id"`
+	UserID     string       `json:"user_id"`
+	RoomID     string       `json:"room_id,omitempty"`
+	RoomNumber string       `json:"room_number,omitempty"`
+	StartDate  *time.Time   `json:"start_date,omitempty"`
+	EndDate    *time.Time   `json:"end_date,omitempty"`
+	Lines      InvoiceLines `json:"lines"`
+	Taxes      InvoiceTaxes `json:"taxes"`
+	Subtotal   money.Money  `json:"subtotal"`
+	TaxTotal   money.Money  `json:"tax_total"`
+	Total      money.Money  `json:"total"`
+	AmountPaid money.Money  `json:"amount_paid"`
+	BalanceDue money.Money  `json:"balance_due"`
+	IssuedAt   time.Time    `json:"issued_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// InvoiceLine is a charge on an invoice
+type InvoiceLine struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Date        *time.Time  `json:"date,omitempty"`
+	Quantity    int         `json:"quantity"`
+	UnitPrice   money.Money `json:"unit_price"`
+	Amount      money.Money `json:"amount"`
+}
+
+// InvoiceTax is a tax or service charge levied on an invoice
+type InvoiceTax struct {
+	Name   string      `json:"name"`
+	Rate   float64     `json:"rate"` // percent
+	Base   money.Money `json:"base"`
+	Amount money.Money `json:"amount"`
+}
+
+// InvoiceLines is the list of charges of an invoice, stored as JSONB
+type InvoiceLines []InvoiceLine
+
+// InvoiceTaxes is the list of taxes of an invoice, stored as JSONB
+type InvoiceTaxes []InvoiceTax
+
+// Value implements driver.Valuer so lines can be stored as JSONB
+func (l InvoiceLines) Value() (driver.Value, error) {
+	if l == nil {
+		l = InvoiceLines{}
+	}
+	return json.Marshal(l)
+}
+
+// Scan implements sql.Scanner so lines can be read from JSONB
+func (l *InvoiceLines) Scan(src interface{}) error {
+	return scanJSON(src, l)
+}
+
+// Value implements driver.Valuer so taxes can be stored as JSONB
+func (t InvoiceTaxes) Value() (driver.Value, error) {
+	if t == nil {
+		t = InvoiceTaxes{}
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements sql.Scanner so taxes can be read from JSONB
+func (t *InvoiceTaxes) Scan(src interface{}) error {
+	return scanJSON(src, t)
+}
+
+// scanJSON decodes a JSONB column into dest
+func scanJSON(src interface{}, dest interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return errors.New("unsupported type for JSON column")
+	}
+}
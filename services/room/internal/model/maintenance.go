@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// MaintenanceWindow is a period from StartDate up to, but not including, EndDate during
+// which a room is out of service. While the window is active the room's status is
+// maintenance.
+type MaintenanceWindow struct {
+	ID         string    `json:"id"`
+	RoomID     string    `json:"room_id"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	Reason     string    `json:"reason"`
+	AssignedTo string    `json:"assigned_to,omitempty"` // staff user ID
+	Status     string    `json:"status"`                // scheduled, active, completed, cancelled
+	CreatedBy  string    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// MaintenanceRequest represents a request to schedule or reschedule a maintenance window
+type MaintenanceRequest struct {
+	RoomID     string    `json:"room_id" validate:"required"`
+	StartDate  time.Time `json:"start_date" validate:"required"`
+	EndDate    time.Time `json:"end_date" validate:"required"`
+	Reason     string    `json:"reason" validate:"required"`
+	AssignedTo string    `json:"assigned_to,omitempty"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+// OccupancyCalendar is a rooms × dates matrix showing which booking occupies each
+// room on each night of a window, as drawn on a tape chart
+type OccupancyCalendar struct {
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Dates     []string       `json:"dates"`
+	Rooms     []OccupancyRow `json:"rooms"`
+}
+
+// OccupancyRow is one room's line of the occupancy calendar, with one cell per night
+type OccupancyRow struct {
+	RoomID     string          `json:"room_id"`
+	RoomNumber string          `json:"room_number"`
+	RoomType   string          `json:"room_type"`
+	RoomStatus string          `json:"room_status"`
+	Cells      []OccupancyCell `json:"cells"`
+}
+
+// OccupancyCell is a room's night in the occupancy calendar. The booking fields are
+// empty when the room is free that night; Status is maintenance when it is out of service.
+type OccupancyCell struct {
+	Date      string `json:"date"`
+	BookingID string `json:"booking_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	GuestName string `json:"guest_name,omitempty"`
+}
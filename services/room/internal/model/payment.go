@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+)
+
+// Payment is a card payment for a booking, as authorized, captured, refunded or voided
+// through the payment gateway
+type Payment struct {
+	ID             string      `json:"id"`
+	BookingID      string      `json:"booking_id"`
+	UserID         string      `json:"user_id"`
+	Gateway        string      `json:"gateway"`
+	TransactionID  string      `json:"transaction_id,omitempty"`
+	Status         string      `json:"status"` // authorized, captured, partially_refunded, refunded, voided, failed
+	Amount         money.Money `json:"amount"` // authorized
+	CapturedAmount money.Money `json:"captured_amount"`
+	RefundedAmount money.Money `json:"refunded_amount"`
+	FailureReason  string      `json:"failure_reason,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// PaymentRequest carries the payment method a booking is confirmed with
+type PaymentRequest struct {
+	PaymentToken string `json:"payment_token" validate:"required"`
+}
+
+// RefundRequest represents a request to refund a captured payment. Leaving the amount
+// out refunds everything not yet refunded.
+type RefundRequest struct {
+	Amount *money.Money `json:"amount,omitempty"`
+}
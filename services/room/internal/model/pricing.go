@@ -0,0 +1,108 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+)
+
+// Rate rule types
+const (
+	RateRuleWeekday      = "weekday"
+	RateRuleWeekend      = "weekend"
+	RateRuleSeason       = "season"
+	RateRuleLengthOfStay = "length_of_stay"
+	RateRuleOccupancy    = "occupancy"
+)
+
+// Rate rule adjustment types
+const (
+	AdjustmentPercent = "percent"
+	AdjustmentAmount  = "amount"
+)
+
+// RateRule adjusts the nightly price of a room. Weekday and weekend rules apply to
+// nights by day of week (Friday and Saturday nights are the weekend), season rules to
+// nights from StartDate through EndDate inclusive, length-of-stay rules to every night
+// of stays of at least MinNights, and occupancy rules to nights on which at least
+// MinOccupancy percent of comparable rooms are booked.
+type RateRule struct {
+	ID                   string     `json:"id"`
+	Name                 string     `json:"name"`
+	RuleType             string     `json:"rule_type"`              // weekday, weekend, season, length_of_stay, occupancy
+	RoomTypeID           string     `json:"room_type_id,omitempty"` // empty applies to every room type
+	StartDate            *time.Time `json:"start_date,omitempty"`
+	EndDate              *time.Time `json:"end_date,omitempty"`
+	MinNights            int        `json:"min_nights,omitempty"`
+	MinOccupancy         float64    `json:"min_occupancy,omitempty"`
+	AdjustmentType       string     `json:"adjustment_type"` // percent, amount
+	Adjustment           float64    `json:"adjustment"`      // negative values are discounts
+	Active               bool       `json:"active"`
+	CancellationPolicyID string     `json:"cancellation_policy_id,omitempty"` // overrides the room type's policy
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// PriceAdjustment records the effect of one rate rule on one night
+type PriceAdjustment struct {
+	RuleID   string      `json:"rule_id"`
+	RuleName string      `json:"rule_name"`
+	RuleType string      `json:"rule_type"`
+	Amount   money.Money `json:"amount"`
+}
+
+// NightlyPrice is the price of a single night of a stay
+type NightlyPrice struct {
+	Date        time.Time         `json:"date"`
+	BasePrice   money.Money       `json:"base_price"`
+	Adjustments []PriceAdjustment `json:"adjustments,omitempty"`
+	Price       money.Money       `json:"price"`
+}
+
+// PriceBreakdown is the per-night pricing of a stay. It is stored with each booking
+// so that later rule changes do not alter existing reservations. Total is the room
+// charge before the taxes and service charges levied on it.
+type PriceBreakdown struct {
+	Nights []NightlyPrice `json:"nights"`
+	Total  money.Money    `json:"total"`
+	Taxes  tax.Breakdown  `json:"taxes"`
+}
+
+// Value implements driver.Valuer so a breakdown can be stored as JSONB
+func (b PriceBreakdown) Value() (driver.Value, error) {
+	return json.Marshal(b)
+}
+
+// Scan implements sql.Scanner so a breakdown can be read from JSONB
+func (b *PriceBreakdown) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*b = PriceBreakdown{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, b)
+	case string:
+		return json.Unmarshal([]byte(v), b)
+	default:
+		return errors.New("unsupported type for price breakdown")
+	}
+}
+
+// PriceQuote is the price of a prospective stay in a room
+type PriceQuote struct {
+	RoomID          string         `json:"room_id"`
+	RoomTypeID      string         `json:"room_type_id,omitempty"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         time.Time      `json:"end_date"`
+	NumberOfNights  int            `json:"number_of_nights"`
+	Breakdown       PriceBreakdown `json:"breakdown"`
+	TotalPrice      money.Money    `json:"total_price"`
+	TaxTotal        money.Money    `json:"tax_total"`
+	DisplayCurrency string         `json:"display_currency,omitempty"`
+	ExchangeRate    float64        `json:"exchange_rate,omitempty"`
+	DisplayTotal    *money.Money   `json:"display_total,omitempty"` // total with taxes in the display currency
+}
@@ -2,75 +2,225 @@ package model
 
 import (
 	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
 )
 
 // Room represents a hotel room
 type Room struct {
-	ID          string    `json:"id"`
-	Number      string    `json:"number"`
-	Type        string    `json:"type"`
-	Floor       int       `json:"floor"`
-	Description string    `json:"description"`
-	Capacity    int       `json:"capacity"`
-	PricePerDay float64   `json:"price_per_day"`
-	Status      string    `json:"status"` // available, occupied, maintenance
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string      `json:"id"`
+	Number      string      `json:"number"`
+	TypeID      string      `json:"type_id,omitempty"`
+	Type        string      `json:"type"`
+	Floor       int         `json:"floor"`
+	Description string      `json:"description"`
+	Capacity    int         `json:"capacity"`
+	PricePerDay money.Money `json:"price_per_day"`
+	Status      string      `json:"status"` // available, occupied, cleaning, maintenance
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 // RoomType represents a type of room
 type RoomType struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	BasePrice   float64   `json:"base_price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                   string      `json:"id"`
+	Name                 string      `json:"name"`
+	Description          string      `json:"description"`
+	BasePrice            money.Money `json:"base_price"`
+	DefaultCapacity      int         `json:"default_capacity"`
+	Amenities            []string    `json:"amenities"`
+	CancellationPolicyID string      `json:"cancellation_policy_id,omitempty"`
+	DepositType          string      `json:"deposit_type,omitempty"` // percent, fixed; empty takes no deposit
+	DepositPercent       float64     `json:"deposit_percent,omitempty"`
+	DepositAmount        money.Money `json:"deposit_amount"`
+	DepositDueHours      int         `json:"deposit_due_hours,omitempty"` // how long after booking the deposit must be paid
+	CreatedAt            time.Time   `json:"created_at"`
+	UpdatedAt            time.Time   `json:"updated_at"`
+}
+
+// Deposit types
+const (
+	DepositPercent = "percent"
+	DepositFixed   = "fixed"
+)
+
+// Deposit returns the deposit the room type takes on a stay costing total, which is never
+// more than the stay itself
+func (t RoomType) Deposit(total money.Money) money.Money {
+	switch t.DepositType {
+	case DepositPercent:
+		return total.Percent(t.DepositPercent)
+	case DepositFixed:
+		return t.DepositAmount.Min(total)
+	default:
+		return money.Zero(total.Currency)
+	}
+}
+
+// RoomTypeAvailability summarizes how many rooms of a type are free for a date range
+type RoomTypeAvailability struct {
+	RoomTypeID     string    `json:"room_type_id"`
+	Name           string    `json:"name"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	TotalRooms     int       `json:"total_rooms"`
+	AvailableRooms int       `json:"available_rooms"`
 }
 
 // Booking represents a room booking
 type Booking struct {
-	ID         string    `json:"id"`
-	RoomID     string    `json:"room_id"`
-	UserID     string    `json:"user_id"`
-	StartDate  time.Time `json:"start_date"`
-	EndDate    time.Time `json:"end_date"`
-	TotalPrice float64   `json:"total_price"`
-	Status     string    `json:"status"` // confirmed, cancelled, completed
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID              string         `json:"id"`
+	RoomID          string         `json:"room_id"`
+	UserID          string         `json:"user_id"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         time.Time      `json:"end_date"`
+	TotalPrice      money.Money    `json:"total_price"`
+	TaxTotal        money.Money    `json:"tax_total"`
+	DisplayCurrency string         `json:"display_currency,omitempty"` // the currency the guest booked in
+	ExchangeRate    float64        `json:"exchange_rate,omitempty"`    // the rate the guest booked at
+	DisplayTotal    *money.Money   `json:"display_total,omitempty"`    // total with taxes in the display currency
+	Status          string         `json:"status"`                     // held, confirmed, checked_in, completed, cancelled, no_show, expired, released, external
+	PaymentStatus   string         `json:"payment_status"`             // unpaid, authorized, captured, partially_refunded, refunded, voided, failed
+	DepositAmount   money.Money    `json:"deposit_amount"`
+	DepositDueAt    *time.Time     `json:"deposit_due_at,omitempty"`
+	AmountPaid      money.Money    `json:"amount_paid"`
+	BalanceDue      money.Money    `json:"balance_due"` // total with taxes less the amount paid
+	GroupID         string         `json:"group_id,omitempty"`
+	PriceBreakdown  PriceBreakdown `json:"price_breakdown"`
+	CheckedInAt     *time.Time     `json:"checked_in_at,omitempty"`
+	CheckedOutAt    *time.Time     `json:"checked_out_at,omitempty"`
+	HoldExpiresAt   *time.Time     `json:"hold_expires_at,omitempty"`
+	ExternalSource  string         `json:"external_source,omitempty"` // set on blocks imported from another calendar
+	ExternalUID     string         `json:"external_uid,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+// DepositOutstanding reports whether the booking's deposit has yet to be paid in full
+func (b Booking) DepositOutstanding() bool {
+	return b.DepositAmount.IsPositive() && b.AmountPaid.Cmp(b.DepositAmount) < 0
+}
+
+// BookingGroup represents a block of rooms booked together for the same dates
+type BookingGroup struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Status    string    `json:"status"` // confirmed, cancelled
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // BookingRequest represents a request to book a room
 type BookingRequest struct {
-	RoomID    string    `json:"room_id" validate:"required"`
-	StartDate time.Time `json:"start_date" validate:"required"`
-	EndDate   time.Time `json:"end_date" validate:"required"`
+	RoomID       string    `json:"room_id" validate:"required"`
+	StartDate    time.Time `json:"start_date" validate:"required"`
+	EndDate      time.Time `json:"end_date" validate:"required"`
+	Currency     string    `json:"currency,omitempty"`      // the currency to show the total in; the base currency if empty
+	PaymentToken string    `json:"payment_token,omitempty"` // the payment method to authorize; required to confirm a booking
+}
+
+// BookingAmendRequest represents a request to change a booking's dates or room.
+// Fields left empty keep their current value.
+type BookingAmendRequest struct {
+	RoomID       string    `json:"room_id,omitempty"`
+	StartDate    time.Time `json:"start_date,omitempty"`
+	EndDate      time.Time `json:"end_date,omitempty"`
+	PaymentToken string    `json:"payment_token,omitempty"` // the payment method to authorize the new total on; required when it rises
+}
+
+// BookingHistory records the old and new values of a change made to a booking
+type BookingHistory struct {
+	ID            string      `json:"id"`
+	BookingID     string      `json:"booking_id"`
+	ChangedBy     string      `json:"changed_by"`
+	Action        string      `json:"action"` // amended
+	OldRoomID     string      `json:"old_room_id"`
+	NewRoomID     string      `json:"new_room_id"`
+	OldStartDate  time.Time   `json:"old_start_date"`
+	NewStartDate  time.Time   `json:"new_start_date"`
+	OldEndDate    time.Time   `json:"old_end_date"`
+	NewEndDate    time.Time   `json:"new_end_date"`
+	OldTotalPrice money.Money `json:"old_total_price"`
+	NewTotalPrice money.Money `json:"new_total_price"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// GroupBookingRequest represents a request to book several rooms for the same dates.
+// Either RoomIDs or a room type (by RoomTypeID or RoomType name) and Count must be provided.
+type GroupBookingRequest struct {
+	Name         string    `json:"name"`
+	RoomIDs      []string  `json:"room_ids,omitempty"`
+	RoomTypeID   string    `json:"room_type_id,omitempty"`
+	RoomType     string    `json:"room_type,omitempty"`
+	Count        int       `json:"count,omitempty"`
+	StartDate    time.Time `json:"start_date" validate:"required"`
+	EndDate      time.Time `json:"end_date" validate:"required"`
+	PaymentToken string    `json:"payment_token" validate:"required"` // authorized for each room's stay
+}
+
+// GroupAmendRequest represents a request to move every booking in a group to new dates
+type GroupAmendRequest struct {
+	StartDate    time.Time `json:"start_date" validate:"required"`
+	EndDate      time.Time `json:"end_date" validate:"required"`
+	PaymentToken string    `json:"payment_token,omitempty"` // the payment method to authorize new totals on; required when one rises
 }
 
 // RoomResponse represents the room data returned in responses
 type RoomResponse struct {
-	ID          string    `json:"id"`
-	Number      string    `json:"number"`
-	Type        string    `json:"type"`
-	Floor       int       `json:"floor"`
-	Description string    `json:"description"`
-	Capacity    int       `json:"capacity"`
-	PricePerDay float64   `json:"price_per_day"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string      `json:"id"`
+	Number      string      `json:"number"`
+	TypeID      string      `json:"type_id,omitempty"`
+	Type        string      `json:"type"`
+	Floor       int         `json:"floor"`
+	Description string      `json:"description"`
+	Capacity    int         `json:"capacity"`
+	PricePerDay money.Money `json:"price_per_day"`
+	Status      string      `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
 
 // BookingResponse represents the booking data returned in responses
 type BookingResponse struct {
-	ID         string       `json:"id"`
-	Room       RoomResponse `json:"room"`
-	UserID     string       `json:"user_id"`
-	StartDate  time.Time    `json:"start_date"`
-	EndDate    time.Time    `json:"end_date"`
-	TotalPrice float64      `json:"total_price"`
-	Status     string       `json:"status"`
-	CreatedAt  time.Time    `json:"created_at"`
+	ID              string         `json:"id"`
+	Room            RoomResponse   `json:"room"`
+	UserID          string         `json:"user_id"`
+	StartDate       time.Time      `json:"start_date"`
+	EndDate         time.Time      `json:"end_date"`
+	TotalPrice      money.Money    `json:"total_price"`
+	TaxTotal        money.Money    `json:"tax_total"`
+	DisplayCurrency string         `json:"display_currency,omitempty"`
+	ExchangeRate    float64        `json:"exchange_rate,omitempty"`
+	DisplayTotal    *money.Money   `json:"display_total,omitempty"`
+	Status          string         `json:"status"`
+	PaymentStatus   string         `json:"payment_status"`
+	DepositAmount   money.Money    `json:"deposit_amount"`
+	DepositDueAt    *time.Time     `json:"deposit_due_at,omitempty"`
+	AmountPaid      money.Money    `json:"amount_paid"`
+	BalanceDue      money.Money    `json:"balance_due"`
+	GroupID         string         `json:"group_id,omitempty"`
+	PriceBreakdown  PriceBreakdown `json:"price_breakdown"`
+	CheckedInAt     *time.Time     `json:"checked_in_at,omitempty"`
+	CheckedOutAt    *time.Time     `json:"checked_out_at,omitempty"`
+	HoldExpiresAt   *time.Time     `json:"hold_expires_at,omitempty"`
+	ExternalSource  string         `json:"external_source,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
+// BookingGroupResponse represents the booking group data returned in responses
+type BookingGroupResponse struct {
+	ID         string            `json:"id"`
+	UserID     string            `json:"user_id"`
+	Name       string            `json:"name"`
+	StartDate  time.Time         `json:"start_date"`
+	EndDate    time.Time         `json:"end_date"`
+	Status     string            `json:"status"`
+	TotalPrice money.Money       `json:"total_price"`
+	TaxTotal   money.Money       `json:"tax_total"`
+	Bookings   []BookingResponse `json:"bookings"`
+	CreatedAt  time.Time         `json:"created_at"`
 }
 
 // ToResponse converts a Room to a RoomResponse
@@ -78,6 +228,7 @@ func (r *Room) ToResponse() RoomResponse {
 	return RoomResponse{
 		ID:          r.ID,
 		Number:      r.Number,
+		TypeID:      r.TypeID,
 		Type:        r.Type,
 		Floor:       r.Floor,
 		Description: r.Description,
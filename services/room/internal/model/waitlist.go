@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+)
+
+// WaitlistEntry is a guest's request to be offered a room, or any room of a type,
+// if it frees up for the given dates. When a slot is offered it is held for the
+// guest as a held booking until OfferExpiresAt.
+type WaitlistEntry struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	RoomID           string     `json:"room_id,omitempty"`
+	RoomTypeID       string     `json:"room_type_id,omitempty"`
+	StartDate        time.Time  `json:"start_date"`
+	EndDate          time.Time  `json:"end_date"`
+	Status           string     `json:"status"` // waiting, offered, accepted, declined, expired, cancelled
+	OfferedBookingID string     `json:"offered_booking_id,omitempty"`
+	OfferExpiresAt   *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// WaitlistRequest represents a request to join the waitlist for a room or a room type
+type WaitlistRequest struct {
+	RoomID     string    `json:"room_id,omitempty"`
+	RoomTypeID string    `json:"room_type_id,omitempty"`
+	StartDate  time.Time `json:"start_date" validate:"required"`
+	EndDate    time.Time `json:"end_date" validate:"required"`
+}
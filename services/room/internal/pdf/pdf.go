@@ -0,0 +1,179 @@
+// Package pdf writes simple PDF documents: text and rules on A4 pages, set in the
+// standard Helvetica fonts that every PDF reader provides, so nothing is embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font selects one of the standard fonts of a document
+type Font string
+
+// Fonts available to every document
+const (
+	Regular Font = "F1"
+	Bold    Font = "F2"
+)
+
+// helveticaWidths holds the advance widths of the printable ASCII characters in
+// Helvetica, in thousandths of the font size, starting at the space character. Bold
+// digits and punctuation share these widths, which is all right-aligned figures need.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 to 9
+	278, 278, 584, 584, 584, 556, 1015, // : to @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A to M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N to Z
+	278, 278, 278, 469, 556, 333, // [ to `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a to m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n to z
+	334, 260, 334, 584, // { to ~
+}
+
+// Document is a PDF document under construction
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page is a page of a document. Positions are in points from the top-left corner.
+type Page struct {
+	content bytes.Buffer
+}
+
+// New creates an empty document with the given title
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage adds a blank page to the end of the document
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Width returns the width in points of s set in Helvetica at size
+func Width(s string, size float64) float64 {
+	var total int
+	for _, b := range encode(s) {
+		if b >= 32 && b <= 126 {
+			total += helveticaWidths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Text draws s with its baseline starting at x, y
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PageHeight-y, escape(encode(s)))
+}
+
+// TextRight draws s with its baseline ending at x, y
+func (p *Page) TextRight(x, y float64, font Font, size float64, s string) {
+	p.Text(x-Width(s, size), y, font, size, s)
+}
+
+// Line draws a straight rule of the given thickness from x1, y1 to x2, y2
+func (p *Page) Line(x1, y1, x2, y2, thickness float64) {
+	fmt.Fprintf(&p.content, "%.2f w %.2f %.2f m %.2f %.2f l S\n", thickness, x1, PageHeight-y1, x2, PageHeight-y2)
+}
+
+// WriteTo writes the document as a PDF file
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects 1 to 5 are the catalog, page tree, fonts and document information; each
+	// page then takes two objects, itself and its content stream
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (address.ai) >>", escape(encode(d.title))))
+
+	for i, page := range pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 7+2*i,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// Bytes returns the document as a PDF file
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	d.WriteTo(&buf)
+	return buf.Bytes()
+}
+
+// encode converts s to WinAnsiEncoding, replacing characters the standard fonts cannot
+// show with a question mark
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r <= 126:
+			out = append(out, byte(r))
+		case r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case r == '€':
+			out = append(out, 0x80)
+		case r == '\t':
+			out = append(out, ' ')
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+// escape escapes the characters with a special meaning inside a PDF string literal
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch c {
+		case '\\', '(', ')':
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
@@ -0,0 +1,332 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// BookingGroupRepository handles database operations for booking groups
+type BookingGroupRepository struct {
+	db *sql.DB
+}
+
+// NewBookingGroupRepository creates a new BookingGroupRepository
+func NewBookingGroupRepository(db *sql.DB) *BookingGroupRepository {
+	return &BookingGroupRepository{db: db}
+}
+
+// lockOrder returns the indexes of bookings ordered by room, so that room locks are always
+// taken in the same order, which keeps concurrent group transactions from deadlocking.
+// The bookings themselves are left in the order the caller gave them.
+func lockOrder(bookings []model.Booking) []int {
+	order := make([]int, len(bookings))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return bookings[order[i]].RoomID < bookings[order[j]].RoomID
+	})
+
+	return order
+}
+
+// Create creates a group and all of its bookings in one transaction. The created bookings
+// are returned in the order they were given. If any room cannot be held for the group's
+// dates nothing is written and model.ErrBookingConflict is returned.
+func (r *BookingGroupRepository) Create(group model.BookingGroup, bookings []model.Booking) (model.BookingGroup, []model.Booking, error) {
+	query := `
+		INSERT INTO booking_groups (id, user_id, name, start_date, end_date, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, user_id, name, start_date, end_date, status, created_at, updated_at
+	`
+
+	// Generate UUID if not provided
+	if group.ID == "" {
+		group.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	group.CreatedAt = now
+	group.UpdatedAt = now
+
+	// Set default status if not provided
+	if group.Status == "" {
+		group.Status = "confirmed"
+	}
+
+	created := make([]model.Booking, len(bookings))
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(
+			query,
+			group.ID,
+			group.UserID,
+			group.Name,
+			group.StartDate,
+			group.EndDate,
+			group.Status,
+			group.CreatedAt,
+			group.UpdatedAt,
+		).Scan(
+			&group.ID,
+			&group.UserID,
+			&group.Name,
+			&group.StartDate,
+			&group.EndDate,
+			&group.Status,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range lockOrder(bookings) {
+			booking := bookings[i]
+			if err := lockRoom(tx, booking.RoomID); err != nil {
+				return err
+			}
+
+			overlap, err := hasOverlap(tx, booking.RoomID, booking.StartDate, booking.EndDate, "")
+			if err != nil {
+				return err
+			}
+			if overlap {
+				return model.ErrBookingConflict
+			}
+
+			booking.GroupID = group.ID
+			createdBooking, err := insertBooking(tx, booking)
+			if err != nil {
+				return err
+			}
+			created[i] = createdBooking
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return model.BookingGroup{}, nil, err
+	}
+
+	return group, created, nil
+}
+
+// GetByID gets a booking group by ID
+func (r *BookingGroupRepository) GetByID(id string) (model.BookingGroup, error) {
+	query := `
+		SELECT id, user_id, name, start_date, end_date, status, created_at, updated_at
+		FROM booking_groups
+		WHERE id = $1
+	`
+
+	var group model.BookingGroup
+	err := r.db.QueryRow(query, id).Scan(
+		&group.ID,
+		&group.UserID,
+		&group.Name,
+		&group.StartDate,
+		&group.EndDate,
+		&group.Status,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.BookingGroup{}, errors.New("booking group not found")
+		}
+		return model.BookingGroup{}, err
+	}
+
+	return group, nil
+}
+
+// ListByUserID lists booking groups by user ID
+func (r *BookingGroupRepository) ListByUserID(userID string, limit, offset int) ([]model.BookingGroup, error) {
+	query := `
+		SELECT id, user_id, name, start_date, end_date, status, created_at, updated_at
+		FROM booking_groups
+		WHERE user_id = $1
+		ORDER BY start_date DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []model.BookingGroup
+	for rows.Next() {
+		var group model.BookingGroup
+		err := rows.Scan(
+			&group.ID,
+			&group.UserID,
+			&group.Name,
+			&group.StartDate,
+			&group.EndDate,
+			&group.Status,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// GetBookings gets the bookings that belong to a group
+func (r *BookingGroupRepository) GetBookings(groupID string) ([]model.Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE group_id = $1
+		ORDER BY room_id
+	`
+
+	rows, err := r.db.Query(query, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBookings(rows)
+}
+
+// Cancel cancels a group and every confirmed booking in it in one transaction,
+// recording each booking's refund and penalty
+func (r *BookingGroupRepository) Cancel(id string, cancellations []model.BookingCancellation) ([]model.BookingCancellation, error) {
+	var recorded []model.BookingCancellation
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		_, err := tx.Exec(`
+			UPDATE booking_groups
+			SET status = 'cancelled', updated_at = $1
+			WHERE id = $2
+		`, now, id)
+		if err != nil {
+			return err
+		}
+
+		for _, cancellation := range cancellations {
+			result, err := tx.Exec(`
+				UPDATE bookings
+				SET status = 'cancelled', updated_at = $1
+				WHERE id = $2
+				AND group_id = $3
+				AND status = 'confirmed'
+			`, now, cancellation.BookingID, id)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			// Skip bookings that changed state since they were priced for cancellation
+			if rowsAffected == 0 {
+				continue
+			}
+
+			created, err := insertBookingCancellation(tx, cancellation)
+			if err != nil {
+				return err
+			}
+			recorded = append(recorded, created)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+// Amend moves a group and all of its bookings to new dates in one transaction.
+// Each booking is re-checked for availability, ignoring itself, and saved with the
+// TotalPrice and PriceBreakdown, taxes included, and the deposit it carries, and the
+// history entry at the same index is recorded for it. If any room is taken for the new
+// dates nothing is changed and model.ErrBookingConflict is returned. The amended bookings
+// are returned in the order they were given.
+func (r *BookingGroupRepository) Amend(group model.BookingGroup, bookings []model.Booking, history []model.BookingHistory) (model.BookingGroup, []model.Booking, error) {
+	amended := make([]model.Booking, len(bookings))
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		for _, i := range lockOrder(bookings) {
+			booking := bookings[i]
+			if err := lockRoom(tx, booking.RoomID); err != nil {
+				return err
+			}
+
+			overlap, err := hasOverlap(tx, booking.RoomID, group.StartDate, group.EndDate, booking.ID)
+			if err != nil {
+				return err
+			}
+			if overlap {
+				return model.ErrBookingConflict
+			}
+
+			updated, err := scanBooking(tx.QueryRow(`
+				UPDATE bookings
+				SET start_date = $1, end_date = $2, total_price = $3, tax_total = $4, price_breakdown = $5,
+					deposit_amount = $6, deposit_due_at = $7, updated_at = $8
+				WHERE id = $9
+				AND status IN ('confirmed', 'checked_in')
+				RETURNING `+bookingColumns,
+				group.StartDate, group.EndDate, booking.TotalPrice, booking.PriceBreakdown.Taxes.Total, booking.PriceBreakdown,
+				booking.DepositAmount, booking.DepositDueAt, now, booking.ID,
+			))
+			if err != nil {
+				// A booking cancelled or checked out since the group was read is not moved
+				if errors.Is(err, sql.ErrNoRows) {
+					return model.ErrBookingChanged
+				}
+				if isExclusionViolation(err) {
+					return model.ErrBookingConflict
+				}
+				return err
+			}
+			amended[i] = updated
+
+			if err := insertBookingHistory(tx, history[i]); err != nil {
+				return err
+			}
+		}
+
+		group.UpdatedAt = now
+		_, err := tx.Exec(`
+			UPDATE booking_groups
+			SET start_date = $1, end_date = $2, updated_at = $3
+			WHERE id = $4
+		`, group.StartDate, group.EndDate, group.UpdatedAt, group.ID)
+		return err
+	})
+
+	if err != nil {
+		return model.BookingGroup{}, nil, err
+	}
+
+	return group, amended, nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/lib/pq"
+)
+
+func TestLockOrderKeepsBookingsInGivenOrder(t *testing.T) {
+	bookings := []model.Booking{
+		{RoomID: "room-c"},
+		{RoomID: "room-a"},
+		{RoomID: "room-b"},
+	}
+
+	order := lockOrder(bookings)
+	if want := []int{1, 2, 0}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("lockOrder = %v, want %v", order, want)
+	}
+
+	for i, room := range []string{"room-c", "room-a", "room-b"} {
+		if bookings[i].RoomID != room {
+			t.Errorf("booking %d moved to room %s, want %s", i, bookings[i].RoomID, room)
+		}
+	}
+}
+
+// testGroup returns a group of two rooms for the dates of testBooking, with the bookings
+// given out of room order
+func testGroup() (model.BookingGroup, []model.Booking) {
+	first := testBooking()
+	first.ID = "booking-2"
+	first.RoomID = "room-2"
+
+	second := testBooking()
+
+	group := model.BookingGroup{
+		ID:        "group-1",
+		UserID:    first.UserID,
+		Name:      "Wedding party",
+		StartDate: first.StartDate,
+		EndDate:   first.EndDate,
+		Status:    "confirmed",
+	}
+
+	return group, []model.Booking{first, second}
+}
+
+// expectGroupInsert expects the group to be inserted
+func expectGroupInsert(mock sqlmock.Sqlmock, group model.BookingGroup) {
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO booking_groups`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "start_date", "end_date", "status", "created_at", "updated_at"}).
+			AddRow(group.ID, group.UserID, group.Name, group.StartDate, group.EndDate, group.Status, now, now))
+}
+
+// expectRoomLock expects the room to be locked and its expired holds released
+func expectRoomLock(mock sqlmock.Sqlmock, roomID string) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM rooms WHERE id = $1 FOR UPDATE`)).
+		WithArgs(roomID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(roomID))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'expired'`)).
+		WithArgs(roomID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectOverlap expects the room's availability to be checked
+func expectOverlap(mock sqlmock.Sqlmock, roomID string, taken bool) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WithArgs(roomID, sqlmock.AnyArg(), sqlmock.AnyArg(), "").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(taken))
+}
+
+func TestCreateGroupRollsBackWhenARoomIsTaken(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	group, bookings := testGroup()
+
+	// Rooms are locked in room order, so room-1 is booked before room-2 is found taken
+	mock.ExpectBegin()
+	expectGroupInsert(mock, group)
+	expectRoomLock(mock, "room-1")
+	expectOverlap(mock, "room-1", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnRows(bookingRows(bookings[1]))
+	expectRoomLock(mock, "room-2")
+	expectOverlap(mock, "room-2", true)
+	mock.ExpectRollback()
+
+	_, created, err := NewBookingGroupRepository(db).Create(group, bookings)
+	if !errors.Is(err, model.ErrBookingConflict) {
+		t.Errorf("creating a group with a taken room returned %v, want %v", err, model.ErrBookingConflict)
+	}
+	if created != nil {
+		t.Errorf("creating a group with a taken room returned bookings %v, want none", created)
+	}
+}
+
+func TestCreateGroupRollsBackWhenAConcurrentBookingWins(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	group, bookings := testGroup()
+
+	mock.ExpectBegin()
+	expectGroupInsert(mock, group)
+	expectRoomLock(mock, "room-1")
+	expectOverlap(mock, "room-1", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnRows(bookingRows(bookings[1]))
+	expectRoomLock(mock, "room-2")
+	expectOverlap(mock, "room-2", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnError(&pq.Error{Code: "23P01"})
+	mock.ExpectRollback()
+
+	_, _, err := NewBookingGroupRepository(db).Create(group, bookings)
+	if !errors.Is(err, model.ErrBookingConflict) {
+		t.Errorf("creating a group that loses a room to a concurrent booking returned %v, want %v", err, model.ErrBookingConflict)
+	}
+}
+
+func TestCreateGroupCommitsWhenEveryRoomIsFree(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	group, bookings := testGroup()
+
+	mock.ExpectBegin()
+	expectGroupInsert(mock, group)
+	expectRoomLock(mock, "room-1")
+	expectOverlap(mock, "room-1", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnRows(bookingRows(bookings[1]))
+	expectRoomLock(mock, "room-2")
+	expectOverlap(mock, "room-2", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnRows(bookingRows(bookings[0]))
+	mock.ExpectCommit()
+
+	_, created, err := NewBookingGroupRepository(db).Create(group, bookings)
+	if err != nil {
+		t.Fatalf("creating a group of free rooms failed: %v", err)
+	}
+
+	// The bookings come back in the order they were given, not the order they were locked in
+	for i, booking := range created {
+		if booking.RoomID != bookings[i].RoomID {
+			t.Errorf("created booking %d is for %s, want %s", i, booking.RoomID, bookings[i].RoomID)
+		}
+	}
+}
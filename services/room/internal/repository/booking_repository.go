@@ -9,6 +9,15 @@ import (
 	"github.com/flaminshinjan/address.ai/services/room/internal/model"
 )
 
+// bookingColumns is the column list selected for every booking query
+const bookingColumns = `id, room_id, COALESCE(user_id, ''), start_date, end_date, total_price, tax_total, COALESCE(display_currency, ''), COALESCE(exchange_rate, 0),
+	status, payment_status, deposit_amount, deposit_due_at, amount_paid, COALESCE(group_id::text, ''), price_breakdown, checked_in_at, checked_out_at, hold_expires_at,
+	COALESCE(external_source, ''), COALESCE(external_uid, ''), created_at, updated_at`
+
+// blockingBookingStatuses lists the booking statuses that occupy a room for their date range.
+// It must be kept in sync with the bookings_no_overlap exclusion constraint.
+const blockingBookingStatuses = `('held', 'confirmed', 'checked_in', 'external')`
+
 // BookingRepository handles database operations for bookings
 type BookingRepository struct {
 	db *sql.DB
@@ -19,13 +28,75 @@ func NewBookingRepository(db *sql.DB) *BookingRepository {
 	return &BookingRepository{db: db}
 }
 
-// Create creates a new booking
-func (r *BookingRepository) Create(booking model.Booking) (model.Booking, error) {
+// scanBooking scans a row selected with bookingColumns into a booking
+func scanBooking(row rowScanner) (model.Booking, error) {
+	var booking model.Booking
+	err := row.Scan(
+		&booking.ID,
+		&booking.RoomID,
+		&booking.UserID,
+		&booking.StartDate,
+		&booking.EndDate,
+		&booking.TotalPrice,
+		&booking.TaxTotal,
+		&booking.DisplayCurrency,
+		&booking.ExchangeRate,
+		&booking.Status,
+		&booking.PaymentStatus,
+		&booking.DepositAmount,
+		&booking.DepositDueAt,
+		&booking.AmountPaid,
+		&booking.GroupID,
+		&booking.PriceBreakdown,
+		&booking.CheckedInAt,
+		&booking.CheckedOutAt,
+		&booking.HoldExpiresAt,
+		&booking.ExternalSource,
+		&booking.ExternalUID,
+		&booking.CreatedAt,
+		&booking.UpdatedAt,
+	)
+
+	if err == nil {
+		booking.BalanceDue = booking.TotalPrice.Add(booking.TaxTotal).Sub(booking.AmountPaid)
+	}
+
+	// The display total follows the booking's price at the rate it was booked at
+	if err == nil && booking.DisplayCurrency != "" {
+		booking.ShowIn(model.CurrencyRate{Currency: booking.DisplayCurrency, Rate: booking.ExchangeRate})
+	}
+
+	return booking, err
+}
+
+// scanBookings scans all rows selected with bookingColumns
+func scanBookings(rows *sql.Rows) ([]model.Booking, error) {
+	defer rows.Close()
+
+	var bookings []model.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+// insertBooking inserts a booking using q
+func insertBooking(q querier, booking model.Booking) (model.Booking, error) {
 	query := `
-		INSERT INTO bookings (id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
-	`
+		INSERT INTO bookings (id, room_id, user_id, start_date, end_date, total_price, tax_total, display_currency, exchange_rate, status, group_id, price_breakdown,
+			hold_expires_at, external_source, external_uid, deposit_amount, deposit_due_at, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9::numeric, 0), $10, NULLIF($11, '')::uuid, $12, $13, NULLIF($14, ''), NULLIF($15, ''),
+			$16, $17, $18, $19)
+		RETURNING ` + bookingColumns
 
 	// Generate UUID if not provided
 	if booking.ID == "" {
@@ -42,7 +113,7 @@ func (r *BookingRepository) Create(booking model.Booking) (model.Booking, error)
 		booking.Status = "confirmed"
 	}
 
-	err := r.db.QueryRow(
+	created, err := scanBooking(q.QueryRow(
 		query,
 		booking.ID,
 		booking.RoomID,
@@ -50,49 +121,131 @@ func (r *BookingRepository) Create(booking model.Booking) (model.Booking, error)
 		booking.StartDate,
 		booking.EndDate,
 		booking.TotalPrice,
+		booking.PriceBreakdown.Taxes.Total,
+		booking.DisplayCurrency,
+		booking.ExchangeRate,
 		booking.Status,
+		booking.GroupID,
+		booking.PriceBreakdown,
+		booking.HoldExpiresAt,
+		booking.ExternalSource,
+		booking.ExternalUID,
+		booking.DepositAmount,
+		booking.DepositDueAt,
 		booking.CreatedAt,
 		booking.UpdatedAt,
-	).Scan(
-		&booking.ID,
-		&booking.RoomID,
-		&booking.UserID,
-		&booking.StartDate,
-		&booking.EndDate,
-		&booking.TotalPrice,
-		&booking.Status,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+	))
 
 	if err != nil {
+		if isExclusionViolation(err) {
+			return model.Booking{}, model.ErrBookingConflict
+		}
 		return model.Booking{}, err
 	}
 
-	return booking, nil
+	return created, nil
+}
+
+// lockRoom takes a row lock on the room so that concurrent bookings for it are serialized.
+// Holds on the room that have run out are expired while the lock is held.
+func lockRoom(q querier, roomID string) error {
+	var id string
+	err := q.QueryRow(`SELECT id FROM rooms WHERE id = $1 FOR UPDATE`, roomID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("room not found")
+	}
+	if err != nil {
+		return err
+	}
+
+	return expireHolds(q, roomID)
+}
+
+// expireHolds marks the room's holds that have run out as expired, so that they stop
+// blocking the room before the background reaper gets to them
+func expireHolds(q querier, roomID string) error {
+	_, err := q.Exec(`
+		UPDATE bookings
+		SET status = 'expired', updated_at = NOW()
+		WHERE room_id = $1
+		AND status = 'held'
+		AND hold_expires_at <= NOW()
+	`, roomID)
+	return err
+}
+
+// hasOverlap reports whether the room is taken for any part of [startDate, endDate), either
+// by a blocking booking or by a maintenance window, ignoring the booking or window with ID
+// excludeID (pass "" to consider everything)
+func hasOverlap(q querier, roomID string, startDate, endDate time.Time, excludeID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM bookings
+			WHERE room_id = $1
+			AND status IN ` + blockingBookingStatuses + `
+			AND start_date < $3
+			AND end_date > $2
+			AND id::text <> $4
+		) OR EXISTS (
+			SELECT 1
+			FROM maintenance_windows
+			WHERE room_id = $1
+			AND status IN ` + blockingMaintenanceStatuses + `
+			AND start_date < $3
+			AND end_date > $2
+			AND id::text <> $4
+		)
+	`
+
+	var exists bool
+	err := q.QueryRow(query, roomID, startDate, endDate, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// Create creates a new booking
+func (r *BookingRepository) Create(booking model.Booking) (model.Booking, error) {
+	return insertBooking(r.db, booking)
+}
+
+// CreateIfAvailable creates a booking in a single transaction, locking the room and
+// re-checking availability first. It returns model.ErrBookingConflict if the room is
+// already booked for an overlapping date range, including when a concurrent request wins.
+func (r *BookingRepository) CreateIfAvailable(booking model.Booking) (model.Booking, error) {
+	var created model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockRoom(tx, booking.RoomID); err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, booking.RoomID, booking.StartDate, booking.EndDate, "")
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return model.ErrBookingConflict
+		}
+
+		created, err = insertBooking(tx, booking)
+		return err
+	})
+
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return created, nil
 }
 
 // GetByID gets a booking by ID
 func (r *BookingRepository) GetByID(id string) (model.Booking, error) {
 	query := `
-		SELECT id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		WHERE id = $1
 	`
 
-	var booking model.Booking
-	err := r.db.QueryRow(query, id).Scan(
-		&booking.ID,
-		&booking.RoomID,
-		&booking.UserID,
-		&booking.StartDate,
-		&booking.EndDate,
-		&booking.TotalPrice,
-		&booking.Status,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
-
+	booking, err := scanBooking(r.db.QueryRow(query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Booking{}, errors.New("booking not found")
@@ -106,7 +259,7 @@ func (r *BookingRepository) GetByID(id string) (model.Booking, error) {
 // GetByUserID gets bookings by user ID
 func (r *BookingRepository) GetByUserID(userID string, limit, offset int) ([]model.Booking, error) {
 	query := `
-		SELECT id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		WHERE user_id = $1
 		ORDER BY start_date DESC
@@ -117,39 +270,14 @@ func (r *BookingRepository) GetByUserID(userID string, limit, offset int) ([]mod
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var bookings []model.Booking
-	for rows.Next() {
-		var booking model.Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.RoomID,
-			&booking.UserID,
-			&booking.StartDate,
-			&booking.EndDate,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-			&booking.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		bookings = append(bookings, booking)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-
-	return bookings, nil
+	return scanBookings(rows)
 }
 
 // GetByRoomID gets bookings by room ID
 func (r *BookingRepository) GetByRoomID(roomID string, limit, offset int) ([]model.Booking, error) {
 	query := `
-		SELECT id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		WHERE room_id = $1
 		ORDER BY start_date DESC
@@ -160,76 +288,204 @@ func (r *BookingRepository) GetByRoomID(roomID string, limit, offset int) ([]mod
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var bookings []model.Booking
-	for rows.Next() {
-		var booking model.Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.RoomID,
-			&booking.UserID,
-			&booking.StartDate,
-			&booking.EndDate,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-			&booking.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		bookings = append(bookings, booking)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
 
-	return bookings, nil
+	return scanBookings(rows)
 }
 
-// Update updates a booking
-func (r *BookingRepository) Update(booking model.Booking) (model.Booking, error) {
+// updateBooking saves every mutable field of a booking, including its deposit, using q
+func updateBooking(q querier, booking model.Booking) (model.Booking, error) {
 	query := `
 		UPDATE bookings
-		SET room_id = $1, user_id = $2, start_date = $3, end_date = $4, total_price = $5, status = $6, updated_at = $7
-		WHERE id = $8
-		RETURNING id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
-	`
+		SET room_id = $1, user_id = NULLIF($2, ''), start_date = $3, end_date = $4, total_price = $5, tax_total = $6, status = $7, price_breakdown = $8,
+			deposit_amount = $9, deposit_due_at = $10, updated_at = $11
+		WHERE id = $12
+		RETURNING ` + bookingColumns
 
 	booking.UpdatedAt = time.Now()
 
-	err := r.db.QueryRow(
+	updated, err := scanBooking(q.QueryRow(
 		query,
 		booking.RoomID,
 		booking.UserID,
 		booking.StartDate,
 		booking.EndDate,
 		booking.TotalPrice,
+		booking.PriceBreakdown.Taxes.Total,
 		booking.Status,
+		booking.PriceBreakdown,
+		booking.DepositAmount,
+		booking.DepositDueAt,
 		booking.UpdatedAt,
 		booking.ID,
-	).Scan(
-		&booking.ID,
-		&booking.RoomID,
-		&booking.UserID,
-		&booking.StartDate,
-		&booking.EndDate,
-		&booking.TotalPrice,
-		&booking.Status,
-		&booking.CreatedAt,
-		&booking.UpdatedAt,
-	)
+	))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Booking{}, errors.New("booking not found")
 		}
+		if isExclusionViolation(err) {
+			return model.Booking{}, model.ErrBookingConflict
+		}
 		return model.Booking{}, err
 	}
 
-	return booking, nil
+	return updated, nil
+}
+
+// Update updates a booking
+func (r *BookingRepository) Update(booking model.Booking) (model.Booking, error) {
+	return updateBooking(r.db, booking)
+}
+
+// Amend saves a booking's new room, dates and price and records the change in the
+// booking history, all in one transaction. The booking is locked and must still be as
+// the amendment found it, in a confirmed or checked-in state, so that a cancellation or
+// check-out made meanwhile is not undone; model.ErrBookingChanged is returned otherwise.
+// The target room is locked and re-checked for availability, ignoring the booking
+// itself; model.ErrBookingConflict is returned if it is taken.
+func (r *BookingRepository) Amend(booking model.Booking, history model.BookingHistory) (model.Booking, error) {
+	var amended model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		current, err := scanBooking(tx.QueryRow(`
+			SELECT `+bookingColumns+`
+			FROM bookings
+			WHERE id = $1
+			FOR UPDATE
+		`, booking.ID))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("booking not found")
+			}
+			return err
+		}
+
+		if current.Status != booking.Status || current.RoomID != history.OldRoomID ||
+			!current.StartDate.Equal(history.OldStartDate) || !current.EndDate.Equal(history.OldEndDate) {
+			return model.ErrBookingChanged
+		}
+
+		if err := lockRoom(tx, booking.RoomID); err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, booking.RoomID, booking.StartDate, booking.EndDate, booking.ID)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return model.ErrBookingConflict
+		}
+
+		// An amendment never changes the booking's status
+		amended, err = scanBooking(tx.QueryRow(`
+			UPDATE bookings
+			SET room_id = $1, start_date = $2, end_date = $3, total_price = $4, tax_total = $5, price_breakdown = $6,
+				deposit_amount = $7, deposit_due_at = $8, updated_at = $9
+			WHERE id = $10
+			AND status IN ('confirmed', 'checked_in')
+			RETURNING `+bookingColumns,
+			booking.RoomID, booking.StartDate, booking.EndDate, booking.TotalPrice, booking.PriceBreakdown.Taxes.Total, booking.PriceBreakdown,
+			booking.DepositAmount, booking.DepositDueAt, time.Now(), booking.ID,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return model.ErrBookingChanged
+			}
+			if isExclusionViolation(err) {
+				return model.ErrBookingConflict
+			}
+			return err
+		}
+
+		return insertBookingHistory(tx, history)
+	})
+
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return amended, nil
+}
+
+// insertBookingHistory inserts a booking history entry using q
+func insertBookingHistory(q querier, history model.BookingHistory) error {
+	query := `
+		INSERT INTO booking_history (id, booking_id, changed_by, action, old_room_id, new_room_id, old_start_date, new_start_date,
+			old_end_date, new_end_date, old_total_price, new_total_price, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	// Generate UUID if not provided
+	if history.ID == "" {
+		history.ID = uuid.New().String()
+	}
+
+	history.CreatedAt = time.Now()
+
+	_, err := q.Exec(
+		query,
+		history.ID,
+		history.BookingID,
+		history.ChangedBy,
+		history.Action,
+		history.OldRoomID,
+		history.NewRoomID,
+		history.OldStartDate,
+		history.NewStartDate,
+		history.OldEndDate,
+		history.NewEndDate,
+		history.OldTotalPrice,
+		history.NewTotalPrice,
+		history.CreatedAt,
+	)
+	return err
+}
+
+// GetHistory gets the change history of a booking, oldest first
+func (r *BookingRepository) GetHistory(bookingID string) ([]model.BookingHistory, error) {
+	query := `
+		SELECT id, booking_id, changed_by, action, old_room_id, new_room_id, old_start_date, new_start_date,
+			old_end_date, new_end_date, old_total_price, new_total_price, created_at
+		FROM booking_history
+		WHERE booking_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []model.BookingHistory
+	for rows.Next() {
+		var entry model.BookingHistory
+		err := rows.Scan(
+			&entry.ID,
+			&entry.BookingID,
+			&entry.ChangedBy,
+			&entry.Action,
+			&entry.OldRoomID,
+			&entry.NewRoomID,
+			&entry.OldStartDate,
+			&entry.NewStartDate,
+			&entry.OldEndDate,
+			&entry.NewEndDate,
+			&entry.OldTotalPrice,
+			&entry.NewTotalPrice,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return history, nil
 }
 
 // UpdateStatus updates a booking's status
@@ -244,6 +500,468 @@ func (r *BookingRepository) UpdateStatus(id, status string) error {
 	return err
 }
 
+// insertBookingCancellation records the outcome of a cancellation using q
+func insertBookingCancellation(q querier, cancellation model.BookingCancellation) (model.BookingCancellation, error) {
+	query := `
+		INSERT INTO booking_cancellations (booking_id, policy_id, policy_name, rule_applied, hours_before_start, penalty, refund_amount, cancelled_by, created_at)
+		VALUES ($1, NULLIF($2, '')::uuid, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	cancellation.CreatedAt = time.Now()
+
+	_, err := q.Exec(
+		query,
+		cancellation.BookingID,
+		cancellation.PolicyID,
+		cancellation.PolicyName,
+		cancellation.RuleApplied,
+		cancellation.HoursBeforeStart,
+		cancellation.Penalty,
+		cancellation.RefundAmount,
+		cancellation.CancelledBy,
+		cancellation.CreatedAt,
+	)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+
+	return cancellation, nil
+}
+
+// Cancel cancels a confirmed booking and records its refund and penalty in one transaction
+func (r *BookingRepository) Cancel(cancellation model.BookingCancellation) (model.BookingCancellation, error) {
+	return r.cancel(cancellation, "cancelled", "", errors.New("booking is not in a confirmed state"))
+}
+
+// CancelUnpaidDeposit cancels a confirmed booking for its unpaid deposit as Cancel does,
+// unless the deposit has been paid since the booking was found unpaid
+func (r *BookingRepository) CancelUnpaidDeposit(cancellation model.BookingCancellation) (model.BookingCancellation, error) {
+	return r.cancel(cancellation, "cancelled", "AND amount_paid < deposit_amount", errors.New("booking is not confirmed with a deposit outstanding"))
+}
+
+// MarkNoShow marks a confirmed booking as a no-show and records the penalty charged for
+// it under its cancellation policy in one transaction
+func (r *BookingRepository) MarkNoShow(cancellation model.BookingCancellation) (model.BookingCancellation, error) {
+	return r.cancel(cancellation, "no_show", "", errors.New("booking is not in a confirmed state"))
+}
+
+// cancel moves a confirmed booking that also meets condition to status, returning
+// notCancelled if it does not, and records its refund and penalty in one transaction
+func (r *BookingRepository) cancel(cancellation model.BookingCancellation, status, condition string, notCancelled error) (model.BookingCancellation, error) {
+	var recorded model.BookingCancellation
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE bookings
+			SET status = $1, updated_at = $2
+			WHERE id = $3
+			AND status = 'confirmed'
+			`+condition,
+			status, time.Now(), cancellation.BookingID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return notCancelled
+		}
+
+		recorded, err = insertBookingCancellation(tx, cancellation)
+		return err
+	})
+
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+
+	return recorded, nil
+}
+
+// GetCancellation gets the recorded cancellation of a booking
+func (r *BookingRepository) GetCancellation(bookingID string) (model.BookingCancellation, error) {
+	query := `
+		SELECT booking_id, COALESCE(policy_id::text, ''), policy_name, rule_applied, hours_before_start, penalty, refund_amount, cancelled_by, created_at
+		FROM booking_cancellations
+		WHERE booking_id = $1
+	`
+
+	var cancellation model.BookingCancellation
+	err := r.db.QueryRow(query, bookingID).Scan(
+		&cancellation.BookingID,
+		&cancellation.PolicyID,
+		&cancellation.PolicyName,
+		&cancellation.RuleApplied,
+		&cancellation.HoursBeforeStart,
+		&cancellation.Penalty,
+		&cancellation.RefundAmount,
+		&cancellation.CancelledBy,
+		&cancellation.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.BookingCancellation{}, errors.New("cancellation not found")
+		}
+		return model.BookingCancellation{}, err
+	}
+
+	return cancellation, nil
+}
+
+// CheckIn marks a confirmed booking as checked in, its room as occupied and opens the
+// stay's folio in one transaction
+func (r *BookingRepository) CheckIn(id string) (model.Booking, error) {
+	var booking model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var err error
+		booking, err = scanBooking(tx.QueryRow(`
+			UPDATE bookings
+			SET status = 'checked_in', checked_in_at = $1, updated_at = $1
+			WHERE id = $2
+			AND status = 'confirmed'
+			RETURNING `+bookingColumns,
+			now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("booking is not in a confirmed state")
+			}
+			return err
+		}
+
+		// The room must be ready for the guest; anything else rolls the check-in back
+		result, err := tx.Exec(`
+			UPDATE rooms
+			SET status = 'occupied', updated_at = $1
+			WHERE id = $2
+			AND status = 'available'
+		`, now, booking.RoomID)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 {
+			return errors.New("room is not ready for check-in")
+		}
+
+		return openFolio(tx, booking, now)
+	})
+
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// CheckOut marks a checked-in booking as completed, closes its folio with the last of the
+// room-service orders delivered to the room, flags its room for housekeeping and queues
+// the room's clean in one transaction
+func (r *BookingRepository) CheckOut(id string, orders []model.FoodOrder) (model.Booking, error) {
+	var booking model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var err error
+		booking, err = scanBooking(tx.QueryRow(`
+			UPDATE bookings
+			SET status = 'completed', checked_out_at = $1, updated_at = $1
+			WHERE id = $2
+			AND status = 'checked_in'
+			RETURNING `+bookingColumns,
+			now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("booking is not checked in")
+			}
+			return err
+		}
+
+		if err := closeFolio(tx, booking.ID, orders, now); err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE rooms
+			SET status = 'cleaning', updated_at = $1
+			WHERE id = $2
+		`, now, booking.RoomID)
+		if err != nil {
+			return err
+		}
+
+		_, err = insertHousekeepingTask(tx, model.HousekeepingTask{
+			RoomID:    booking.RoomID,
+			BookingID: booking.ID,
+			Type:      model.TaskClean,
+			Priority:  model.PriorityHigh,
+		})
+		return err
+	})
+
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ListBlocking lists the bookings that occupy a room on or after the given date, in date
+// order. Holds that have run out are left out, since the next lock on the room expires them.
+func (r *BookingRepository) ListBlocking(roomID string, from time.Time) ([]model.Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE room_id = $1
+		AND status IN ` + blockingBookingStatuses + `
+		AND NOT (status = 'held' AND hold_expires_at <= NOW())
+		AND end_date > $2
+		ORDER BY start_date
+	`
+
+	rows, err := r.db.Query(query, roomID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBookings(rows)
+}
+
+// ListNoShows lists the confirmed bookings that should have started before the given
+// date, whose guests never checked in
+func (r *BookingRepository) ListNoShows(before time.Time) ([]model.Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE status = 'confirmed'
+		AND start_date < $1
+		ORDER BY start_date
+	`
+
+	rows, err := r.db.Query(query, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBookings(rows)
+}
+
+// ListUnpaidDeposits lists the confirmed bookings whose deposit was due before the given
+// time and has not been paid in full
+func (r *BookingRepository) ListUnpaidDeposits(before time.Time) ([]model.Booking, error) {
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE status = 'confirmed'
+		AND deposit_amount > 0
+		AND amount_paid < deposit_amount
+		AND deposit_due_at < $1
+		ORDER BY deposit_due_at
+	`
+
+	rows, err := r.db.Query(query, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBookings(rows)
+}
+
+// ConfirmHold turns a checkout hold into a confirmed booking if it has not run out.
+// Holds offered to the waitlist are confirmed through the waitlist instead.
+func (r *BookingRepository) ConfirmHold(id string) (model.Booking, error) {
+	query := `
+		UPDATE bookings
+		SET status = 'confirmed', hold_expires_at = NULL, updated_at = $1
+		WHERE id = $2
+		AND status = 'held'
+		AND hold_expires_at > $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM waitlist_entries
+			WHERE offered_booking_id = bookings.id
+		)
+		RETURNING ` + bookingColumns
+
+	booking, err := scanBooking(r.db.QueryRow(query, time.Now(), id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Booking{}, errors.New("hold not found or expired")
+		}
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ReleaseHold gives up a checkout hold before it runs out
+func (r *BookingRepository) ReleaseHold(id string) (model.Booking, error) {
+	query := `
+		UPDATE bookings
+		SET status = 'released', updated_at = $1
+		WHERE id = $2
+		AND status = 'held'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM waitlist_entries
+			WHERE offered_booking_id = bookings.id
+		)
+		RETURNING ` + bookingColumns
+
+	booking, err := scanBooking(r.db.QueryRow(query, time.Now(), id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Booking{}, errors.New("hold not found or expired")
+		}
+		return model.Booking{}, err
+	}
+
+	return booking, nil
+}
+
+// ExpireHolds marks every checkout hold that has run out as expired and returns them.
+// Holds offered to the waitlist are left to expire with their offers.
+func (r *BookingRepository) ExpireHolds() ([]model.Booking, error) {
+	query := `
+		UPDATE bookings
+		SET status = 'expired', updated_at = $1
+		WHERE status = 'held'
+		AND hold_expires_at <= $1
+		AND NOT EXISTS (
+			SELECT 1
+			FROM waitlist_entries
+			WHERE offered_booking_id = bookings.id
+		)
+		RETURNING ` + bookingColumns
+
+	rows, err := r.db.Query(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBookings(rows)
+}
+
+// ImportExternal synchronizes a room's external blocks from source with blocks in one
+// transaction. Blocks are matched on their external UID: new ones are inserted, moved ones
+// are updated and current ones no longer in the source are cancelled. Blocks that would overlap
+// another booking are skipped and reported as conflicts.
+func (r *BookingRepository) ImportExternal(roomID, source string, blocks []model.Booking) (model.CalendarImportResult, error) {
+	result := model.CalendarImportResult{
+		RoomID:    roomID,
+		Source:    source,
+		Conflicts: []model.CalendarImportConflict{},
+	}
+
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockRoom(tx, roomID); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`
+			SELECT `+bookingColumns+`
+			FROM bookings
+			WHERE room_id = $1
+			AND external_source = $2
+			AND status = 'external'
+			AND end_date > CURRENT_DATE
+		`, roomID, source)
+		if err != nil {
+			return err
+		}
+
+		existing, err := scanBookings(rows)
+		if err != nil {
+			return err
+		}
+
+		incoming := make(map[string]bool)
+		for _, block := range blocks {
+			incoming[block.ExternalUID] = true
+		}
+
+		// Cancel dropped blocks first so that their dates are free for moved ones
+		known := make(map[string]model.Booking)
+		now := time.Now()
+		for _, booking := range existing {
+			if incoming[booking.ExternalUID] {
+				known[booking.ExternalUID] = booking
+				continue
+			}
+
+			if _, err := tx.Exec(`
+				UPDATE bookings
+				SET status = 'cancelled', updated_at = $1
+				WHERE id = $2
+			`, now, booking.ID); err != nil {
+				return err
+			}
+			result.Removed++
+		}
+
+		for _, block := range blocks {
+			current, found := known[block.ExternalUID]
+			if found && current.StartDate.Equal(block.StartDate) && current.EndDate.Equal(block.EndDate) {
+				continue
+			}
+
+			overlap, err := hasOverlap(tx, roomID, block.StartDate, block.EndDate, current.ID)
+			if err != nil {
+				return err
+			}
+			if overlap {
+				result.Conflicts = append(result.Conflicts, model.CalendarImportConflict{
+					UID:       block.ExternalUID,
+					StartDate: block.StartDate,
+					EndDate:   block.EndDate,
+					Reason:    "overlaps an existing booking or maintenance window",
+				})
+				continue
+			}
+
+			if found {
+				if _, err := tx.Exec(`
+					UPDATE bookings
+					SET start_date = $1, end_date = $2, updated_at = $3
+					WHERE id = $4
+				`, block.StartDate, block.EndDate, now, current.ID); err != nil {
+					return err
+				}
+				result.Updated++
+				continue
+			}
+
+			block.RoomID = roomID
+			block.ExternalSource = source
+			block.Status = "external"
+			if _, err := insertBooking(tx, block); err != nil {
+				return err
+			}
+			result.Created++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return model.CalendarImportResult{}, err
+	}
+
+	return result, nil
+}
+
 // Delete deletes a booking
 func (r *BookingRepository) Delete(id string) error {
 	query := `
@@ -271,7 +989,7 @@ func (r *BookingRepository) Delete(id string) error {
 // List lists all bookings
 func (r *BookingRepository) List(limit, offset int) ([]model.Booking, error) {
 	query := `
-		SELECT id, room_id, user_id, start_date, end_date, total_price, status, created_at, updated_at
+		SELECT ` + bookingColumns + `
 		FROM bookings
 		ORDER BY start_date DESC
 		LIMIT $1 OFFSET $2
@@ -281,54 +999,17 @@ func (r *BookingRepository) List(limit, offset int) ([]model.Booking, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var bookings []model.Booking
-	for rows.Next() {
-		var booking model.Booking
-		err := rows.Scan(
-			&booking.ID,
-			&booking.RoomID,
-			&booking.UserID,
-			&booking.StartDate,
-			&booking.EndDate,
-			&booking.TotalPrice,
-			&booking.Status,
-			&booking.CreatedAt,
-			&booking.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		bookings = append(bookings, booking)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
 
-	return bookings, nil
+	return scanBookings(rows)
 }
 
-// CheckRoomAvailability checks if a room is available for the given dates
+// CheckRoomAvailability checks if a room is available for the given dates.
+// Date ranges are half-open, so a stay may start on the day another one ends.
 func (r *BookingRepository) CheckRoomAvailability(roomID string, startDate, endDate time.Time) (bool, error) {
-	query := `
-		SELECT COUNT(*)
-		FROM bookings
-		WHERE room_id = $1
-		AND status = 'confirmed'
-		AND (
-			(start_date <= $2 AND end_date >= $2)
-			OR (start_date <= $3 AND end_date >= $3)
-			OR (start_date >= $2 AND end_date <= $3)
-		)
-	`
-
-	var count int
-	err := r.db.QueryRow(query, roomID, startDate, endDate).Scan(&count)
+	overlap, err := hasOverlap(r.db, roomID, startDate, endDate, "")
 	if err != nil {
 		return false, err
 	}
 
-	return count == 0, nil
+	return !overlap, nil
 }
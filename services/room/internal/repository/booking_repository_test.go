@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// bookingColumnNames names the columns of bookingColumns, for mocked booking rows
+var bookingColumnNames = []string{"id", "room_id", "user_id", "start_date", "end_date", "total_price", "tax_total", "display_currency", "exchange_rate",
+	"status", "payment_status", "deposit_amount", "deposit_due_at", "amount_paid", "group_id", "price_breakdown", "checked_in_at", "checked_out_at", "hold_expires_at",
+	"external_source", "external_uid", "created_at", "updated_at"}
+
+// newMockDB opens a mocked database that fails the test if it is used other than expected
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+	})
+
+	return db, mock
+}
+
+// bookingRows returns bookings as rows selected with bookingColumns
+func bookingRows(bookings ...model.Booking) *sqlmock.Rows {
+	rows := sqlmock.NewRows(bookingColumnNames)
+	for _, b := range bookings {
+		rows.AddRow(b.ID, b.RoomID, b.UserID, b.StartDate, b.EndDate, b.TotalPrice.Decimal(), "0.00", "", 0.0,
+			b.Status, "authorized", "0.00", nil, "0.00", b.GroupID, []byte(`{}`), b.CheckedInAt, nil, nil,
+			b.ExternalSource, b.ExternalUID, b.CreatedAt, b.UpdatedAt)
+	}
+	return rows
+}
+
+// testBooking returns a confirmed four-night booking of room-1
+func testBooking() model.Booking {
+	return model.Booking{
+		ID:        "booking-1",
+		RoomID:    "room-1",
+		UserID:    "user-1",
+		StartDate: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+		Status:    "confirmed",
+	}
+}
+
+// testAmendment extends a booking by two nights
+func testAmendment(booking model.Booking) (model.Booking, model.BookingHistory) {
+	amended := booking
+	amended.EndDate = booking.EndDate.AddDate(0, 0, 2)
+
+	return amended, model.BookingHistory{
+		BookingID:    booking.ID,
+		Action:       "amended",
+		OldRoomID:    booking.RoomID,
+		NewRoomID:    amended.RoomID,
+		OldStartDate: booking.StartDate,
+		NewStartDate: amended.StartDate,
+		OldEndDate:   booking.EndDate,
+		NewEndDate:   amended.EndDate,
+	}
+}
+
+func TestAmendRefusesBookingCancelledMeanwhile(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	amended, history := testAmendment(booking)
+
+	cancelled := booking
+	cancelled.Status = "cancelled"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WithArgs(booking.ID).
+		WillReturnRows(bookingRows(cancelled))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).Amend(amended, history)
+	if !errors.Is(err, model.ErrBookingChanged) {
+		t.Errorf("amending a booking cancelled meanwhile returned %v, want %v", err, model.ErrBookingChanged)
+	}
+}
+
+func TestAmendOnlyUpdatesOpenBookings(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	amended, history := testAmendment(booking)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WithArgs(booking.ID).
+		WillReturnRows(bookingRows(booking))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM rooms WHERE id = $1 FOR UPDATE`)).
+		WithArgs(booking.RoomID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(booking.RoomID))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'expired'`)).
+		WithArgs(booking.RoomID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	// The guard on the update leaves a booking checked out at the last moment alone
+	mock.ExpectQuery(regexp.QuoteMeta(`AND status IN ('confirmed', 'checked_in')`)).
+		WillReturnRows(sqlmock.NewRows(bookingColumnNames))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).Amend(amended, history)
+	if !errors.Is(err, model.ErrBookingChanged) {
+		t.Errorf("amending a booking closed meanwhile returned %v, want %v", err, model.ErrBookingChanged)
+	}
+}
+
+func TestAmendRollsBackWhenRoomIsTaken(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	amended, history := testAmendment(booking)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE`)).
+		WithArgs(booking.ID).
+		WillReturnRows(bookingRows(booking))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM rooms WHERE id = $1 FOR UPDATE`)).
+		WithArgs(booking.RoomID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(booking.RoomID))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'expired'`)).
+		WithArgs(booking.RoomID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WithArgs(booking.RoomID, amended.StartDate, amended.EndDate, booking.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).Amend(amended, history)
+	if !errors.Is(err, model.ErrBookingConflict) {
+		t.Errorf("amending into a taken room returned %v, want %v", err, model.ErrBookingConflict)
+	}
+}
+
+func TestCheckInRefusesBookingNotConfirmed(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'checked_in'`)).
+		WillReturnRows(sqlmock.NewRows(bookingColumnNames))
+	mock.ExpectRollback()
+
+	if _, err := NewBookingRepository(db).CheckIn("booking-1"); err == nil {
+		t.Error("checking in a booking that is not confirmed succeeded")
+	}
+}
+
+func TestCheckInRollsBackWhenRoomIsNotReady(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	checkedIn := booking
+	checkedIn.Status = "checked_in"
+
+	// The booking is checked in before the room is found still being cleaned, and no folio
+	// is opened for it
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'checked_in'`)).
+		WillReturnRows(bookingRows(checkedIn))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'occupied'`)).
+		WithArgs(sqlmock.AnyArg(), booking.RoomID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).CheckIn(booking.ID)
+	if err == nil || err.Error() != "room is not ready for check-in" {
+		t.Errorf("checking in to a room that is not ready returned %v, want the room to be refused", err)
+	}
+}
+
+func TestCheckOutRefusesBookingNotCheckedIn(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'completed'`)).
+		WillReturnRows(sqlmock.NewRows(bookingColumnNames))
+	mock.ExpectRollback()
+
+	if _, err := NewBookingRepository(db).CheckOut("booking-1", nil); err == nil {
+		t.Error("checking out a booking that is not checked in succeeded")
+	}
+}
+
+func TestCheckOutRollsBackWhenRoomCannotBeFlagged(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	completed := booking
+	completed.Status = "completed"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'completed'`)).
+		WillReturnRows(bookingRows(completed))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM folios`)).
+		WithArgs(booking.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'cleaning'`)).
+		WithArgs(sqlmock.AnyArg(), booking.RoomID).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if _, err := NewBookingRepository(db).CheckOut(booking.ID, nil); err == nil {
+		t.Error("checking out succeeded although the room could not be flagged for cleaning")
+	}
+}
+
+func TestListUnpaidDepositsOnlyListsConfirmedBookingsPastDue(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	now := time.Now()
+	mock.ExpectQuery(`status = 'confirmed'\s+AND deposit_amount > 0\s+AND amount_paid < deposit_amount\s+AND deposit_due_at < \$1`).
+		WithArgs(now).
+		WillReturnRows(bookingRows(testBooking()))
+
+	bookings, err := NewBookingRepository(db).ListUnpaidDeposits(now)
+	if err != nil {
+		t.Fatalf("listing unpaid deposits failed: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Errorf("listed %d bookings with unpaid deposits, want 1", len(bookings))
+	}
+}
+
+func TestCancelUnpaidDepositLeavesDepositPaidMeanwhile(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// The guest pays between the booking being listed and being cancelled
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`AND amount_paid < deposit_amount`)).
+		WithArgs("cancelled", sqlmock.AnyArg(), "booking-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).CancelUnpaidDeposit(model.BookingCancellation{BookingID: "booking-1", CancelledBy: "system"})
+	if err == nil {
+		t.Error("cancelling a booking whose deposit was paid meanwhile succeeded")
+	}
+}
+
+func TestCancelUnpaidDepositRollsBackWhenCancellationCannotBeRecorded(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`AND amount_paid < deposit_amount`)).
+		WithArgs("cancelled", sqlmock.AnyArg(), "booking-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO booking_cancellations`)).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).CancelUnpaidDeposit(model.BookingCancellation{BookingID: "booking-1", CancelledBy: "system"})
+	if err == nil {
+		t.Error("cancelling for an unpaid deposit succeeded although the cancellation could not be recorded")
+	}
+}
+
+func TestListNoShowsOnlyListsConfirmedBookingsPastArrival(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	today := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE status = 'confirmed'\s+AND start_date < \$1`).
+		WithArgs(today).
+		WillReturnRows(bookingRows(testBooking()))
+
+	bookings, err := NewBookingRepository(db).ListNoShows(today)
+	if err != nil {
+		t.Fatalf("listing no-shows failed: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Errorf("listed %d no-shows, want 1", len(bookings))
+	}
+}
+
+func TestMarkNoShowLeavesBookingCheckedInMeanwhile(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// The guest checks in between the booking being listed and being marked
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`AND status = 'confirmed'`)).
+		WithArgs("no_show", sqlmock.AnyArg(), "booking-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := NewBookingRepository(db).MarkNoShow(model.BookingCancellation{BookingID: "booking-1", CancelledBy: "system"})
+	if err == nil {
+		t.Error("marking a booking checked in meanwhile as a no-show succeeded")
+	}
+}
+
+func TestMarkNoShowRecordsPenalty(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`AND status = 'confirmed'`)).
+		WithArgs("no_show", sqlmock.AnyArg(), "booking-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO booking_cancellations`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if _, err := NewBookingRepository(db).MarkNoShow(model.BookingCancellation{BookingID: "booking-1", CancelledBy: "system"}); err != nil {
+		t.Errorf("marking a no-show failed: %v", err)
+	}
+}
+
+func TestExpireHoldsLeavesWaitlistOffers(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// An offer's hold is released with its waitlist entry, which also offers the room on
+	mock.ExpectQuery(`WHERE status = 'held'\s+AND hold_expires_at <= \$1\s+AND NOT EXISTS \(\s+SELECT 1\s+FROM waitlist_entries\s+WHERE offered_booking_id = bookings.id`).
+		WillReturnRows(sqlmock.NewRows(bookingColumnNames))
+
+	expired, err := NewBookingRepository(db).ExpireHolds()
+	if err != nil {
+		t.Fatalf("expiring holds failed: %v", err)
+	}
+	if len(expired) != 0 {
+		t.Errorf("expired %d holds, want none", len(expired))
+	}
+}
+
+func TestListBlockingListsEveryBookingThatTakesTheRoom(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// Holds, including waitlist offers, take the room as much as stays and external blocks do
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE room_id = \$1\s+AND status IN \('held', 'confirmed', 'checked_in', 'external'\)\s+AND NOT \(status = 'held' AND hold_expires_at <= NOW\(\)\)\s+AND end_date > \$2`).
+		WithArgs("room-1", from).
+		WillReturnRows(bookingRows(testBooking()))
+
+	bookings, err := NewBookingRepository(db).ListBlocking("room-1", from)
+	if err != nil {
+		t.Fatalf("listing blocking bookings failed: %v", err)
+	}
+	if len(bookings) != 1 {
+		t.Errorf("listed %d blocking bookings, want 1", len(bookings))
+	}
+}
+
+// testExternalBlock returns a block of room-1 imported from an OTA's calendar
+func testExternalBlock(id, uid string, startDay, endDay int) model.Booking {
+	return model.Booking{
+		ID:             id,
+		RoomID:         "room-1",
+		StartDate:      time.Date(2026, 3, startDay, 0, 0, 0, 0, time.UTC),
+		EndDate:        time.Date(2026, 3, endDay, 0, 0, 0, 0, time.UTC),
+		Status:         "external",
+		ExternalSource: "ota",
+		ExternalUID:    uid,
+	}
+}
+
+// expectExternalBlocks expects the room's current blocks from the source to be read
+func expectExternalBlocks(mock sqlmock.Sqlmock, blocks ...model.Booking) {
+	mock.ExpectQuery(`AND external_source = \$2\s+AND status = 'external'`).
+		WithArgs("room-1", "ota").
+		WillReturnRows(bookingRows(blocks...))
+}
+
+func TestImportExternalInsertsMovesAndCancelsOnUID(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	dropped := testExternalBlock("block-1", "a", 8, 10)
+	moved := testExternalBlock("block-2", "b", 12, 14)
+	unchanged := testExternalBlock("block-3", "c", 20, 22)
+	incoming := []model.Booking{
+		{StartDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC), ExternalUID: "b"},
+		{StartDate: unchanged.StartDate, EndDate: unchanged.EndDate, ExternalUID: "c"},
+		{StartDate: time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 27, 0, 0, 0, 0, time.UTC), ExternalUID: "d"},
+	}
+
+	// The dropped block is cancelled before the moved one is checked against its own dates
+	mock.ExpectBegin()
+	expectRoomLock(mock, "room-1")
+	expectExternalBlocks(mock, dropped, moved, unchanged)
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'cancelled'`)).
+		WithArgs(sqlmock.AnyArg(), "block-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS`)).
+		WithArgs("room-1", incoming[0].StartDate, incoming[0].EndDate, "block-2").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(regexp.QuoteMeta(`SET start_date = $1, end_date = $2`)).
+		WithArgs(incoming[0].StartDate, incoming[0].EndDate, sqlmock.AnyArg(), "block-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectOverlap(mock, "room-1", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO bookings`)).
+		WillReturnRows(bookingRows(testExternalBlock("block-4", "d", 25, 27)))
+	mock.ExpectCommit()
+
+	result, err := NewBookingRepository(db).ImportExternal("room-1", "ota", incoming)
+	if err != nil {
+		t.Fatalf("importing external blocks failed: %v", err)
+	}
+	if result.Created != 1 || result.Updated != 1 || result.Removed != 1 {
+		t.Errorf("import created %d, updated %d and removed %d blocks, want 1 of each", result.Created, result.Updated, result.Removed)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("import reported %d conflicts, want none", len(result.Conflicts))
+	}
+}
+
+func TestImportExternalReportsConflicts(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	incoming := []model.Booking{
+		{StartDate: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), ExternalUID: "a"},
+	}
+
+	// A block over a stay is skipped and reported, and the rest of the import still goes ahead
+	mock.ExpectBegin()
+	expectRoomLock(mock, "room-1")
+	expectExternalBlocks(mock)
+	expectOverlap(mock, "room-1", true)
+	mock.ExpectCommit()
+
+	result, err := NewBookingRepository(db).ImportExternal("room-1", "ota", incoming)
+	if err != nil {
+		t.Fatalf("importing external blocks failed: %v", err)
+	}
+	if result.Created != 0 {
+		t.Errorf("import created %d blocks over a stay", result.Created)
+	}
+	if len(result.Conflicts) != 1 || result.Conflicts[0].UID != "a" {
+		t.Fatalf("import reported conflicts %+v, want one for a", result.Conflicts)
+	}
+	if !result.Conflicts[0].StartDate.Equal(incoming[0].StartDate) || !result.Conflicts[0].EndDate.Equal(incoming[0].EndDate) {
+		t.Errorf("conflict reported for %v to %v, want the block's dates", result.Conflicts[0].StartDate, result.Conflicts[0].EndDate)
+	}
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/lib/pq"
+)
+
+// cancellationPolicyColumns is the column list selected for every cancellation policy query
+const cancellationPolicyColumns = `id, name, COALESCE(description, ''), tiers, is_default, created_at, updated_at`
+
+// CancellationPolicyRepository handles database operations for cancellation policies
+type CancellationPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewCancellationPolicyRepository creates a new CancellationPolicyRepository
+func NewCancellationPolicyRepository(db *sql.DB) *CancellationPolicyRepository {
+	return &CancellationPolicyRepository{db: db}
+}
+
+// scanCancellationPolicy scans a row selected with cancellationPolicyColumns into a policy
+func scanCancellationPolicy(row rowScanner) (model.CancellationPolicy, error) {
+	var policy model.CancellationPolicy
+	err := row.Scan(
+		&policy.ID,
+		&policy.Name,
+		&policy.Description,
+		&policy.Tiers,
+		&policy.IsDefault,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	return policy, err
+}
+
+// clearDefault unsets the default flag on every policy other than id
+func clearDefault(q querier, id string) error {
+	_, err := q.Exec(`
+		UPDATE cancellation_policies
+		SET is_default = FALSE, updated_at = $1
+		WHERE is_default = TRUE
+		AND id <> $2
+	`, time.Now(), id)
+	return err
+}
+
+// Create creates a new cancellation policy. If it is the default, the previous
+// default is unset in the same transaction.
+func (r *CancellationPolicyRepository) Create(policy model.CancellationPolicy) (model.CancellationPolicy, error) {
+	query := `
+		INSERT INTO cancellation_policies (id, name, description, tiers, is_default, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + cancellationPolicyColumns
+
+	// Generate UUID if not provided
+	if policy.ID == "" {
+		policy.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	var created model.CancellationPolicy
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if policy.IsDefault {
+			if err := clearDefault(tx, policy.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		created, err = scanCancellationPolicy(tx.QueryRow(
+			query,
+			policy.ID,
+			policy.Name,
+			policy.Description,
+			policy.Tiers,
+			policy.IsDefault,
+			policy.CreatedAt,
+			policy.UpdatedAt,
+		))
+		return err
+	})
+
+	if err != nil {
+		return model.CancellationPolicy{}, err
+	}
+
+	return created, nil
+}
+
+// GetByID gets a cancellation policy by ID
+func (r *CancellationPolicyRepository) GetByID(id string) (model.CancellationPolicy, error) {
+	query := `
+		SELECT ` + cancellationPolicyColumns + `
+		FROM cancellation_policies
+		WHERE id = $1
+	`
+
+	policy, err := scanCancellationPolicy(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.CancellationPolicy{}, errors.New("cancellation policy not found")
+		}
+		return model.CancellationPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+// Update updates a cancellation policy. If it becomes the default, the previous
+// default is unset in the same transaction.
+func (r *CancellationPolicyRepository) Update(policy model.CancellationPolicy) (model.CancellationPolicy, error) {
+	query := `
+		UPDATE cancellation_policies
+		SET name = $1, description = $2, tiers = $3, is_default = $4, updated_at = $5
+		WHERE id = $6
+		RETURNING ` + cancellationPolicyColumns
+
+	policy.UpdatedAt = time.Now()
+
+	var updated model.CancellationPolicy
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if policy.IsDefault {
+			if err := clearDefault(tx, policy.ID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		updated, err = scanCancellationPolicy(tx.QueryRow(
+			query,
+			policy.Name,
+			policy.Description,
+			policy.Tiers,
+			policy.IsDefault,
+			policy.UpdatedAt,
+			policy.ID,
+		))
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.CancellationPolicy{}, errors.New("cancellation policy not found")
+		}
+		return model.CancellationPolicy{}, err
+	}
+
+	return updated, nil
+}
+
+// Delete deletes a cancellation policy
+func (r *CancellationPolicyRepository) Delete(id string) error {
+	query := `
+		DELETE FROM cancellation_policies
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("cancellation policy not found")
+	}
+
+	return nil
+}
+
+// List lists all cancellation policies
+func (r *CancellationPolicyRepository) List(limit, offset int) ([]model.CancellationPolicy, error) {
+	query := `
+		SELECT ` + cancellationPolicyColumns + `
+		FROM cancellation_policies
+		ORDER BY name ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []model.CancellationPolicy
+	for rows.Next() {
+		policy, err := scanCancellationPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// Resolve finds the policy that governs a booking, in order of precedence: the policy of
+// the earliest created rate rule among ruleIDs that has one, the policy of the room type,
+// then the default policy. It returns false if none applies.
+func (r *CancellationPolicyRepository) Resolve(ruleIDs []string, roomTypeID string) (model.CancellationPolicy, bool, error) {
+	query := `
+		SELECT ` + cancellationPolicyColumns + `
+		FROM cancellation_policies p
+		WHERE p.id = COALESCE(
+			(
+				SELECT rr.cancellation_policy_id
+				FROM rate_rules rr
+				WHERE rr.id::text = ANY($1)
+				AND rr.cancellation_policy_id IS NOT NULL
+				ORDER BY rr.created_at ASC
+				LIMIT 1
+			),
+			(
+				SELECT rt.cancellation_policy_id
+				FROM room_types rt
+				WHERE rt.id::text = $2
+			),
+			(
+				SELECT d.id
+				FROM cancellation_policies d
+				WHERE d.is_default = TRUE
+				LIMIT 1
+			)
+		)
+	`
+
+	policy, err := scanCancellationPolicy(r.db.QueryRow(query, pq.Array(ruleIDs), roomTypeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.CancellationPolicy{}, false, nil
+		}
+		return model.CancellationPolicy{}, false, err
+	}
+
+	return policy, true, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// currencyRateColumns is the column list selected for every currency rate query
+const currencyRateColumns = `currency, rate, COALESCE(updated_by, ''), created_at, updated_at`
+
+// CurrencyRateRepository handles database operations for exchange rates
+type CurrencyRateRepository struct {
+	db *sql.DB
+}
+
+// NewCurrencyRateRepository creates a new CurrencyRateRepository
+func NewCurrencyRateRepository(db *sql.DB) *CurrencyRateRepository {
+	return &CurrencyRateRepository{db: db}
+}
+
+// scanCurrencyRate scans a row selected with currencyRateColumns into a currency rate
+func scanCurrencyRate(row rowScanner) (model.CurrencyRate, error) {
+	var rate model.CurrencyRate
+	err := row.Scan(
+		&rate.Currency,
+		&rate.Rate,
+		&rate.UpdatedBy,
+		&rate.CreatedAt,
+		&rate.UpdatedAt,
+	)
+	return rate, err
+}
+
+// upsertCurrencyRate sets a currency's rate using q, adding the currency if it is new
+func upsertCurrencyRate(q querier, rate model.CurrencyRate) (model.CurrencyRate, error) {
+	query := `
+		INSERT INTO currency_rates (currency, rate, updated_by, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $4)
+		ON CONFLICT (currency) DO UPDATE SET rate = EXCLUDED.rate, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at
+		RETURNING ` + currencyRateColumns
+
+	return scanCurrencyRate(q.QueryRow(query, rate.Currency, rate.Rate, rate.UpdatedBy, time.Now()))
+}
+
+// Upsert sets a currency's rate, adding the currency if it is new
+func (r *CurrencyRateRepository) Upsert(rate model.CurrencyRate) (model.CurrencyRate, error) {
+	return upsertCurrencyRate(r.db, rate)
+}
+
+// Import sets several rates in one transaction, so a file is either imported whole or not at all
+func (r *CurrencyRateRepository) Import(rates []model.CurrencyRate) ([]model.CurrencyRate, error) {
+	var imported []model.CurrencyRate
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		for _, rate := range rates {
+			saved, err := upsertCurrencyRate(tx, rate)
+			if err != nil {
+				return err
+			}
+			imported = append(imported, saved)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return imported, nil
+}
+
+// Get gets the rate of a currency
+func (r *CurrencyRateRepository) Get(currency string) (model.CurrencyRate, error) {
+	query := `
+		SELECT ` + currencyRateColumns + `
+		FROM currency_rates
+		WHERE currency = $1
+	`
+
+	rate, err := scanCurrencyRate(r.db.QueryRow(query, currency))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.CurrencyRate{}, errors.New("currency rate not found")
+		}
+		return model.CurrencyRate{}, err
+	}
+
+	return rate, nil
+}
+
+// List lists every currency rate in currency order
+func (r *CurrencyRateRepository) List() ([]model.CurrencyRate, error) {
+	query := `
+		SELECT ` + currencyRateColumns + `
+		FROM currency_rates
+		ORDER BY currency
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []model.CurrencyRate
+	for rows.Next() {
+		rate, err := scanCurrencyRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// Delete deletes a currency's rate
+func (r *CurrencyRateRepository) Delete(currency string) error {
+	query := `
+		DELETE FROM currency_rates
+		WHERE currency = $1
+	`
+
+	result, err := r.db.Exec(query, currency)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("currency rate not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,452 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// folioColumns is the column list selected for every folio query
+const folioColumns = `id, booking_id, COALESCE(user_id, ''), room_id, status, opened_at, closed_at, created_at, updated_at`
+
+// folioLineColumns is the column list selected for every folio line query
+const folioLineColumns = `id, folio_id, type, description, quantity, unit_price, amount, COALESCE(rate, 0), COALESCE(base, 0), COALESCE(source_id, ''), service_date,
+	COALESCE(method, ''), COALESCE(reference, ''), COALESCE(posted_by, ''), created_at`
+
+// FolioRepository handles database operations for guest folios
+type FolioRepository struct {
+	db *sql.DB
+}
+
+// NewFolioRepository creates a new FolioRepository
+func NewFolioRepository(db *sql.DB) *FolioRepository {
+	return &FolioRepository{db: db}
+}
+
+// scanFolio scans a row selected with folioColumns into a folio, without its lines
+func scanFolio(row rowScanner) (model.Folio, error) {
+	var folio model.Folio
+	err := row.Scan(
+		&folio.ID,
+		&folio.BookingID,
+		&folio.UserID,
+		&folio.RoomID,
+		&folio.Status,
+		&folio.OpenedAt,
+		&folio.ClosedAt,
+		&folio.CreatedAt,
+		&folio.UpdatedAt,
+	)
+	return folio, err
+}
+
+// scanFolioLine scans a row selected with folioLineColumns into a folio line
+func scanFolioLine(row rowScanner) (model.FolioLine, error) {
+	var line model.FolioLine
+	err := row.Scan(
+		&line.ID,
+		&line.FolioID,
+		&line.Type,
+		&line.Description,
+		&line.Quantity,
+		&line.UnitPrice,
+		&line.Amount,
+		&line.Rate,
+		&line.Base,
+		&line.SourceID,
+		&line.ServiceDate,
+		&line.Method,
+		&line.Reference,
+		&line.PostedBy,
+		&line.CreatedAt,
+	)
+	return line, err
+}
+
+// insertFolioLine posts a line to a folio using q
+func insertFolioLine(q querier, line model.FolioLine) (model.FolioLine, error) {
+	query := `
+		INSERT INTO folio_lines (id, folio_id, type, description, quantity, unit_price, amount, rate, base, source_id, service_date, method, reference, posted_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8::numeric, 0), NULLIF($9::numeric, 0), NULLIF($10, ''), $11, NULLIF($12, ''), NULLIF($13, ''), NULLIF($14, ''), $15)
+		RETURNING ` + folioLineColumns
+
+	// Generate UUID if not provided
+	if line.ID == "" {
+		line.ID = uuid.New().String()
+	}
+
+	// Set timestamp
+	line.CreatedAt = time.Now()
+
+	if line.Quantity == 0 {
+		line.Quantity = 1
+	}
+
+	return scanFolioLine(q.QueryRow(
+		query,
+		line.ID,
+		line.FolioID,
+		line.Type,
+		line.Description,
+		line.Quantity,
+		line.UnitPrice,
+		line.Amount,
+		line.Rate,
+		line.Base,
+		line.SourceID,
+		line.ServiceDate,
+		line.Method,
+		line.Reference,
+		line.PostedBy,
+		line.CreatedAt,
+	))
+}
+
+// getFolioLines gets the lines of a folio in the order they were posted
+func getFolioLines(q querier, folioID string) ([]model.FolioLine, error) {
+	rows, err := q.Query(`
+		SELECT `+folioLineColumns+`
+		FROM folio_lines
+		WHERE folio_id = $1
+		ORDER BY service_date ASC NULLS LAST, created_at ASC
+	`, folioID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []model.FolioLine
+	for rows.Next() {
+		line, err := scanFolioLine(rows)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// loadFolio fills in a folio's lines and totals
+func loadFolio(q querier, folio model.Folio) (model.Folio, error) {
+	lines, err := getFolioLines(q, folio.ID)
+	if err != nil {
+		return model.Folio{}, err
+	}
+
+	folio.Lines = lines
+	folio.Total()
+	return folio, nil
+}
+
+// openFolio opens the folio of a booking being checked in and posts its room nights,
+// one line per night as priced when the booking was made, and the taxes levied on them
+func openFolio(q querier, booking model.Booking, now time.Time) error {
+	folioID := uuid.New().String()
+	_, err := q.Exec(`
+		INSERT INTO folios (id, booking_id, user_id, room_id, status, opened_at, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, 'open', $5, $5, $5)
+	`, folioID, booking.ID, booking.UserID, booking.RoomID, now)
+	if err != nil {
+		return err
+	}
+
+	startDate := booking.StartDate
+	nights := booking.PriceBreakdown.Nights
+	if len(nights) == 0 {
+		// Bookings made before per-night pricing carry only a total
+		_, err := insertFolioLine(q, model.FolioLine{
+			FolioID:     folioID,
+			Type:        model.LineRoom,
+			Description: "Room charge",
+			Quantity:    1,
+			UnitPrice:   booking.TotalPrice,
+			Amount:      booking.TotalPrice,
+			SourceID:    booking.ID,
+			ServiceDate: &startDate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, night := range nights {
+		date := night.Date
+		_, err := insertFolioLine(q, model.FolioLine{
+			FolioID:     folioID,
+			Type:        model.LineRoom,
+			Description: fmt.Sprintf("Room night %s", date.Format("2006-01-02")),
+			Quantity:    1,
+			UnitPrice:   night.Price,
+			Amount:      night.Price,
+			SourceID:    booking.ID,
+			ServiceDate: &date,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tax := range booking.PriceBreakdown.Taxes.Lines {
+		_, err := insertFolioLine(q, model.FolioLine{
+			FolioID:     folioID,
+			Type:        model.LineTax,
+			Description: tax.Name,
+			Quantity:    1,
+			UnitPrice:   tax.Amount,
+			Amount:      tax.Amount,
+			Rate:        tax.Rate,
+			Base:        tax.Base,
+			SourceID:    booking.ID,
+			ServiceDate: &startDate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// What was paid before arrival, such as a deposit, is credited to the stay
+	if booking.AmountPaid.IsPositive() {
+		today := now
+		_, err := insertFolioLine(q, model.FolioLine{
+			FolioID:     folioID,
+			Type:        model.LinePayment,
+			Description: "Paid before arrival",
+			Quantity:    1,
+			UnitPrice:   booking.AmountPaid,
+			Amount:      booking.AmountPaid,
+			SourceID:    booking.ID,
+			ServiceDate: &today,
+			Method:      "card",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// postFoodOrders charges an open folio with room-service orders delivered to its room,
+// as reported by the food service, along with the taxes and service charges each order
+// was placed with. Orders already on a folio are never charged twice.
+func postFoodOrders(q querier, folioID string, orders []model.FoodOrder, now time.Time) error {
+	for _, order := range orders {
+		serviceDate := order.CreatedAt
+
+		// The unique index on food lines skips orders that are already on a folio
+		var lineID string
+		err := q.QueryRow(`
+			INSERT INTO folio_lines (id, folio_id, type, description, quantity, unit_price, amount, source_id, service_date, created_at)
+			VALUES ($1, $2, 'food', 'Room service order', 1, $3, $3, $4, $5, $6)
+			ON CONFLICT (source_id) WHERE type = 'food' DO NOTHING
+			RETURNING id
+		`, uuid.New().String(), folioID, order.TotalPrice, order.ID, serviceDate, now).Scan(&lineID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return err
+		}
+
+		for _, tax := range order.TaxBreakdown.Lines {
+			_, err := insertFolioLine(q, model.FolioLine{
+				FolioID:     folioID,
+				Type:        model.LineTax,
+				Description: tax.Name,
+				Quantity:    1,
+				UnitPrice:   tax.Amount,
+				Amount:      tax.Amount,
+				Rate:        tax.Rate,
+				Base:        tax.Base,
+				SourceID:    order.ID,
+				ServiceDate: &serviceDate,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// closeFolio posts the last room-service orders of a booking's folio and closes it
+func closeFolio(q querier, bookingID string, orders []model.FoodOrder, now time.Time) error {
+	var folioID string
+	err := q.QueryRow(`
+		SELECT id
+		FROM folios
+		WHERE booking_id = $1
+		AND status = 'open'
+		FOR UPDATE
+	`, bookingID).Scan(&folioID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Stays checked in before folios existed have nothing to close
+			return nil
+		}
+		return err
+	}
+
+	if err := postFoodOrders(q, folioID, orders, now); err != nil {
+		return err
+	}
+
+	_, err = q.Exec(`
+		UPDATE folios
+		SET status = 'closed', closed_at = $1, updated_at = $1
+		WHERE id = $2
+	`, now, folioID)
+	return err
+}
+
+// GetByID gets a folio with its lines by ID
+func (r *FolioRepository) GetByID(id string) (model.Folio, error) {
+	query := `
+		SELECT ` + folioColumns + `
+		FROM folios
+		WHERE id = $1
+	`
+
+	folio, err := scanFolio(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Folio{}, errors.New("folio not found")
+		}
+		return model.Folio{}, err
+	}
+
+	return loadFolio(r.db, folio)
+}
+
+// GetByBookingID gets the folio of a booking with its lines
+func (r *FolioRepository) GetByBookingID(bookingID string) (model.Folio, error) {
+	query := `
+		SELECT ` + folioColumns + `
+		FROM folios
+		WHERE booking_id = $1
+	`
+
+	folio, err := scanFolio(r.db.QueryRow(query, bookingID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Folio{}, errors.New("folio not found")
+		}
+		return model.Folio{}, err
+	}
+
+	return loadFolio(r.db, folio)
+}
+
+// List lists folios with their lines, most recently opened first, optionally by status
+func (r *FolioRepository) List(status string, limit, offset int) ([]model.Folio, error) {
+	query := `
+		SELECT ` + folioColumns + `
+		FROM folios
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY opened_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var folios []model.Folio
+	for rows.Next() {
+		folio, err := scanFolio(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		folios = append(folios, folio)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range folios {
+		folios[i], err = loadFolio(r.db, folios[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return folios, nil
+}
+
+// AddLine posts a line to an open folio, locking the folio so nothing is posted to it
+// while it is being closed
+func (r *FolioRepository) AddLine(line model.FolioLine) (model.FolioLine, error) {
+	var posted model.FolioLine
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		var status string
+		err := tx.QueryRow(`SELECT status FROM folios WHERE id = $1 FOR UPDATE`, line.FolioID).Scan(&status)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("folio not found")
+			}
+			return err
+		}
+
+		if status != "open" {
+			return errors.New("folio is closed")
+		}
+
+		posted, err = insertFolioLine(tx, line)
+		return err
+	})
+
+	if err != nil {
+		return model.FolioLine{}, err
+	}
+
+	return posted, nil
+}
+
+// SyncFoodOrders posts room-service orders delivered to a folio's room to the folio,
+// locking it so that nothing is posted to it while it is being closed
+func (r *FolioRepository) SyncFoodOrders(folioID string, orders []model.FoodOrder) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		var status string
+		err := tx.QueryRow(`SELECT status FROM folios WHERE id = $1 FOR UPDATE`, folioID).Scan(&status)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("folio not found")
+			}
+			return err
+		}
+
+		if status != "open" {
+			return errors.New("folio is closed")
+		}
+
+		return postFoodOrders(tx, folioID, orders, time.Now())
+	})
+}
+
+// HasFoodOrder reports whether a food order has been charged to a folio
+func (r *FolioRepository) HasFoodOrder(orderID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM folio_lines
+			WHERE type = 'food'
+			AND source_id = $1
+		)
+	`, orderID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// testFoodOrders returns two room-service orders delivered to room-1
+func testFoodOrders() []model.FoodOrder {
+	placed := time.Date(2026, 3, 9, 19, 30, 0, 0, time.UTC)
+	return []model.FoodOrder{
+		{ID: "order-1", RoomID: "room-1", Status: "delivered", TotalPrice: money.New(1250, money.DefaultCurrency), PaymentStatus: "unpaid", CreatedAt: placed},
+		{ID: "order-2", RoomID: "room-1", Status: "delivered", TotalPrice: money.New(800, money.DefaultCurrency), PaymentStatus: "unpaid", CreatedAt: placed.Add(time.Hour)},
+	}
+}
+
+// expectOpenFolio expects the booking's open folio to be locked
+func expectOpenFolio(mock sqlmock.Sqlmock, bookingID, folioID string) {
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM folios`)).
+		WithArgs(bookingID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(folioID))
+}
+
+func TestCloseFolioSkipsOrdersAlreadyCharged(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	orders := testFoodOrders()
+
+	expectOpenFolio(mock, "booking-1", "folio-1")
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (source_id) WHERE type = 'food' DO NOTHING`)).
+		WithArgs(sqlmock.AnyArg(), "folio-1", sqlmock.AnyArg(), "order-1", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("line-1"))
+	// order-2 was charged while the stay was open, so the insert returns nothing
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (source_id) WHERE type = 'food' DO NOTHING`)).
+		WithArgs(sqlmock.AnyArg(), "folio-1", sqlmock.AnyArg(), "order-2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'closed'`)).
+		WithArgs(sqlmock.AnyArg(), "folio-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := closeFolio(db, "booking-1", orders, time.Now()); err != nil {
+		t.Errorf("closing a folio with an order already charged failed: %v", err)
+	}
+}
+
+func TestCheckOutRollsBackWhenARoomChargeFails(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	booking := testBooking()
+	completed := booking
+	completed.Status = "completed"
+
+	// The folio is left open, and the booking checked in, when an order cannot be charged
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'completed'`)).
+		WillReturnRows(bookingRows(completed))
+	expectOpenFolio(mock, booking.ID, "folio-1")
+	mock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (source_id) WHERE type = 'food' DO NOTHING`)).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if _, err := NewBookingRepository(db).CheckOut(booking.ID, testFoodOrders()); err == nil {
+		t.Error("checking out succeeded although a room-service order could not be charged")
+	}
+}
+
+func TestSyncFoodOrdersRefusesClosedFolio(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT status FROM folios WHERE id = $1 FOR UPDATE`)).
+		WithArgs("folio-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("closed"))
+	mock.ExpectRollback()
+
+	if err := NewFolioRepository(db).SyncFoodOrders("folio-1", testFoodOrders()); err == nil {
+		t.Error("charging room-service orders to a closed folio succeeded")
+	}
+}
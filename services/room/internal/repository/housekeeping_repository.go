@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// housekeepingColumns is the column list selected for every housekeeping task query
+const housekeepingColumns = `id, room_id, COALESCE(booking_id::text, ''), type, COALESCE(assigned_to, ''), priority, status, COALESCE(notes, ''),
+	due_date, inspection_passed, COALESCE(completed_by, ''), completed_at, created_at, updated_at`
+
+// taskPriorityOrder sorts housekeeping tasks from most to least urgent
+const taskPriorityOrder = `CASE priority WHEN 'high' THEN 0 WHEN 'normal' THEN 1 ELSE 2 END`
+
+// HousekeepingRepository handles database operations for housekeeping tasks
+type HousekeepingRepository struct {
+	db *sql.DB
+}
+
+// NewHousekeepingRepository creates a new HousekeepingRepository
+func NewHousekeepingRepository(db *sql.DB) *HousekeepingRepository {
+	return &HousekeepingRepository{db: db}
+}
+
+// scanHousekeepingTask scans a row selected with housekeepingColumns into a task
+func scanHousekeepingTask(row rowScanner) (model.HousekeepingTask, error) {
+	var task model.HousekeepingTask
+	err := row.Scan(
+		&task.ID,
+		&task.RoomID,
+		&task.BookingID,
+		&task.Type,
+		&task.AssignedTo,
+		&task.Priority,
+		&task.Status,
+		&task.Notes,
+		&task.DueDate,
+		&task.InspectionPassed,
+		&task.CompletedBy,
+		&task.CompletedAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	return task, err
+}
+
+// scanHousekeepingTasks scans all rows selected with housekeepingColumns
+func scanHousekeepingTasks(rows *sql.Rows) ([]model.HousekeepingTask, error) {
+	defer rows.Close()
+
+	var tasks []model.HousekeepingTask
+	for rows.Next() {
+		task, err := scanHousekeepingTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// insertHousekeepingTask inserts a housekeeping task using q
+func insertHousekeepingTask(q querier, task model.HousekeepingTask) (model.HousekeepingTask, error) {
+	query := `
+		INSERT INTO housekeeping_tasks (id, room_id, booking_id, type, assigned_to, priority, status, notes, due_date, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11)
+		RETURNING ` + housekeepingColumns
+
+	// Generate UUID if not provided
+	if task.ID == "" {
+		task.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	task.CreatedAt = now
+	task.UpdatedAt = now
+
+	// Set defaults if not provided
+	if task.Status == "" {
+		task.Status = "pending"
+	}
+	if task.Priority == "" {
+		task.Priority = model.PriorityNormal
+	}
+	if task.DueDate.IsZero() {
+		task.DueDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return scanHousekeepingTask(q.QueryRow(
+		query,
+		task.ID,
+		task.RoomID,
+		task.BookingID,
+		task.Type,
+		task.AssignedTo,
+		task.Priority,
+		task.Status,
+		task.Notes,
+		task.DueDate,
+		task.CreatedAt,
+		task.UpdatedAt,
+	))
+}
+
+// Create creates a new housekeeping task
+func (r *HousekeepingRepository) Create(task model.HousekeepingTask) (model.HousekeepingTask, error) {
+	return insertHousekeepingTask(r.db, task)
+}
+
+// GetByID gets a housekeeping task by ID
+func (r *HousekeepingRepository) GetByID(id string) (model.HousekeepingTask, error) {
+	query := `
+		SELECT ` + housekeepingColumns + `
+		FROM housekeeping_tasks
+		WHERE id = $1
+	`
+
+	task, err := scanHousekeepingTask(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.HousekeepingTask{}, errors.New("housekeeping task not found")
+		}
+		return model.HousekeepingTask{}, err
+	}
+
+	return task, nil
+}
+
+// List lists housekeeping tasks, most urgent first, optionally filtered by status and room
+func (r *HousekeepingRepository) List(status, roomID string, limit, offset int) ([]model.HousekeepingTask, error) {
+	query := `
+		SELECT ` + housekeepingColumns + `
+		FROM housekeeping_tasks
+		WHERE ($1 = '' OR status = $1)
+		AND ($2 = '' OR room_id::text = $2)
+		ORDER BY due_date DESC, ` + taskPriorityOrder + `, created_at ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.Query(query, status, roomID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanHousekeepingTasks(rows)
+}
+
+// ListByAssignee lists the pending tasks assigned to a staff member, most urgent first
+func (r *HousekeepingRepository) ListByAssignee(userID string, limit, offset int) ([]model.HousekeepingTask, error) {
+	query := `
+		SELECT ` + housekeepingColumns + `
+		FROM housekeeping_tasks
+		WHERE assigned_to = $1
+		AND status = 'pending'
+		ORDER BY due_date ASC, ` + taskPriorityOrder + `, created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanHousekeepingTasks(rows)
+}
+
+// Update updates a pending task's assignee, priority and notes
+func (r *HousekeepingRepository) Update(task model.HousekeepingTask) (model.HousekeepingTask, error) {
+	query := `
+		UPDATE housekeeping_tasks
+		SET assigned_to = NULLIF($1, ''), priority = $2, notes = $3, updated_at = $4
+		WHERE id = $5
+		AND status = 'pending'
+		RETURNING ` + housekeepingColumns
+
+	updated, err := scanHousekeepingTask(r.db.QueryRow(
+		query,
+		task.AssignedTo,
+		task.Priority,
+		task.Notes,
+		time.Now(),
+		task.ID,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.HousekeepingTask{}, errors.New("housekeeping task is not pending")
+		}
+		return model.HousekeepingTask{}, err
+	}
+
+	return updated, nil
+}
+
+// Cancel cancels a pending task
+func (r *HousekeepingRepository) Cancel(id string) error {
+	query := `
+		UPDATE housekeeping_tasks
+		SET status = 'cancelled', updated_at = $1
+		WHERE id = $2
+		AND status = 'pending'
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("housekeeping task is not pending")
+	}
+
+	return nil
+}
+
+// Complete marks a pending task done and moves the room along in one transaction. A
+// clean of a room in cleaning is followed by an inspection. A passed inspection makes the
+// room available once no other cleaning work is open on it; a failed one sends it back
+// for cleaning.
+func (r *HousekeepingRepository) Complete(id, completedBy string, completion model.TaskCompletion) (model.HousekeepingTask, error) {
+	var completed model.HousekeepingTask
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var err error
+		completed, err = scanHousekeepingTask(tx.QueryRow(`
+			UPDATE housekeeping_tasks
+			SET status = 'done', notes = CASE WHEN $1 = '' THEN notes ELSE $1 END, inspection_passed = $2,
+				completed_by = $3, completed_at = $4, updated_at = $4
+			WHERE id = $5
+			AND status = 'pending'
+			RETURNING `+housekeepingColumns,
+			completion.Notes, completion.Passed, completedBy, now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("housekeeping task is not pending")
+			}
+			return err
+		}
+
+		var roomStatus string
+		if err := tx.QueryRow(`SELECT status FROM rooms WHERE id = $1 FOR UPDATE`, completed.RoomID).Scan(&roomStatus); err != nil {
+			return err
+		}
+
+		if roomStatus != "cleaning" {
+			return nil
+		}
+
+		switch completed.Type {
+		case model.TaskClean:
+			_, err = insertHousekeepingTask(tx, model.HousekeepingTask{
+				RoomID:    completed.RoomID,
+				BookingID: completed.BookingID,
+				Type:      model.TaskInspect,
+				Priority:  model.PriorityHigh,
+			})
+			return err
+		case model.TaskInspect:
+			if completion.Passed != nil && !*completion.Passed {
+				_, err = insertHousekeepingTask(tx, model.HousekeepingTask{
+					RoomID:    completed.RoomID,
+					BookingID: completed.BookingID,
+					Type:      model.TaskClean,
+					Priority:  model.PriorityHigh,
+					Notes:     "Failed inspection: " + completion.Notes,
+				})
+				return err
+			}
+
+			_, err = tx.Exec(`
+				UPDATE rooms
+				SET status = 'available', updated_at = $1
+				WHERE id = $2
+				AND NOT EXISTS (
+					SELECT 1
+					FROM housekeeping_tasks
+					WHERE room_id = $2
+					AND type IN ('clean', 'inspect')
+					AND status = 'pending'
+				)
+			`, now, completed.RoomID)
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	return completed, nil
+}
+
+// HasOpenCleaning reports whether a room has clean or inspect work still pending
+func (r *HousekeepingRepository) HasOpenCleaning(roomID string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM housekeeping_tasks
+			WHERE room_id = $1
+			AND type IN ('clean', 'inspect')
+			AND status = 'pending'
+		)
+	`
+
+	var exists bool
+	err := r.db.QueryRow(query, roomID).Scan(&exists)
+	return exists, err
+}
+
+// GenerateDaily creates, in a single query, the day's tasks for every occupied room: a
+// turndown, and a stayover clean for guests who arrived before today. Tasks that already
+// exist for the day are not created again, so it is safe to run more than once a day.
+func (r *HousekeepingRepository) GenerateDaily(today time.Time) (int64, error) {
+	query := `
+		INSERT INTO housekeeping_tasks (id, room_id, booking_id, type, priority, status, due_date, created_at, updated_at)
+		SELECT gen_random_uuid(), r.id, b.id, t.type, t.priority, 'pending', $1, $2, $2
+		FROM rooms r
+		JOIN bookings b ON b.room_id = r.id AND b.status = 'checked_in'
+		CROSS JOIN (VALUES ('clean', 'normal'), ('turndown', 'low')) AS t(type, priority)
+		WHERE r.status = 'occupied'
+		AND (t.type = 'turndown' OR b.start_date < $1)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM housekeeping_tasks h
+			WHERE h.room_id = r.id
+			AND h.type = t.type
+			AND h.due_date = $1
+		)
+	`
+
+	result, err := r.db.Exec(query, today, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
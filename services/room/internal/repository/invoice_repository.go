@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// invoiceColumns is the column list selected for every invoice query
+const invoiceColumns = `id, number, property, sequence, source_type, source_id, COALESCE(user_id, ''), COALESCE(room_id::text, ''), COALESCE(room_number, ''),
+	start_date, end_date, lines, taxes, subtotal, tax_total, total, amount_paid, balance_due, issued_at, created_at`
+
+// errInvoiceExists rolls back an issue when the source has been invoiced already
+var errInvoiceExists = errors.New("invoice already issued")
+
+// InvoiceRepository handles database operations for invoices
+type InvoiceRepository struct {
+	db *sql.DB
+}
+
+// NewInvoiceRepository creates a new InvoiceRepository
+func NewInvoiceRepository(db *sql.DB) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// scanInvoice scans a row selected with invoiceColumns into an invoice
+func scanInvoice(row rowScanner) (model.Invoice, error) {
+	var invoice model.Invoice
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&invoice.Property,
+		&invoice.Sequence,
+		&invoice.SourceType,
+		&invoice.SourceID,
+		&invoice.UserID,
+		&invoice.RoomID,
+		&invoice.RoomNumber,
+		&invoice.StartDate,
+		&invoice.EndDate,
+		&invoice.Lines,
+		&invoice.Taxes,
+		&invoice.Subtotal,
+		&invoice.TaxTotal,
+		&invoice.Total,
+		&invoice.AmountPaid,
+		&invoice.BalanceDue,
+		&invoice.IssuedAt,
+		&invoice.CreatedAt,
+	)
+	return invoice, err
+}
+
+// scanInvoices scans all rows selected with invoiceColumns
+func scanInvoices(rows *sql.Rows) ([]model.Invoice, error) {
+	defer rows.Close()
+
+	var invoices []model.Invoice
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+// Issue numbers and stores an invoice in one transaction. The property's counter is
+// advanced under a row lock that is held until commit, so concurrent issues are
+// serialised and a failed issue rolls its number back, leaving no gaps. Issuing a source
+// that has been invoiced already returns the existing invoice.
+func (r *InvoiceRepository) Issue(invoice model.Invoice) (model.Invoice, error) {
+	query := `
+		INSERT INTO invoices (id, number, property, sequence, source_type, source_id, user_id, room_id, room_number, start_date, end_date,
+			lines, taxes, subtotal, tax_total, total, amount_paid, balance_due, issued_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, '')::uuid, NULLIF($9, ''), $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING ` + invoiceColumns
+
+	// Generate UUID if not provided
+	if invoice.ID == "" {
+		invoice.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	invoice.IssuedAt = now
+	invoice.CreatedAt = now
+
+	var issued model.Invoice
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`
+			INSERT INTO invoice_sequences (property, last_number)
+			VALUES ($1, 1)
+			ON CONFLICT (property) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+			RETURNING last_number
+		`, invoice.Property).Scan(&invoice.Sequence)
+		if err != nil {
+			return err
+		}
+
+		// Checked under the counter's lock so two issues of one source cannot both pass
+		var exists bool
+		err = tx.QueryRow(`
+			SELECT EXISTS (
+				SELECT 1
+				FROM invoices
+				WHERE source_type = $1
+				AND source_id = $2
+			)
+		`, invoice.SourceType, invoice.SourceID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errInvoiceExists
+		}
+
+		invoice.Number = fmt.Sprintf("%s-%06d", invoice.Property, invoice.Sequence)
+
+		issued, err = scanInvoice(tx.QueryRow(
+			query,
+			invoice.ID,
+			invoice.Number,
+			invoice.Property,
+			invoice.Sequence,
+			invoice.SourceType,
+			invoice.SourceID,
+			invoice.UserID,
+			invoice.RoomID,
+			invoice.RoomNumber,
+			invoice.StartDate,
+			invoice.EndDate,
+			invoice.Lines,
+			invoice.Taxes,
+			invoice.Subtotal,
+			invoice.TaxTotal,
+			invoice.Total,
+			invoice.AmountPaid,
+			invoice.BalanceDue,
+			invoice.IssuedAt,
+			invoice.CreatedAt,
+		))
+		return err
+	})
+
+	if errors.Is(err, errInvoiceExists) {
+		return r.GetBySource(invoice.SourceType, invoice.SourceID)
+	}
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	return issued, nil
+}
+
+// GetByID gets an invoice by ID
+func (r *InvoiceRepository) GetByID(id string) (model.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE id = $1
+	`
+
+	invoice, err := scanInvoice(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Invoice{}, errors.New("invoice not found")
+		}
+		return model.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+// GetBySource gets the invoice issued for a booking or food order
+func (r *InvoiceRepository) GetBySource(sourceType, sourceID string) (model.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE source_type = $1
+		AND source_id = $2
+	`
+
+	invoice, err := scanInvoice(r.db.QueryRow(query, sourceType, sourceID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Invoice{}, errors.New("invoice not found")
+		}
+		return model.Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+// ListByUserID lists the invoices of a guest, newest first
+func (r *InvoiceRepository) ListByUserID(userID string, limit, offset int) ([]model.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE user_id = $1
+		ORDER BY issued_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanInvoices(rows)
+}
+
+// List lists invoices in number order, newest first
+func (r *InvoiceRepository) List(limit, offset int) ([]model.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		ORDER BY property, sequence DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanInvoices(rows)
+}
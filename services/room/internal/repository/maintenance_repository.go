@@ -0,0 +1,434 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// maintenanceColumns is the column list selected for every maintenance window query
+const maintenanceColumns = `id, room_id, start_date, end_date, reason, COALESCE(assigned_to, ''), status, created_by, created_at, updated_at`
+
+// blockingMaintenanceStatuses lists the maintenance window statuses that take a room out
+// of service for their date range
+const blockingMaintenanceStatuses = `('scheduled', 'active')`
+
+// inServiceRoom is a condition on rooms r that fails only for rooms put under maintenance
+// by hand. Rooms under maintenance because of a window are in service outside the window.
+const inServiceRoom = `(r.status <> 'maintenance' OR EXISTS (
+	SELECT 1
+	FROM maintenance_windows mw
+	WHERE mw.room_id = r.id
+	AND mw.status = 'active'
+))`
+
+// MaintenanceRepository handles database operations for maintenance windows
+type MaintenanceRepository struct {
+	db *sql.DB
+}
+
+// NewMaintenanceRepository creates a new MaintenanceRepository
+func NewMaintenanceRepository(db *sql.DB) *MaintenanceRepository {
+	return &MaintenanceRepository{db: db}
+}
+
+// scanMaintenanceWindow scans a row selected with maintenanceColumns into a maintenance window
+func scanMaintenanceWindow(row rowScanner) (model.MaintenanceWindow, error) {
+	var window model.MaintenanceWindow
+	err := row.Scan(
+		&window.ID,
+		&window.RoomID,
+		&window.StartDate,
+		&window.EndDate,
+		&window.Reason,
+		&window.AssignedTo,
+		&window.Status,
+		&window.CreatedBy,
+		&window.CreatedAt,
+		&window.UpdatedAt,
+	)
+	return window, err
+}
+
+// scanMaintenanceWindows scans all rows selected with maintenanceColumns
+func scanMaintenanceWindows(rows *sql.Rows) ([]model.MaintenanceWindow, error) {
+	defer rows.Close()
+
+	var windows []model.MaintenanceWindow
+	for rows.Next() {
+		window, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+// restoreRoom puts a room back in service if it is under maintenance and no window keeps it
+// there. A room taken for maintenance while it was being cleaned goes back to cleaning
+// until its clean and inspection are done.
+func restoreRoom(q querier, roomID string, now time.Time) error {
+	_, err := q.Exec(`
+		UPDATE rooms
+		SET status = CASE
+				WHEN EXISTS (
+					SELECT 1
+					FROM housekeeping_tasks
+					WHERE room_id = $2
+					AND type IN ('clean', 'inspect')
+					AND status = 'pending'
+				) THEN 'cleaning'
+				ELSE 'available'
+			END,
+			updated_at = $1
+		WHERE id = $2
+		AND status = 'maintenance'
+		AND NOT EXISTS (
+			SELECT 1
+			FROM maintenance_windows
+			WHERE room_id = $2
+			AND status = 'active'
+		)
+	`, now, roomID)
+	return err
+}
+
+// Create schedules a maintenance window in a single transaction, locking the room and
+// checking it first. It returns model.ErrBookingConflict if the room has a booking or
+// another maintenance window during the window.
+func (r *MaintenanceRepository) Create(window model.MaintenanceWindow) (model.MaintenanceWindow, error) {
+	query := `
+		INSERT INTO maintenance_windows (id, room_id, start_date, end_date, reason, assigned_to, status, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8, $9, $10)
+		RETURNING ` + maintenanceColumns
+
+	// Generate UUID if not provided
+	if window.ID == "" {
+		window.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	window.CreatedAt = now
+	window.UpdatedAt = now
+
+	// Set default status if not provided
+	if window.Status == "" {
+		window.Status = "scheduled"
+	}
+
+	var created model.MaintenanceWindow
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockRoom(tx, window.RoomID); err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, window.RoomID, window.StartDate, window.EndDate, "")
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return model.ErrBookingConflict
+		}
+
+		created, err = scanMaintenanceWindow(tx.QueryRow(
+			query,
+			window.ID,
+			window.RoomID,
+			window.StartDate,
+			window.EndDate,
+			window.Reason,
+			window.AssignedTo,
+			window.Status,
+			window.CreatedBy,
+			window.CreatedAt,
+			window.UpdatedAt,
+		))
+		return err
+	})
+
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	return created, nil
+}
+
+// GetByID gets a maintenance window by ID
+func (r *MaintenanceRepository) GetByID(id string) (model.MaintenanceWindow, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM maintenance_windows
+		WHERE id = $1
+	`
+
+	window, err := scanMaintenanceWindow(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.MaintenanceWindow{}, errors.New("maintenance window not found")
+		}
+		return model.MaintenanceWindow{}, err
+	}
+
+	return window, nil
+}
+
+// List lists maintenance windows, most recent first, optionally for a single room
+func (r *MaintenanceRepository) List(roomID string, limit, offset int) ([]model.MaintenanceWindow, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM maintenance_windows
+		WHERE ($1 = '' OR room_id::text = $1)
+		ORDER BY start_date DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, roomID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMaintenanceWindows(rows)
+}
+
+// ListByAssignee lists the scheduled and active maintenance windows assigned to a staff member
+func (r *MaintenanceRepository) ListByAssignee(userID string, limit, offset int) ([]model.MaintenanceWindow, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM maintenance_windows
+		WHERE assigned_to = $1
+		AND status IN ` + blockingMaintenanceStatuses + `
+		ORDER BY start_date ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMaintenanceWindows(rows)
+}
+
+// ListBlocking lists the scheduled and active maintenance windows that take a room out of
+// service on or after the given date, in date order
+func (r *MaintenanceRepository) ListBlocking(roomID string, from time.Time) ([]model.MaintenanceWindow, error) {
+	query := `
+		SELECT ` + maintenanceColumns + `
+		FROM maintenance_windows
+		WHERE room_id = $1
+		AND status IN ` + blockingMaintenanceStatuses + `
+		AND end_date > $2
+		ORDER BY start_date
+	`
+
+	rows, err := r.db.Query(query, roomID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMaintenanceWindows(rows)
+}
+
+// Update saves a scheduled or active maintenance window in a single transaction, checking
+// that its new dates do not clash with a booking or another window
+func (r *MaintenanceRepository) Update(window model.MaintenanceWindow) (model.MaintenanceWindow, error) {
+	query := `
+		UPDATE maintenance_windows
+		SET start_date = $1, end_date = $2, reason = $3, assigned_to = NULLIF($4, ''), updated_at = $5
+		WHERE id = $6
+		AND status IN ` + blockingMaintenanceStatuses + `
+		RETURNING ` + maintenanceColumns
+
+	window.UpdatedAt = time.Now()
+
+	var updated model.MaintenanceWindow
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockRoom(tx, window.RoomID); err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, window.RoomID, window.StartDate, window.EndDate, window.ID)
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return model.ErrBookingConflict
+		}
+
+		updated, err = scanMaintenanceWindow(tx.QueryRow(
+			query,
+			window.StartDate,
+			window.EndDate,
+			window.Reason,
+			window.AssignedTo,
+			window.UpdatedAt,
+			window.ID,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("maintenance window has already ended")
+		}
+		return err
+	})
+
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	return updated, nil
+}
+
+// Cancel cancels a maintenance window that has not started yet
+func (r *MaintenanceRepository) Cancel(id string) error {
+	query := `
+		UPDATE maintenance_windows
+		SET status = 'cancelled', updated_at = $1
+		WHERE id = $2
+		AND status = 'scheduled'
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("maintenance window is not scheduled")
+	}
+
+	return nil
+}
+
+// Complete ends an active maintenance window early, cutting it short at today, and puts
+// the room back in service in one transaction
+func (r *MaintenanceRepository) Complete(id string, today time.Time) (model.MaintenanceWindow, error) {
+	var completed model.MaintenanceWindow
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var err error
+		completed, err = scanMaintenanceWindow(tx.QueryRow(`
+			UPDATE maintenance_windows
+			SET status = 'completed', end_date = LEAST(end_date, GREATEST($1::date, start_date + 1)), updated_at = $2
+			WHERE id = $3
+			AND status = 'active'
+			RETURNING `+maintenanceColumns,
+			today, now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("maintenance window is not active")
+			}
+			return err
+		}
+
+		return restoreRoom(tx, completed.RoomID, now)
+	})
+
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	return completed, nil
+}
+
+// StartDue activates the scheduled windows that begin on or before today and puts the
+// rooms of active windows under maintenance in one transaction. A room with a guest still
+// in it keeps its status until a run after the guest checks out.
+func (r *MaintenanceRepository) StartDue(today time.Time) ([]model.MaintenanceWindow, error) {
+	var started []model.MaintenanceWindow
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		rows, err := tx.Query(`
+			UPDATE maintenance_windows
+			SET status = 'active', updated_at = $1
+			WHERE status = 'scheduled'
+			AND start_date <= $2
+			RETURNING `+maintenanceColumns,
+			now, today,
+		)
+		if err != nil {
+			return err
+		}
+
+		started, err = scanMaintenanceWindows(rows)
+		if err != nil {
+			return err
+		}
+
+		// Also catches rooms whose guest has left since their window started
+		_, err = tx.Exec(`
+			UPDATE rooms r
+			SET status = 'maintenance', updated_at = $1
+			WHERE r.status NOT IN ('occupied', 'maintenance')
+			AND EXISTS (
+				SELECT 1
+				FROM maintenance_windows mw
+				WHERE mw.room_id = r.id
+				AND mw.status = 'active'
+			)
+		`, now)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return started, nil
+}
+
+// FinishDue completes the active windows that end on or before today and puts their rooms
+// back in service in one transaction
+func (r *MaintenanceRepository) FinishDue(today time.Time) ([]model.MaintenanceWindow, error) {
+	var finished []model.MaintenanceWindow
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		rows, err := tx.Query(`
+			UPDATE maintenance_windows
+			SET status = 'completed', updated_at = $1
+			WHERE status = 'active'
+			AND end_date <= $2
+			RETURNING `+maintenanceColumns,
+			now, today,
+		)
+		if err != nil {
+			return err
+		}
+
+		finished, err = scanMaintenanceWindows(rows)
+		if err != nil {
+			return err
+		}
+
+		for _, window := range finished {
+			if err := restoreRoom(tx, window.RoomID, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return finished, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// maintenanceColumnNames names the columns of maintenanceColumns, for mocked window rows
+var maintenanceColumnNames = []string{"id", "room_id", "start_date", "end_date", "reason", "assigned_to", "status", "created_by", "created_at", "updated_at"}
+
+func TestListBlockingListsScheduledAndActiveWindows(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`WHERE room_id = \$1\s+AND status IN \('scheduled', 'active'\)\s+AND end_date > \$2`).
+		WithArgs("room-1", from).
+		WillReturnRows(sqlmock.NewRows(maintenanceColumnNames).
+			AddRow("window-1", "room-1", from, from.AddDate(0, 0, 2), "Repainting", "", "scheduled", "user-1", from, from))
+
+	windows, err := NewMaintenanceRepository(db).ListBlocking("room-1", from)
+	if err != nil {
+		t.Fatalf("listing blocking maintenance windows failed: %v", err)
+	}
+	if len(windows) != 1 {
+		t.Errorf("listed %d blocking maintenance windows, want 1", len(windows))
+	}
+}
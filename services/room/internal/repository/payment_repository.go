@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// paymentColumns is the column list selected for every payment query, from booking_payments p joined to bookings b
+const paymentColumns = `p.id, p.booking_id, COALESCE(b.user_id, ''), p.gateway, COALESCE(p.transaction_id, ''), p.status, p.amount, p.captured_amount, p.refunded_amount,
+	COALESCE(p.failure_reason, ''), p.created_at, p.updated_at`
+
+// PaymentRepository handles database operations for booking payments
+type PaymentRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentRepository creates a new PaymentRepository
+func NewPaymentRepository(db *sql.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// scanPayment scans a row selected with paymentColumns into a payment
+func scanPayment(row rowScanner) (model.Payment, error) {
+	var payment model.Payment
+	err := row.Scan(
+		&payment.ID,
+		&payment.BookingID,
+		&payment.UserID,
+		&payment.Gateway,
+		&payment.TransactionID,
+		&payment.Status,
+		&payment.Amount,
+		&payment.CapturedAmount,
+		&payment.RefundedAmount,
+		&payment.FailureReason,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	return payment, err
+}
+
+// setBookingPaymentStatus sets a booking's payment status and amount paid from all of its
+// payments using q. The status is that of the payment that matters most: an authorization
+// still held, then a capture, and so on down to a failure, so that neither a captured
+// deposit nor a declined reauthorization hides an authorization that still stands. The
+// amount paid is what the payments have taken less what they have refunded.
+func setBookingPaymentStatus(q querier, bookingID string) error {
+	_, err := q.Exec(`
+		UPDATE bookings
+		SET payment_status = COALESCE((
+				SELECT status
+				FROM booking_payments
+				WHERE booking_id = $1
+				ORDER BY CASE status
+					WHEN 'authorized' THEN 1
+					WHEN 'captured' THEN 2
+					WHEN 'partially_refunded' THEN 3
+					WHEN 'refunded' THEN 4
+					WHEN 'voided' THEN 5
+					ELSE 6
+				END
+				LIMIT 1
+			), 'unpaid'),
+			amount_paid = (
+				SELECT COALESCE(SUM(captured_amount - refunded_amount), 0)
+				FROM booking_payments
+				WHERE booking_id = $1
+			)
+		WHERE id = $1
+	`, bookingID)
+	return err
+}
+
+// Create records a payment and sets its booking's payment status in one transaction
+func (r *PaymentRepository) Create(payment model.Payment) (model.Payment, error) {
+	query := `
+		INSERT INTO booking_payments (id, booking_id, gateway, transaction_id, status, amount, captured_amount, refunded_amount, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, NULLIF($9, ''), $10, $11)
+	`
+
+	// Generate UUID if not provided
+	if payment.ID == "" {
+		payment.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	payment.CreatedAt = now
+	payment.UpdatedAt = now
+
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		_, err := tx.Exec(
+			query,
+			payment.ID,
+			payment.BookingID,
+			payment.Gateway,
+			payment.TransactionID,
+			payment.Status,
+			payment.Amount,
+			payment.CapturedAmount,
+			payment.RefundedAmount,
+			payment.FailureReason,
+			payment.CreatedAt,
+			payment.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		return setBookingPaymentStatus(tx, payment.BookingID)
+	})
+
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return r.GetByID(payment.ID)
+}
+
+// updatePayment saves a payment's status and amounts after a gateway call and sets its
+// booking's payment status using q
+func updatePayment(q querier, payment model.Payment) error {
+	result, err := q.Exec(`
+		UPDATE booking_payments
+		SET status = $1, captured_amount = $2, refunded_amount = $3, updated_at = $4
+		WHERE id = $5
+	`,
+		payment.Status,
+		payment.CapturedAmount,
+		payment.RefundedAmount,
+		time.Now(),
+		payment.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("payment not found")
+	}
+
+	return setBookingPaymentStatus(q, payment.BookingID)
+}
+
+// Update saves a payment's status and amounts after a gateway call and sets its
+// booking's payment status in one transaction
+func (r *PaymentRepository) Update(payment model.Payment) (model.Payment, error) {
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		return updatePayment(tx, payment)
+	})
+
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return r.GetByID(payment.ID)
+}
+
+// UpdateAndPost saves a payment after a capture and posts the capture to a folio in one
+// transaction, so that a capture is either recorded on the folio or not recorded at all.
+// The line's source is the payment, and a payment is posted to a folio only once.
+func (r *PaymentRepository) UpdateAndPost(payment model.Payment, line model.FolioLine) (model.Payment, error) {
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := updatePayment(tx, payment); err != nil {
+			return err
+		}
+
+		line.SourceID = payment.ID
+		_, err := insertFolioLine(tx, line)
+		return err
+	})
+
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return r.GetByID(payment.ID)
+}
+
+// GetByID gets a payment by ID
+func (r *PaymentRepository) GetByID(id string) (model.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM booking_payments p
+		JOIN bookings b ON b.id = p.booking_id
+		WHERE p.id = $1
+	`
+
+	payment, err := scanPayment(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.Payment{}, errors.New("payment not found")
+		}
+		return model.Payment{}, err
+	}
+
+	return payment, nil
+}
+
+// ListByBookingID lists the payments of a booking, newest first
+func (r *PaymentRepository) ListByBookingID(bookingID string) ([]model.Payment, error) {
+	query := `
+		SELECT ` + paymentColumns + `
+		FROM booking_payments p
+		JOIN bookings b ON b.id = p.booking_id
+		WHERE p.booking_id = $1
+		ORDER BY p.created_at DESC
+	`
+
+	rows, err := r.db.Query(query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []model.Payment
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// testCapture returns a stay's authorization as captured at check-out, and its folio line
+func testCapture() (model.Payment, model.FolioLine) {
+	amount := money.New(48000, money.DefaultCurrency)
+	p := model.Payment{
+		ID:             "payment-1",
+		BookingID:      "booking-1",
+		TransactionID:  "fake_1",
+		Status:         payment.StatusCaptured,
+		Amount:         amount,
+		CapturedAmount: amount,
+		RefundedAmount: money.Zero(money.DefaultCurrency),
+	}
+
+	return p, model.FolioLine{
+		FolioID:     "folio-1",
+		Type:        model.LinePayment,
+		Description: "Payment by card",
+		UnitPrice:   amount,
+		Amount:      amount,
+		Method:      "card",
+		Reference:   p.TransactionID,
+	}
+}
+
+func TestUpdateAndPostRollsBackCaptureWhenLineCannotBePosted(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	p, line := testCapture()
+
+	// The capture is only recorded along with its folio line, so the payment stays authorized
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE booking_payments`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE bookings`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO folio_lines`)).
+		WillReturnError(errors.New("duplicate key value violates unique constraint \"idx_folio_lines_payment_source\""))
+	mock.ExpectRollback()
+
+	if _, err := NewPaymentRepository(db).UpdateAndPost(p, line); err == nil {
+		t.Error("recording a capture succeeded although its folio line could not be posted")
+	}
+}
+
+func TestUpdateDerivesBookingPaymentStatusFromAllItsPayments(t *testing.T) {
+	db, mock := newMockDB(t)
+
+	// A declined reauthorization must not hide the authorization the booking still holds,
+	// so the booking's status is worked out from its payments rather than copied from this one
+	p, _ := testCapture()
+	p.Status = payment.StatusFailed
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE booking_payments`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE bookings\s+SET payment_status = COALESCE\(\(\s+SELECT status\s+FROM booking_payments`).
+		WithArgs("booking-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM booking_payments`)).
+		WithArgs("payment-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "booking_id", "user_id", "gateway", "transaction_id", "status", "amount", "captured_amount", "refunded_amount",
+			"failure_reason", "created_at", "updated_at"}).
+			AddRow(p.ID, p.BookingID, "user-1", "fake", p.TransactionID, p.Status, "480.00", "480.00", "0.00", "declined", time.Now(), time.Now()))
+
+	if _, err := NewPaymentRepository(db).Update(p); err != nil {
+		t.Errorf("saving a declined payment failed: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// rateRuleColumns is the column list selected for every rate rule query
+const rateRuleColumns = `id, name, rule_type, COALESCE(room_type_id::text, ''), start_date, end_date, min_nights, min_occupancy, adjustment_type, adjustment, active, COALESCE(cancellation_policy_id::text, ''), created_at, updated_at`
+
+// RateRuleRepository handles database operations for rate rules
+type RateRuleRepository struct {
+	db *sql.DB
+}
+
+// NewRateRuleRepository creates a new RateRuleRepository
+func NewRateRuleRepository(db *sql.DB) *RateRuleRepository {
+	return &RateRuleRepository{db: db}
+}
+
+// scanRateRule scans a row selected with rateRuleColumns into a rate rule
+func scanRateRule(row rowScanner) (model.RateRule, error) {
+	var rule model.RateRule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.RuleType,
+		&rule.RoomTypeID,
+		&rule.StartDate,
+		&rule.EndDate,
+		&rule.MinNights,
+		&rule.MinOccupancy,
+		&rule.AdjustmentType,
+		&rule.Adjustment,
+		&rule.Active,
+		&rule.CancellationPolicyID,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	return rule, err
+}
+
+// scanRateRules scans all rows selected with rateRuleColumns
+func scanRateRules(rows *sql.Rows) ([]model.RateRule, error) {
+	defer rows.Close()
+
+	var rules []model.RateRule
+	for rows.Next() {
+		rule, err := scanRateRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Create creates a new rate rule
+func (r *RateRuleRepository) Create(rule model.RateRule) (model.RateRule, error) {
+	query := `
+		INSERT INTO rate_rules (id, name, rule_type, room_type_id, start_date, end_date, min_nights, min_occupancy, adjustment_type, adjustment, active,
+			cancellation_policy_id, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, '')::uuid, $13, $14)
+		RETURNING ` + rateRuleColumns
+
+	// Generate UUID if not provided
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	return scanRateRule(r.db.QueryRow(
+		query,
+		rule.ID,
+		rule.Name,
+		rule.RuleType,
+		rule.RoomTypeID,
+		rule.StartDate,
+		rule.EndDate,
+		rule.MinNights,
+		rule.MinOccupancy,
+		rule.AdjustmentType,
+		rule.Adjustment,
+		rule.Active,
+		rule.CancellationPolicyID,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	))
+}
+
+// GetByID gets a rate rule by ID
+func (r *RateRuleRepository) GetByID(id string) (model.RateRule, error) {
+	query := `
+		SELECT ` + rateRuleColumns + `
+		FROM rate_rules
+		WHERE id = $1
+	`
+
+	rule, err := scanRateRule(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RateRule{}, errors.New("rate rule not found")
+		}
+		return model.RateRule{}, err
+	}
+
+	return rule, nil
+}
+
+// Update updates a rate rule
+func (r *RateRuleRepository) Update(rule model.RateRule) (model.RateRule, error) {
+	query := `
+		UPDATE rate_rules
+		SET name = $1, rule_type = $2, room_type_id = NULLIF($3, '')::uuid, start_date = $4, end_date = $5, min_nights = $6,
+			min_occupancy = $7, adjustment_type = $8, adjustment = $9, active = $10, cancellation_policy_id = NULLIF($11, '')::uuid, updated_at = $12
+		WHERE id = $13
+		RETURNING ` + rateRuleColumns
+
+	rule.UpdatedAt = time.Now()
+
+	updated, err := scanRateRule(r.db.QueryRow(
+		query,
+		rule.Name,
+		rule.RuleType,
+		rule.RoomTypeID,
+		rule.StartDate,
+		rule.EndDate,
+		rule.MinNights,
+		rule.MinOccupancy,
+		rule.AdjustmentType,
+		rule.Adjustment,
+		rule.Active,
+		rule.CancellationPolicyID,
+		rule.UpdatedAt,
+		rule.ID,
+	))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RateRule{}, errors.New("rate rule not found")
+		}
+		return model.RateRule{}, err
+	}
+
+	return updated, nil
+}
+
+// Delete deletes a rate rule
+func (r *RateRuleRepository) Delete(id string) error {
+	query := `
+		DELETE FROM rate_rules
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("rate rule not found")
+	}
+
+	return nil
+}
+
+// List lists all rate rules
+func (r *RateRuleRepository) List(limit, offset int) ([]model.RateRule, error) {
+	query := `
+		SELECT ` + rateRuleColumns + `
+		FROM rate_rules
+		ORDER BY rule_type ASC, created_at ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRateRules(rows)
+}
+
+// ListApplicable lists the active rules that may apply to a stay in a room of the given
+// type between startDate and endDate. Season rules are only returned if they overlap the stay.
+func (r *RateRuleRepository) ListApplicable(roomTypeID string, startDate, endDate time.Time) ([]model.RateRule, error) {
+	query := `
+		SELECT ` + rateRuleColumns + `
+		FROM rate_rules
+		WHERE active = TRUE
+		AND (room_type_id IS NULL OR room_type_id::text = $1)
+		AND (rule_type <> 'season' OR (start_date < $3 AND end_date >= $2))
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, roomTypeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRateRules(rows)
+}
+
+// OccupancyByDate returns, in a single query, the percentage of rooms booked on each
+// night from startDate up to endDate, keyed by date (YYYY-MM-DD). Only rooms of the
+// given type are considered, or every room if roomTypeID is empty.
+func (r *RateRuleRepository) OccupancyByDate(roomTypeID string, startDate, endDate time.Time) (map[string]float64, error) {
+	query := `
+		WITH comparable_rooms AS (
+			SELECT id
+			FROM rooms
+			WHERE $1 = '' OR room_type_id::text = $1
+		)
+		SELECT
+			d::date,
+			COUNT(b.id),
+			(SELECT COUNT(*) FROM comparable_rooms)
+		FROM generate_series($2::date, $3::date - 1, INTERVAL '1 day') AS d
+		LEFT JOIN bookings b
+			ON b.room_id IN (SELECT id FROM comparable_rooms)
+			AND b.status IN ` + blockingBookingStatuses + `
+			AND b.start_date <= d::date
+			AND b.end_date > d::date
+		GROUP BY d
+		ORDER BY d
+	`
+
+	rows, err := r.db.Query(query, roomTypeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occupancy := make(map[string]float64)
+	for rows.Next() {
+		var date time.Time
+		var booked, total int
+		if err := rows.Scan(&date, &booked, &total); err != nil {
+			return nil, err
+		}
+
+		if total > 0 {
+			occupancy[date.Format("2006-01-02")] = float64(booked) * 100 / float64(total)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return occupancy, nil
+}
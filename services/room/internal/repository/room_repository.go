@@ -9,6 +9,9 @@ import (
 	"github.com/flaminshinjan/address.ai/services/room/internal/model"
 )
 
+// roomColumns is the column list selected for every room query
+const roomColumns = `id, number, COALESCE(room_type_id::text, ''), type, floor, description, capacity, price_per_day, status, created_at, updated_at`
+
 // RoomRepository handles database operations for rooms
 type RoomRepository struct {
 	db *sql.DB
@@ -19,13 +22,51 @@ func NewRoomRepository(db *sql.DB) *RoomRepository {
 	return &RoomRepository{db: db}
 }
 
+// scanRoom scans a row selected with roomColumns into a room
+func scanRoom(row rowScanner) (model.Room, error) {
+	var room model.Room
+	err := row.Scan(
+		&room.ID,
+		&room.Number,
+		&room.TypeID,
+		&room.Type,
+		&room.Floor,
+		&room.Description,
+		&room.Capacity,
+		&room.PricePerDay,
+		&room.Status,
+		&room.CreatedAt,
+		&room.UpdatedAt,
+	)
+	return room, err
+}
+
+// scanRooms scans all rows selected with roomColumns
+func scanRooms(rows *sql.Rows) ([]model.Room, error) {
+	defer rows.Close()
+
+	var rooms []model.Room
+	for rows.Next() {
+		room, err := scanRoom(rows)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
 // Create creates a new room
 func (r *RoomRepository) Create(room model.Room) (model.Room, error) {
 	query := `
-		INSERT INTO rooms (id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at
-	`
+		INSERT INTO rooms (id, number, room_type_id, type, floor, description, capacity, price_per_day, status, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ` + roomColumns
 
 	// Generate UUID if not provided
 	if room.ID == "" {
@@ -42,10 +83,11 @@ func (r *RoomRepository) Create(room model.Room) (model.Room, error) {
 		room.Status = "available"
 	}
 
-	err := r.db.QueryRow(
+	created, err := scanRoom(r.db.QueryRow(
 		query,
 		room.ID,
 		room.Number,
+		room.TypeID,
 		room.Type,
 		room.Floor,
 		room.Description,
@@ -54,48 +96,24 @@ func (r *RoomRepository) Create(room model.Room) (model.Room, error) {
 		room.Status,
 		room.CreatedAt,
 		room.UpdatedAt,
-	).Scan(
-		&room.ID,
-		&room.Number,
-		&room.Type,
-		&room.Floor,
-		&room.Description,
-		&room.Capacity,
-		&room.PricePerDay,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
+	))
 
 	if err != nil {
 		return model.Room{}, err
 	}
 
-	return room, nil
+	return created, nil
 }
 
 // GetByID gets a room by ID
 func (r *RoomRepository) GetByID(id string) (model.Room, error) {
 	query := `
-		SELECT id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at
+		SELECT ` + roomColumns + `
 		FROM rooms
 		WHERE id = $1
 	`
 
-	var room model.Room
-	err := r.db.QueryRow(query, id).Scan(
-		&room.ID,
-		&room.Number,
-		&room.Type,
-		&room.Floor,
-		&room.Description,
-		&room.Capacity,
-		&room.PricePerDay,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
-
+	room, err := scanRoom(r.db.QueryRow(query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Room{}, errors.New("room not found")
@@ -109,25 +127,12 @@ func (r *RoomRepository) GetByID(id string) (model.Room, error) {
 // GetByNumber gets a room by number
 func (r *RoomRepository) GetByNumber(number string) (model.Room, error) {
 	query := `
-		SELECT id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at
+		SELECT ` + roomColumns + `
 		FROM rooms
 		WHERE number = $1
 	`
 
-	var room model.Room
-	err := r.db.QueryRow(query, number).Scan(
-		&room.ID,
-		&room.Number,
-		&room.Type,
-		&room.Floor,
-		&room.Description,
-		&room.Capacity,
-		&room.PricePerDay,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
-
+	room, err := scanRoom(r.db.QueryRow(query, number))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return model.Room{}, errors.New("room not found")
@@ -142,16 +147,16 @@ func (r *RoomRepository) GetByNumber(number string) (model.Room, error) {
 func (r *RoomRepository) Update(room model.Room) (model.Room, error) {
 	query := `
 		UPDATE rooms
-		SET number = $1, type = $2, floor = $3, description = $4, capacity = $5, price_per_day = $6, status = $7, updated_at = $8
-		WHERE id = $9
-		RETURNING id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at
-	`
+		SET number = $1, room_type_id = NULLIF($2, '')::uuid, type = $3, floor = $4, description = $5, capacity = $6, price_per_day = $7, status = $8, updated_at = $9
+		WHERE id = $10
+		RETURNING ` + roomColumns
 
 	room.UpdatedAt = time.Now()
 
-	err := r.db.QueryRow(
+	updated, err := scanRoom(r.db.QueryRow(
 		query,
 		room.Number,
+		room.TypeID,
 		room.Type,
 		room.Floor,
 		room.Description,
@@ -160,18 +165,7 @@ func (r *RoomRepository) Update(room model.Room) (model.Room, error) {
 		room.Status,
 		room.UpdatedAt,
 		room.ID,
-	).Scan(
-		&room.ID,
-		&room.Number,
-		&room.Type,
-		&room.Floor,
-		&room.Description,
-		&room.Capacity,
-		&room.PricePerDay,
-		&room.Status,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
+	))
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -180,7 +174,7 @@ func (r *RoomRepository) Update(room model.Room) (model.Room, error) {
 		return model.Room{}, err
 	}
 
-	return room, nil
+	return updated, nil
 }
 
 // Delete deletes a room
@@ -207,10 +201,31 @@ func (r *RoomRepository) Delete(id string) error {
 	return nil
 }
 
+// IsOutOfService reports whether a room has been put under maintenance by hand rather
+// than by a maintenance window, which takes it out of service with no end date
+func (r *RoomRepository) IsOutOfService(id string) (bool, error) {
+	query := `
+		SELECT NOT ` + inServiceRoom + `
+		FROM rooms r
+		WHERE r.id = $1
+	`
+
+	var outOfService bool
+	err := r.db.QueryRow(query, id).Scan(&outOfService)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, errors.New("room not found")
+		}
+		return false, err
+	}
+
+	return outOfService, nil
+}
+
 // List lists all rooms
 func (r *RoomRepository) List(limit, offset int) ([]model.Room, error) {
 	query := `
-		SELECT id, number, type, floor, description, capacity, price_per_day, status, created_at, updated_at
+		SELECT ` + roomColumns + `
 		FROM rooms
 		ORDER BY number ASC
 		LIMIT $1 OFFSET $2
@@ -220,51 +235,48 @@ func (r *RoomRepository) List(limit, offset int) ([]model.Room, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var rooms []model.Room
-	for rows.Next() {
-		var room model.Room
-		err := rows.Scan(
-			&room.ID,
-			&room.Number,
-			&room.Type,
-			&room.Floor,
-			&room.Description,
-			&room.Capacity,
-			&room.PricePerDay,
-			&room.Status,
-			&room.CreatedAt,
-			&room.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		rooms = append(rooms, room)
-	}
+	return scanRooms(rows)
+}
 
-	if err := rows.Err(); err != nil {
+// ListByType lists all rooms of a room type
+func (r *RoomRepository) ListByType(roomTypeID string) ([]model.Room, error) {
+	query := `
+		SELECT ` + roomColumns + `
+		FROM rooms
+		WHERE room_type_id = $1
+		ORDER BY number ASC
+	`
+
+	rows, err := r.db.Query(query, roomTypeID)
+	if err != nil {
 		return nil, err
 	}
 
-	return rooms, nil
+	return scanRooms(rows)
 }
 
-// ListAvailable lists all available rooms
+// ListAvailable lists all rooms in service that have no blocking booking or maintenance
+// window for the given dates. A room's current occupied or cleaning status does not affect
+// future stays.
 func (r *RoomRepository) ListAvailable(startDate, endDate time.Time, limit, offset int) ([]model.Room, error) {
 	query := `
-		SELECT r.id, r.number, r.type, r.floor, r.description, r.capacity, r.price_per_day, r.status, r.created_at, r.updated_at
+		SELECT ` + roomColumns + `
 		FROM rooms r
-		WHERE r.status = 'available'
+		WHERE ` + inServiceRoom + `
 		AND r.id NOT IN (
 			SELECT b.room_id
 			FROM bookings b
-			WHERE b.status = 'confirmed'
-			AND (
-				(b.start_date <= $1 AND b.end_date >= $1)
-				OR (b.start_date <= $2 AND b.end_date >= $2)
-				OR (b.start_date >= $1 AND b.end_date <= $2)
-			)
+			WHERE b.status IN ` + blockingBookingStatuses + `
+			AND b.start_date < $2
+			AND b.end_date > $1
+		)
+		AND r.id NOT IN (
+			SELECT m.room_id
+			FROM maintenance_windows m
+			WHERE m.status IN ` + blockingMaintenanceStatuses + `
+			AND m.start_date < $2
+			AND m.end_date > $1
 		)
 		ORDER BY r.number ASC
 		LIMIT $3 OFFSET $4
@@ -274,34 +286,117 @@ func (r *RoomRepository) ListAvailable(startDate, endDate time.Time, limit, offs
 	if err != nil {
 		return nil, err
 	}
+
+	return scanRooms(rows)
+}
+
+// ListAvailableByType lists rooms of the given room type that are in service and free for
+// the given dates
+func (r *RoomRepository) ListAvailableByType(roomTypeID string, startDate, endDate time.Time, limit int) ([]model.Room, error) {
+	query := `
+		SELECT ` + roomColumns + `
+		FROM rooms r
+		WHERE ` + inServiceRoom + `
+		AND r.room_type_id = $1
+		AND r.id NOT IN (
+			SELECT b.room_id
+			FROM bookings b
+			WHERE b.status IN ` + blockingBookingStatuses + `
+			AND b.start_date < $3
+			AND b.end_date > $2
+		)
+		AND r.id NOT IN (
+			SELECT m.room_id
+			FROM maintenance_windows m
+			WHERE m.status IN ` + blockingMaintenanceStatuses + `
+			AND m.start_date < $3
+			AND m.end_date > $2
+		)
+		ORDER BY r.number ASC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, roomTypeID, startDate, endDate, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRooms(rows)
+}
+
+// Occupancy returns, in a single query, every room's nights in [startDate, endDate)
+// with the booking occupying each night, optionally limited to one room type. Past
+// stays that have been checked out are shown as well as blocking bookings, and nights
+// under maintenance have the status maintenance.
+func (r *RoomRepository) Occupancy(startDate, endDate time.Time, roomTypeID string) ([]model.OccupancyRow, error) {
+	query := `
+		SELECT r.id, r.number, r.type, r.status, TO_CHAR(g.day, 'YYYY-MM-DD'),
+			COALESCE(b.id::text, ''), COALESCE(b.status, CASE WHEN m.id IS NOT NULL THEN 'maintenance' END, ''), COALESCE(b.user_id, ''),
+			COALESCE(TRIM(u.first_name || ' ' || u.last_name), '')
+		FROM rooms r
+		CROSS JOIN generate_series($1::date, $2::date - 1, INTERVAL '1 day') AS g(day)
+		LEFT JOIN LATERAL (
+			SELECT bk.id, bk.status, bk.user_id
+			FROM bookings bk
+			WHERE bk.room_id = r.id
+			AND (bk.status IN ` + blockingBookingStatuses + ` OR bk.status = 'completed')
+			AND bk.start_date <= g.day
+			AND bk.end_date > g.day
+			ORDER BY CASE WHEN bk.status = 'completed' THEN 1 ELSE 0 END, bk.start_date DESC
+			LIMIT 1
+		) b ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT mw.id
+			FROM maintenance_windows mw
+			WHERE mw.room_id = r.id
+			AND mw.status IN ` + blockingMaintenanceStatuses + `
+			AND mw.start_date <= g.day
+			AND mw.end_date > g.day
+			LIMIT 1
+		) m ON TRUE
+		LEFT JOIN users u ON u.id = b.user_id
+		WHERE ($3 = '' OR r.room_type_id::text = $3)
+		ORDER BY r.number ASC, r.id ASC, g.day ASC
+	`
+
+	rows, err := r.db.Query(query, startDate, endDate, roomTypeID)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	var rooms []model.Room
+	var occupancy []model.OccupancyRow
 	for rows.Next() {
-		var room model.Room
+		var row model.OccupancyRow
+		var cell model.OccupancyCell
 		err := rows.Scan(
-			&room.ID,
-			&room.Number,
-			&room.Type,
-			&room.Floor,
-			&room.Description,
-			&room.Capacity,
-			&room.PricePerDay,
-			&room.Status,
-			&room.CreatedAt,
-			&room.UpdatedAt,
+			&row.RoomID,
+			&row.RoomNumber,
+			&row.RoomType,
+			&row.RoomStatus,
+			&cell.Date,
+			&cell.BookingID,
+			&cell.Status,
+			&cell.UserID,
+			&cell.GuestName,
 		)
 		if err != nil {
 			return nil, err
 		}
-		rooms = append(rooms, room)
+
+		// Rows arrive grouped by room, so a new room ID starts a new line
+		if len(occupancy) == 0 || occupancy[len(occupancy)-1].RoomID != row.RoomID {
+			occupancy = append(occupancy, row)
+		}
+		last := &occupancy[len(occupancy)-1]
+		last.Cells = append(last.Cells, cell)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return rooms, nil
+	return occupancy, nil
 }
 
 // UpdateStatus updates a room's status
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/lib/pq"
+)
+
+// roomTypeColumns is the column list selected for every room type query
+const roomTypeColumns = `id, name, COALESCE(description, ''), base_price, default_capacity, amenities, COALESCE(cancellation_policy_id::text, ''),
+	COALESCE(deposit_type, ''), deposit_percent, deposit_amount, deposit_due_hours, created_at, updated_at`
+
+// RoomTypeRepository handles database operations for room types
+type RoomTypeRepository struct {
+	db *sql.DB
+}
+
+// NewRoomTypeRepository creates a new RoomTypeRepository
+func NewRoomTypeRepository(db *sql.DB) *RoomTypeRepository {
+	return &RoomTypeRepository{db: db}
+}
+
+// scanRoomType scans a row selected with roomTypeColumns into a room type
+func scanRoomType(row rowScanner) (model.RoomType, error) {
+	var roomType model.RoomType
+	var amenities pq.StringArray
+	err := row.Scan(
+		&roomType.ID,
+		&roomType.Name,
+		&roomType.Description,
+		&roomType.BasePrice,
+		&roomType.DefaultCapacity,
+		&amenities,
+		&roomType.CancellationPolicyID,
+		&roomType.DepositType,
+		&roomType.DepositPercent,
+		&roomType.DepositAmount,
+		&roomType.DepositDueHours,
+		&roomType.CreatedAt,
+		&roomType.UpdatedAt,
+	)
+	roomType.Amenities = []string(amenities)
+	if roomType.Amenities == nil {
+		roomType.Amenities = []string{}
+	}
+	return roomType, err
+}
+
+// Create creates a new room type
+func (r *RoomTypeRepository) Create(roomType model.RoomType) (model.RoomType, error) {
+	query := `
+		INSERT INTO room_types (id, name, description, base_price, default_capacity, amenities, cancellation_policy_id,
+			deposit_type, deposit_percent, deposit_amount, deposit_due_hours, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, NULLIF($8, ''), $9, $10, $11, $12, $13)
+		RETURNING ` + roomTypeColumns
+
+	// Generate UUID if not provided
+	if roomType.ID == "" {
+		roomType.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	roomType.CreatedAt = now
+	roomType.UpdatedAt = now
+
+	if roomType.Amenities == nil {
+		roomType.Amenities = []string{}
+	}
+
+	return scanRoomType(r.db.QueryRow(
+		query,
+		roomType.ID,
+		roomType.Name,
+		roomType.Description,
+		roomType.BasePrice,
+		roomType.DefaultCapacity,
+		pq.Array(roomType.Amenities),
+		roomType.CancellationPolicyID,
+		roomType.DepositType,
+		roomType.DepositPercent,
+		roomType.DepositAmount,
+		roomType.DepositDueHours,
+		roomType.CreatedAt,
+		roomType.UpdatedAt,
+	))
+}
+
+// GetByID gets a room type by ID
+func (r *RoomTypeRepository) GetByID(id string) (model.RoomType, error) {
+	query := `
+		SELECT ` + roomTypeColumns + `
+		FROM room_types
+		WHERE id = $1
+	`
+
+	roomType, err := scanRoomType(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RoomType{}, errors.New("room type not found")
+		}
+		return model.RoomType{}, err
+	}
+
+	return roomType, nil
+}
+
+// GetByName gets a room type by name
+func (r *RoomTypeRepository) GetByName(name string) (model.RoomType, error) {
+	query := `
+		SELECT ` + roomTypeColumns + `
+		FROM room_types
+		WHERE name = $1
+	`
+
+	roomType, err := scanRoomType(r.db.QueryRow(query, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RoomType{}, errors.New("room type not found")
+		}
+		return model.RoomType{}, err
+	}
+
+	return roomType, nil
+}
+
+// Update updates a room type. The type name denormalized onto its rooms is
+// renamed in the same transaction.
+func (r *RoomTypeRepository) Update(roomType model.RoomType) (model.RoomType, error) {
+	query := `
+		UPDATE room_types
+		SET name = $1, description = $2, base_price = $3, default_capacity = $4, amenities = $5,
+			cancellation_policy_id = NULLIF($6, '')::uuid, deposit_type = NULLIF($7, ''), deposit_percent = $8, deposit_amount = $9,
+			deposit_due_hours = $10, updated_at = $11
+		WHERE id = $12
+		RETURNING ` + roomTypeColumns
+
+	roomType.UpdatedAt = time.Now()
+
+	if roomType.Amenities == nil {
+		roomType.Amenities = []string{}
+	}
+
+	var updated model.RoomType
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		var err error
+		updated, err = scanRoomType(tx.QueryRow(
+			query,
+			roomType.Name,
+			roomType.Description,
+			roomType.BasePrice,
+			roomType.DefaultCapacity,
+			pq.Array(roomType.Amenities),
+			roomType.CancellationPolicyID,
+			roomType.DepositType,
+			roomType.DepositPercent,
+			roomType.DepositAmount,
+			roomType.DepositDueHours,
+			roomType.UpdatedAt,
+			roomType.ID,
+		))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`
+			UPDATE rooms
+			SET type = $1, updated_at = $2
+			WHERE room_type_id = $3
+			AND type <> $1
+		`, updated.Name, roomType.UpdatedAt, updated.ID)
+		return err
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.RoomType{}, errors.New("room type not found")
+		}
+		return model.RoomType{}, err
+	}
+
+	return updated, nil
+}
+
+// Delete deletes a room type
+func (r *RoomTypeRepository) Delete(id string) error {
+	query := `
+		DELETE FROM room_types
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("room type not found")
+	}
+
+	return nil
+}
+
+// List lists all room types
+func (r *RoomTypeRepository) List(limit, offset int) ([]model.RoomType, error) {
+	query := `
+		SELECT ` + roomTypeColumns + `
+		FROM room_types
+		ORDER BY name ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roomTypes []model.RoomType
+	for rows.Next() {
+		roomType, err := scanRoomType(rows)
+		if err != nil {
+			return nil, err
+		}
+		roomTypes = append(roomTypes, roomType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return roomTypes, nil
+}
+
+// CountRooms counts the rooms that reference a room type
+func (r *RoomTypeRepository) CountRooms(id string) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM rooms WHERE room_type_id = $1`, id).Scan(&count)
+	return count, err
+}
+
+// CountAvailable counts, in a single query, how many rooms of a type exist and how many
+// of them are in service and free of blocking bookings and maintenance for [startDate, endDate)
+func (r *RoomTypeRepository) CountAvailable(id string, startDate, endDate time.Time) (total int, available int, err error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (
+				WHERE ` + inServiceRoom + `
+				AND NOT EXISTS (
+					SELECT 1
+					FROM bookings b
+					WHERE b.room_id = r.id
+					AND b.status IN ` + blockingBookingStatuses + `
+					AND b.start_date < $3
+					AND b.end_date > $2
+				)
+				AND NOT EXISTS (
+					SELECT 1
+					FROM maintenance_windows m
+					WHERE m.room_id = r.id
+					AND m.status IN ` + blockingMaintenanceStatuses + `
+					AND m.start_date < $3
+					AND m.end_date > $2
+				)
+			)
+		FROM rooms r
+		WHERE r.room_type_id = $1
+	`
+
+	err = r.db.QueryRow(query, id, startDate, endDate).Scan(&total, &available)
+	return total, available, err
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+)
+
+// taxRuleColumns is the column list selected for every tax rule query
+const taxRuleColumns = `id, name, kind, rate, effective_from, effective_to, created_at, updated_at`
+
+// TaxRuleRepository handles database operations for the taxes and service charges levied on stays
+type TaxRuleRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRuleRepository creates a new TaxRuleRepository
+func NewTaxRuleRepository(db *sql.DB) *TaxRuleRepository {
+	return &TaxRuleRepository{db: db}
+}
+
+// scanTaxRule scans a row selected with taxRuleColumns into a tax rule
+func scanTaxRule(row rowScanner) (tax.Rule, error) {
+	var rule tax.Rule
+	err := row.Scan(
+		&rule.ID,
+		&rule.Name,
+		&rule.Kind,
+		&rule.Rate,
+		&rule.EffectiveFrom,
+		&rule.EffectiveTo,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+	return rule, err
+}
+
+// scanTaxRules scans all rows selected with taxRuleColumns
+func scanTaxRules(rows *sql.Rows) ([]tax.Rule, error) {
+	defer rows.Close()
+
+	var rules []tax.Rule
+	for rows.Next() {
+		rule, err := scanTaxRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Create creates a new tax rule
+func (r *TaxRuleRepository) Create(rule tax.Rule) (tax.Rule, error) {
+	query := `
+		INSERT INTO room_tax_rules (id, name, kind, rate, effective_from, effective_to, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + taxRuleColumns
+
+	// Generate UUID if not provided
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	return scanTaxRule(r.db.QueryRow(
+		query,
+		rule.ID,
+		rule.Name,
+		rule.Kind,
+		rule.Rate,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+		rule.CreatedAt,
+		rule.UpdatedAt,
+	))
+}
+
+// GetByID gets a tax rule by ID
+func (r *TaxRuleRepository) GetByID(id string) (tax.Rule, error) {
+	query := `
+		SELECT ` + taxRuleColumns + `
+		FROM room_tax_rules
+		WHERE id = $1
+	`
+
+	rule, err := scanTaxRule(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tax.Rule{}, errors.New("tax rule not found")
+		}
+		return tax.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// Update updates a tax rule
+func (r *TaxRuleRepository) Update(rule tax.Rule) (tax.Rule, error) {
+	query := `
+		UPDATE room_tax_rules
+		SET name = $1, kind = $2, rate = $3, effective_from = $4, effective_to = $5, updated_at = $6
+		WHERE id = $7
+		RETURNING ` + taxRuleColumns
+
+	rule.UpdatedAt = time.Now()
+
+	updated, err := scanTaxRule(r.db.QueryRow(
+		query,
+		rule.Name,
+		rule.Kind,
+		rule.Rate,
+		rule.EffectiveFrom,
+		rule.EffectiveTo,
+		rule.UpdatedAt,
+		rule.ID,
+	))
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tax.Rule{}, errors.New("tax rule not found")
+		}
+		return tax.Rule{}, err
+	}
+
+	return updated, nil
+}
+
+// Delete deletes a tax rule
+func (r *TaxRuleRepository) Delete(id string) error {
+	query := `
+		DELETE FROM room_tax_rules
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("tax rule not found")
+	}
+
+	return nil
+}
+
+// List lists all tax rules, latest first
+func (r *TaxRuleRepository) List(limit, offset int) ([]tax.Rule, error) {
+	query := `
+		SELECT ` + taxRuleColumns + `
+		FROM room_tax_rules
+		ORDER BY effective_from DESC, name ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTaxRules(rows)
+}
+
+// ListEffective lists the rules in force at any time from startDate up to endDate
+func (r *TaxRuleRepository) ListEffective(startDate, endDate time.Time) ([]tax.Rule, error) {
+	query := `
+		SELECT ` + taxRuleColumns + `
+		FROM room_tax_rules
+		WHERE effective_from < $2
+		AND (effective_to IS NULL OR effective_to > $1)
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanTaxRules(rows)
+}
@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+// waitlistColumns is the column list selected for every waitlist query
+const waitlistColumns = `id, user_id, COALESCE(room_id::text, ''), COALESCE(room_type_id::text, ''), start_date, end_date, status,
+	COALESCE(offered_booking_id::text, ''), offer_expires_at, created_at, updated_at`
+
+// WaitlistRepository handles database operations for waitlist entries
+type WaitlistRepository struct {
+	db *sql.DB
+}
+
+// NewWaitlistRepository creates a new WaitlistRepository
+func NewWaitlistRepository(db *sql.DB) *WaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+// scanWaitlistEntry scans a row selected with waitlistColumns into a waitlist entry
+func scanWaitlistEntry(row rowScanner) (model.WaitlistEntry, error) {
+	var entry model.WaitlistEntry
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.RoomID,
+		&entry.RoomTypeID,
+		&entry.StartDate,
+		&entry.EndDate,
+		&entry.Status,
+		&entry.OfferedBookingID,
+		&entry.OfferExpiresAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	return entry, err
+}
+
+// scanWaitlistEntries scans all rows selected with waitlistColumns
+func scanWaitlistEntries(rows *sql.Rows) ([]model.WaitlistEntry, error) {
+	defer rows.Close()
+
+	var entries []model.WaitlistEntry
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Create creates a new waitlist entry
+func (r *WaitlistRepository) Create(entry model.WaitlistEntry) (model.WaitlistEntry, error) {
+	query := `
+		INSERT INTO waitlist_entries (id, user_id, room_id, room_type_id, start_date, end_date, status, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9)
+		RETURNING ` + waitlistColumns
+
+	// Generate UUID if not provided
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+
+	// Set timestamps
+	now := time.Now()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+
+	// Set default status if not provided
+	if entry.Status == "" {
+		entry.Status = "waiting"
+	}
+
+	return scanWaitlistEntry(r.db.QueryRow(
+		query,
+		entry.ID,
+		entry.UserID,
+		entry.RoomID,
+		entry.RoomTypeID,
+		entry.StartDate,
+		entry.EndDate,
+		entry.Status,
+		entry.CreatedAt,
+		entry.UpdatedAt,
+	))
+}
+
+// GetByID gets a waitlist entry by ID
+func (r *WaitlistRepository) GetByID(id string) (model.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE id = $1
+	`
+
+	entry, err := scanWaitlistEntry(r.db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model.WaitlistEntry{}, errors.New("waitlist entry not found")
+		}
+		return model.WaitlistEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// ListByUserID lists waitlist entries by user ID
+func (r *WaitlistRepository) ListByUserID(userID string, limit, offset int) ([]model.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWaitlistEntries(rows)
+}
+
+// List lists all waitlist entries
+func (r *WaitlistRepository) List(limit, offset int) ([]model.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		ORDER BY created_at ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWaitlistEntries(rows)
+}
+
+// ListWaiting lists, oldest first, the waiting entries for the room or its room type
+// whose dates overlap [startDate, endDate) and have not started yet
+func (r *WaitlistRepository) ListWaiting(roomID, roomTypeID string, startDate, endDate time.Time) ([]model.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE status = 'waiting'
+		AND (room_id::text = $1 OR room_type_id::text = $2)
+		AND start_date < $4
+		AND end_date > $3
+		AND start_date >= CURRENT_DATE
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.Query(query, roomID, roomTypeID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWaitlistEntries(rows)
+}
+
+// ListExpiredOffers lists the entries whose offer has run out without being accepted
+func (r *WaitlistRepository) ListExpiredOffers() ([]model.WaitlistEntry, error) {
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE status = 'offered'
+		AND offer_expires_at <= NOW()
+		ORDER BY offer_expires_at ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanWaitlistEntries(rows)
+}
+
+// Cancel takes a waiting entry off the waitlist
+func (r *WaitlistRepository) Cancel(id string) error {
+	query := `
+		UPDATE waitlist_entries
+		SET status = 'cancelled', updated_at = $1
+		WHERE id = $2
+		AND status = 'waiting'
+	`
+
+	result, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("waitlist entry is not waiting")
+	}
+
+	return nil
+}
+
+// Offer holds a room for a waiting entry by inserting the held booking and marking the
+// entry offered in one transaction. It returns model.ErrBookingConflict if the room is
+// no longer free for the entry's dates.
+func (r *WaitlistRepository) Offer(entry model.WaitlistEntry, booking model.Booking) (model.WaitlistEntry, model.Booking, error) {
+	var offered model.WaitlistEntry
+	var held model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		if err := lockRoom(tx, booking.RoomID); err != nil {
+			return err
+		}
+
+		overlap, err := hasOverlap(tx, booking.RoomID, booking.StartDate, booking.EndDate, "")
+		if err != nil {
+			return err
+		}
+		if overlap {
+			return model.ErrBookingConflict
+		}
+
+		held, err = insertBooking(tx, booking)
+		if err != nil {
+			return err
+		}
+
+		offered, err = scanWaitlistEntry(tx.QueryRow(`
+			UPDATE waitlist_entries
+			SET status = 'offered', offered_booking_id = $1, offer_expires_at = $2, updated_at = $3
+			WHERE id = $4
+			AND status = 'waiting'
+			RETURNING `+waitlistColumns,
+			held.ID, held.HoldExpiresAt, time.Now(), entry.ID,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("waitlist entry is not waiting")
+		}
+		return err
+	})
+
+	if err != nil {
+		return model.WaitlistEntry{}, model.Booking{}, err
+	}
+
+	return offered, held, nil
+}
+
+// Accept confirms the held booking of an offered entry in one transaction. It fails
+// if the hold has already run out.
+func (r *WaitlistRepository) Accept(id string) (model.WaitlistEntry, model.Booking, error) {
+	var accepted model.WaitlistEntry
+	var booking model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var err error
+		accepted, err = scanWaitlistEntry(tx.QueryRow(`
+			UPDATE waitlist_entries
+			SET status = 'accepted', updated_at = $1
+			WHERE id = $2
+			AND status = 'offered'
+			AND offer_expires_at > $1
+			RETURNING `+waitlistColumns,
+			now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("no open offer for this waitlist entry")
+			}
+			return err
+		}
+
+		booking, err = scanBooking(tx.QueryRow(`
+			UPDATE bookings
+			SET status = 'confirmed', hold_expires_at = NULL, updated_at = $1
+			WHERE id = $2
+			AND status = 'held'
+			RETURNING `+bookingColumns,
+			now, accepted.OfferedBookingID,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("the held room is no longer available")
+		}
+		return err
+	})
+
+	if err != nil {
+		return model.WaitlistEntry{}, model.Booking{}, err
+	}
+
+	return accepted, booking, nil
+}
+
+// Release ends an offer, setting the entry to status (declined or expired) and freeing
+// the held booking in one transaction. It returns the booking that was released.
+func (r *WaitlistRepository) Release(id, status string) (model.Booking, error) {
+	var released model.Booking
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		entry, err := scanWaitlistEntry(tx.QueryRow(`
+			UPDATE waitlist_entries
+			SET status = $1, updated_at = $2
+			WHERE id = $3
+			AND status = 'offered'
+			RETURNING `+waitlistColumns,
+			status, now, id,
+		))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("no open offer for this waitlist entry")
+			}
+			return err
+		}
+
+		// The hold may already have been expired by another transaction
+		released, err = scanBooking(tx.QueryRow(`
+			UPDATE bookings
+			SET status = CASE WHEN status = 'held' THEN 'released' ELSE status END, updated_at = $1
+			WHERE id = $2
+			RETURNING `+bookingColumns,
+			now, entry.OfferedBookingID,
+		))
+		return err
+	})
+
+	if err != nil {
+		return model.Booking{}, err
+	}
+
+	return released, nil
+}
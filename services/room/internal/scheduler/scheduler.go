@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a task run periodically in the background
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Scheduler runs jobs periodically until it is stopped
+type Scheduler struct {
+	jobs []Job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		stop: make(chan struct{}),
+	}
+}
+
+// Add registers a job. Jobs must be added before Start is called.
+func (s *Scheduler) Add(name string, interval time.Duration, run func() error) {
+	s.jobs = append(s.jobs, Job{
+		Name:     name,
+		Interval: interval,
+		Run:      run,
+	})
+}
+
+// Start runs every job once immediately and then on its interval
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.run(job)
+	}
+}
+
+// Stop stops every job and waits for running ones to finish
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// run runs a job until the scheduler is stopped. Errors are logged and the job is
+// retried on its next tick.
+func (s *Scheduler) run(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.Run(); err != nil {
+			log.Printf("Scheduled job %q failed: %v", job.Name, err)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// BookingGroupService handles business logic for group bookings
+type BookingGroupService struct {
+	groupRepo       *repository.BookingGroupRepository
+	roomRepo        *repository.RoomRepository
+	roomTypeRepo    *repository.RoomTypeRepository
+	pricingService  *PricingService
+	policyService   *CancellationPolicyService
+	waitlistService *WaitlistService
+	paymentService  *PaymentService
+	hub             *notify.Hub
+}
+
+// NewBookingGroupService creates a new BookingGroupService telling guests of changes to
+// their bookings through hub
+func NewBookingGroupService(groupRepo *repository.BookingGroupRepository, roomRepo *repository.RoomRepository, roomTypeRepo *repository.RoomTypeRepository, pricingService *PricingService, policyService *CancellationPolicyService, waitlistService *WaitlistService, paymentService *PaymentService, hub *notify.Hub) *BookingGroupService {
+	return &BookingGroupService{
+		groupRepo:       groupRepo,
+		roomRepo:        roomRepo,
+		roomTypeRepo:    roomTypeRepo,
+		pricingService:  pricingService,
+		policyService:   policyService,
+		waitlistService: waitlistService,
+		paymentService:  paymentService,
+		hub:             hub,
+	}
+}
+
+// CreateGroupBooking books several rooms for the same dates. Either every room is
+// booked or none is; model.ErrBookingConflict is returned if any room is taken.
+func (s *BookingGroupService) CreateGroupBooking(userID string, req model.GroupBookingRequest) (model.BookingGroupResponse, error) {
+	// Validate dates
+	if !req.StartDate.Before(req.EndDate) {
+		return model.BookingGroupResponse{}, errors.New("start date must be before end date")
+	}
+
+	if req.StartDate.Before(time.Now()) {
+		return model.BookingGroupResponse{}, errors.New("start date must be in the future")
+	}
+
+	rooms, err := s.resolveRooms(req)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	group := model.BookingGroup{
+		UserID:    userID,
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Status:    "confirmed",
+	}
+
+	now := time.Now()
+	var bookings []model.Booking
+	for _, room := range rooms {
+		breakdown, err := s.pricingService.PriceStay(room, req.StartDate, req.EndDate)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+
+		// Each room takes the deposit of its own type
+		roomType, err := roomTypeOf(s.roomTypeRepo, room)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+		deposit, depositDueAt := stayDeposit(roomType, breakdown, req.StartDate, now)
+
+		bookings = append(bookings, model.Booking{
+			RoomID:         room.ID,
+			UserID:         userID,
+			StartDate:      req.StartDate,
+			EndDate:        req.EndDate,
+			TotalPrice:     breakdown.Total,
+			Status:         "confirmed",
+			PriceBreakdown: breakdown,
+			DepositAmount:  deposit,
+			DepositDueAt:   depositDueAt,
+		})
+	}
+
+	// Each room's balance is authorized before the group is confirmed; if any is declined,
+	// or the rooms cannot be booked, the authorizations already made are released. A room
+	// whose deposit covers the stay has nothing to authorize.
+	transactions := make([]payment.Transaction, len(bookings))
+	voidAll := func() {
+		for _, transaction := range transactions {
+			if transaction.ID != "" {
+				s.paymentService.VoidTransaction(transaction)
+			}
+		}
+	}
+
+	for i, booking := range bookings {
+		amount := balanceAmount(booking)
+		if !amount.IsPositive() {
+			continue
+		}
+
+		transaction, err := s.paymentService.Authorize(amount, req.PaymentToken, "")
+		if err != nil {
+			voidAll()
+			return model.BookingGroupResponse{}, err
+		}
+		transactions[i] = transaction
+	}
+
+	createdGroup, createdBookings, err := s.groupRepo.Create(group, bookings)
+	if err != nil {
+		voidAll()
+		return model.BookingGroupResponse{}, err
+	}
+
+	// The bookings are created in the order they were given. They stand even if their
+	// authorization cannot be recorded, since the group is already committed.
+	for i, booking := range createdBookings {
+		if transactions[i].ID == "" {
+			continue
+		}
+
+		if _, err := s.paymentService.Record(booking.ID, transactions[i]); err != nil {
+			log.Printf("Failed to record payment transaction %s for booking %s: %v", transactions[i].ID, booking.ID, err)
+		}
+	}
+
+	// Deposits are then charged to the same payment method, as for a single booking; one
+	// that fails can be paid until it is due
+	for _, booking := range createdBookings {
+		if booking.DepositOutstanding() {
+			if _, err := s.paymentService.PayDeposit(booking, req.PaymentToken); err != nil {
+				log.Printf("Failed to take deposit for booking %s: %v", booking.ID, err)
+			}
+		}
+		notifyBooking(s.hub, bookingStatusChanged, booking)
+	}
+
+	// Reload so the payments taken are reported with the group
+	if createdBookings, err = s.groupRepo.GetBookings(createdGroup.ID); err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	return s.toResponse(createdGroup, createdBookings)
+}
+
+// resolveRooms returns the rooms requested by ID, or picks Count free rooms of the requested room type
+func (s *BookingGroupService) resolveRooms(req model.GroupBookingRequest) ([]model.Room, error) {
+	if len(req.RoomIDs) > 0 {
+		seen := make(map[string]bool)
+		var rooms []model.Room
+		for _, id := range req.RoomIDs {
+			if seen[id] {
+				return nil, errors.New("room listed more than once: " + id)
+			}
+			seen[id] = true
+
+			room, err := s.roomRepo.GetByID(id)
+			if err != nil {
+				return nil, err
+			}
+
+			outOfService, err := s.roomRepo.IsOutOfService(room.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if outOfService {
+				return nil, errors.New("room is not available: " + room.Number)
+			}
+
+			rooms = append(rooms, room)
+		}
+		return rooms, nil
+	}
+
+	if (req.RoomTypeID == "" && req.RoomType == "") || req.Count <= 0 {
+		return nil, errors.New("either room_ids or room_type_id (or room_type) and count are required")
+	}
+
+	var roomType model.RoomType
+	var err error
+	if req.RoomTypeID != "" {
+		roomType, err = s.roomTypeRepo.GetByID(req.RoomTypeID)
+	} else {
+		roomType, err = s.roomTypeRepo.GetByName(req.RoomType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := s.roomRepo.ListAvailableByType(roomType.ID, req.StartDate, req.EndDate, req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rooms) < req.Count {
+		return nil, model.ErrBookingConflict
+	}
+
+	return rooms, nil
+}
+
+// GetGroupByID gets a booking group by ID
+func (s *BookingGroupService) GetGroupByID(id string) (model.BookingGroupResponse, error) {
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	bookings, err := s.groupRepo.GetBookings(id)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	return s.toResponse(group, bookings)
+}
+
+// ListGroupsByUserID lists booking groups by user ID
+func (s *BookingGroupService) ListGroupsByUserID(userID string, limit, offset int) ([]model.BookingGroupResponse, error) {
+	groups, err := s.groupRepo.ListByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []model.BookingGroupResponse
+	for _, group := range groups {
+		bookings, err := s.groupRepo.GetBookings(group.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := s.toResponse(group, bookings)
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// CancelGroup cancels every booking in a group, applying each booking's cancellation
+// policy. The recorded cancellations are returned and the freed rooms are offered to
+// the waitlist.
+func (s *BookingGroupService) CancelGroup(id, cancelledBy string) ([]model.BookingCancellation, error) {
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if group.Status != "confirmed" {
+		return nil, errors.New("booking group is not in a confirmed state")
+	}
+
+	now := time.Now()
+	if group.StartDate.Before(now) {
+		return nil, errors.New("cannot cancel a booking group that has already started")
+	}
+
+	bookings, err := s.groupRepo.GetBookings(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancellations []model.BookingCancellation
+	for _, booking := range bookings {
+		if booking.Status != "confirmed" {
+			continue
+		}
+
+		room, err := s.roomRepo.GetByID(booking.RoomID)
+		if err != nil {
+			return nil, err
+		}
+
+		cancellation, err := s.policyService.Evaluate(booking, room.TypeID, now)
+		if err != nil {
+			return nil, err
+		}
+		cancellation.CancelledBy = cancelledBy
+
+		cancellations = append(cancellations, cancellation)
+	}
+
+	cancellations, err = s.groupRepo.Cancel(id, cancellations)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled := make(map[string]bool)
+	for _, cancellation := range cancellations {
+		cancelled[cancellation.BookingID] = true
+	}
+	for _, booking := range bookings {
+		if cancelled[booking.ID] {
+			booking.Status = "cancelled"
+			notifyBooking(s.hub, bookingStatusChanged, booking)
+		}
+	}
+
+	// Penalties are taken from each stay's authorization and the rest released
+	for _, cancellation := range cancellations {
+		if err := s.paymentService.SettleCancellation(cancellation.BookingID, cancellation.Penalty); err != nil {
+			log.Printf("Failed to settle payment for cancelled booking %s: %v", cancellation.BookingID, err)
+		}
+	}
+
+	// The cancellation stands even if the waitlist cannot be served
+	for _, booking := range bookings {
+		if booking.Status != "confirmed" {
+			continue
+		}
+
+		if err := s.waitlistService.OfferSlot(booking.RoomID, booking.StartDate, booking.EndDate); err != nil {
+			log.Printf("Failed to offer room %s to the waitlist: %v", booking.RoomID, err)
+		}
+	}
+
+	return cancellations, nil
+}
+
+// AmendGroup moves every booking in a group to new dates. Either every booking is
+// moved or none is; model.ErrBookingConflict is returned if any room is taken. As for a
+// single booking, each is re-priced with its deposit, recorded in its history and
+// authorized again when its balance changes.
+func (s *BookingGroupService) AmendGroup(id, changedBy string, req model.GroupAmendRequest) (model.BookingGroupResponse, error) {
+	// Validate dates
+	if !req.StartDate.Before(req.EndDate) {
+		return model.BookingGroupResponse{}, errors.New("start date must be before end date")
+	}
+
+	if req.StartDate.Before(time.Now()) {
+		return model.BookingGroupResponse{}, errors.New("start date must be in the future")
+	}
+
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	if group.Status != "confirmed" {
+		return model.BookingGroupResponse{}, errors.New("booking group is not in a confirmed state")
+	}
+
+	if group.StartDate.Before(time.Now()) {
+		return model.BookingGroupResponse{}, errors.New("cannot amend a booking group that has already started")
+	}
+
+	bookings, err := s.groupRepo.GetBookings(id)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	// Only bookings still held by the group move with it
+	now := time.Now()
+	var active []model.Booking
+	var history []model.BookingHistory
+	for _, booking := range bookings {
+		if booking.Status != "confirmed" {
+			continue
+		}
+
+		room, err := s.roomRepo.GetByID(booking.RoomID)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+
+		breakdown, err := s.pricingService.PriceStay(room, req.StartDate, req.EndDate)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+
+		roomType, err := roomTypeOf(s.roomTypeRepo, room)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+
+		amended := booking
+		amended.StartDate = req.StartDate
+		amended.EndDate = req.EndDate
+		amended.TotalPrice = breakdown.Total
+		amended.PriceBreakdown = breakdown
+		amendDeposit(&amended, roomType, now)
+
+		active = append(active, amended)
+		history = append(history, amendmentHistory(booking, amended, changedBy))
+	}
+
+	// New balances are authorized before the group is moved; if any is declined, or the
+	// rooms cannot be moved, the authorizations already made are released
+	transactions := make([]payment.Transaction, len(active))
+	voidAll := func() {
+		for _, transaction := range transactions {
+			if transaction.ID != "" {
+				s.paymentService.VoidTransaction(transaction)
+			}
+		}
+	}
+
+	for i, booking := range active {
+		transaction, err := s.paymentService.ReauthorizeBooking(booking, req.PaymentToken)
+		if err != nil {
+			voidAll()
+			return model.BookingGroupResponse{}, err
+		}
+		transactions[i] = transaction
+	}
+
+	group.StartDate = req.StartDate
+	group.EndDate = req.EndDate
+
+	amendedGroup, amended, err := s.groupRepo.Amend(group, active, history)
+	if err != nil {
+		voidAll()
+		return model.BookingGroupResponse{}, err
+	}
+
+	// The bookings are amended in the order they were given. They stand if their old
+	// authorizations cannot be swapped for the new ones; the payments can be settled by hand.
+	for i, booking := range amended {
+		notifyBooking(s.hub, bookingAmended, booking)
+
+		if err := s.paymentService.ApplyReauthorization(booking, transactions[i]); err != nil {
+			log.Printf("Failed to update payment for amended booking %s: %v", booking.ID, err)
+		}
+	}
+
+	// Reload so cancelled bookings are still reported with the group
+	bookings, err = s.groupRepo.GetBookings(id)
+	if err != nil {
+		return model.BookingGroupResponse{}, err
+	}
+
+	return s.toResponse(amendedGroup, bookings)
+}
+
+// toResponse builds a group response, totalling the price of its confirmed bookings
+func (s *BookingGroupService) toResponse(group model.BookingGroup, bookings []model.Booking) (model.BookingGroupResponse, error) {
+	response := model.BookingGroupResponse{
+		ID:        group.ID,
+		UserID:    group.UserID,
+		Name:      group.Name,
+		StartDate: group.StartDate,
+		EndDate:   group.EndDate,
+		Status:    group.Status,
+		Bookings:  []model.BookingResponse{},
+		CreatedAt: group.CreatedAt,
+	}
+
+	for _, booking := range bookings {
+		room, err := s.roomRepo.GetByID(booking.RoomID)
+		if err != nil {
+			return model.BookingGroupResponse{}, err
+		}
+
+		if booking.Status == "confirmed" {
+			response.TotalPrice = response.TotalPrice.Add(booking.TotalPrice)
+			response.TaxTotal = response.TaxTotal.Add(booking.TaxTotal)
+		}
+
+		response.Bookings = append(response.Bookings, newBookingResponse(booking, room))
+	}
+
+	return response, nil
+}
@@ -2,91 +2,262 @@ package service
 
 import (
 	"errors"
-	"math"
+	"fmt"
+	"log"
 	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
 	"github.com/flaminshinjan/address.ai/services/room/internal/model"
 	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
 )
 
 // BookingService handles business logic for bookings
 type BookingService struct {
-	bookingRepo *repository.BookingRepository
-	roomRepo    *repository.RoomRepository
+	bookingRepo     *repository.BookingRepository
+	roomRepo        *repository.RoomRepository
+	roomTypeRepo    *repository.RoomTypeRepository
+	pricingService  *PricingService
+	policyService   *CancellationPolicyService
+	waitlistService *WaitlistService
+	invoiceService  *InvoiceService
+	currencyService *CurrencyService
+	paymentService  *PaymentService
+	folioService    *FolioService
+	hub             *notify.Hub
+	holdTTL         time.Duration
 }
 
-// NewBookingService creates a new BookingService
-func NewBookingService(bookingRepo *repository.BookingRepository, roomRepo *repository.RoomRepository) *BookingService {
+// Types of the events sent to a guest when their booking changes
+const (
+	bookingStatusChanged = "booking.status"
+	bookingAmended       = "booking.amended"
+)
+
+// notifyBooking tells a booking's guest that it has changed, as it is now
+func notifyBooking(hub *notify.Hub, eventType string, booking model.Booking) {
+	hub.Publish(booking.UserID, notify.Event{
+		Type:   eventType,
+		ID:     booking.ID,
+		Status: booking.Status,
+	})
+}
+
+// NewBookingService creates a new BookingService. Checkout holds last for holdTTL, and
+// guests are told of changes to their bookings through hub.
+func NewBookingService(bookingRepo *repository.BookingRepository, roomRepo *repository.RoomRepository, roomTypeRepo *repository.RoomTypeRepository, pricingService *PricingService, policyService *CancellationPolicyService, waitlistService *WaitlistService, invoiceService *InvoiceService, currencyService *CurrencyService, paymentService *PaymentService, folioService *FolioService, hub *notify.Hub, holdTTL time.Duration) *BookingService {
 	return &BookingService{
-		bookingRepo: bookingRepo,
-		roomRepo:    roomRepo,
+		bookingRepo:     bookingRepo,
+		roomRepo:        roomRepo,
+		roomTypeRepo:    roomTypeRepo,
+		pricingService:  pricingService,
+		policyService:   policyService,
+		waitlistService: waitlistService,
+		invoiceService:  invoiceService,
+		currencyService: currencyService,
+		paymentService:  paymentService,
+		folioService:    folioService,
+		hub:             hub,
+		holdTTL:         holdTTL,
 	}
 }
 
-// CreateBooking creates a new booking
+// CreateBooking books a room. The room is held while the stay is authorized on the
+// guest's payment method and the booking is only confirmed once that succeeds.
 func (s *BookingService) CreateBooking(userID string, req model.BookingRequest) (model.BookingResponse, error) {
+	if req.PaymentToken == "" {
+		return model.BookingResponse{}, errors.New("payment token is required")
+	}
+
+	expiresAt := time.Now().Add(s.holdTTL)
+	hold, room, err := s.reserve(userID, req, "held", &expiresAt)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	booking, err := s.confirm(hold, req.PaymentToken)
+	if err != nil {
+		// Give the room back rather than leave it held until the hold runs out
+		if releaseErr := s.ReleaseHold(hold.ID); releaseErr != nil {
+			log.Printf("Failed to release hold %s: %v", hold.ID, releaseErr)
+		}
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(booking, room), nil
+}
+
+// HoldRoom reserves a room for the guest while they check out. The hold occupies the
+// room like a booking until it is confirmed, released or runs out after the hold TTL.
+func (s *BookingService) HoldRoom(userID string, req model.BookingRequest) (model.BookingResponse, error) {
+	expiresAt := time.Now().Add(s.holdTTL)
+	hold, room, err := s.reserve(userID, req, "held", &expiresAt)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(hold, room), nil
+}
+
+// confirm authorizes a held booking's stay and confirms it. The authorization is voided
+// if the hold can no longer be confirmed. A deposit the booking takes is then charged to
+// the same payment method; if that fails the booking stands, and the deposit can be paid
+// until it is due.
+func (s *BookingService) confirm(hold model.Booking, token string) (model.Booking, error) {
+	if _, err := s.paymentService.AuthorizeBooking(hold, token); err != nil {
+		return model.Booking{}, err
+	}
+
+	booking, err := s.bookingRepo.ConfirmHold(hold.ID)
+	if err != nil {
+		if voidErr := s.paymentService.VoidBooking(hold.ID); voidErr != nil {
+			log.Printf("Failed to void payment for booking %s: %v", hold.ID, voidErr)
+		}
+		return model.Booking{}, err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, booking)
+
+	if !booking.DepositOutstanding() {
+		return booking, nil
+	}
+
+	if _, err := s.paymentService.PayDeposit(booking, token); err != nil {
+		log.Printf("Failed to take deposit for booking %s: %v", booking.ID, err)
+		return booking, nil
+	}
+
+	return s.bookingRepo.GetByID(booking.ID)
+}
+
+// roomTypeOf gets the type of a room. Rooms without a type get the zero RoomType, which
+// takes no deposit.
+func roomTypeOf(roomTypeRepo *repository.RoomTypeRepository, room model.Room) (model.RoomType, error) {
+	if room.TypeID == "" {
+		return model.RoomType{}, nil
+	}
+
+	return roomTypeRepo.GetByID(room.TypeID)
+}
+
+// stayDeposit works out the deposit a room type takes on a stay priced at breakdown, and
+// when it falls due: within the type's deadline from now, but no later than the start of
+// the stay
+func stayDeposit(roomType model.RoomType, breakdown model.PriceBreakdown, startDate, now time.Time) (money.Money, *time.Time) {
+	deposit := roomType.Deposit(breakdown.Total.Add(breakdown.Taxes.Total))
+	if !deposit.IsPositive() {
+		return deposit, nil
+	}
+
+	dueAt := now.Add(time.Duration(roomType.DepositDueHours) * time.Hour)
+	if dueAt.After(startDate) {
+		dueAt = startDate
+	}
+	return deposit, &dueAt
+}
+
+// reserve validates and prices a stay and books the room with the given status
+func (s *BookingService) reserve(userID string, req model.BookingRequest, status string, holdExpiresAt *time.Time) (model.Booking, model.Room, error) {
 	// Validate dates
-	if req.StartDate.After(req.EndDate) {
-		return model.BookingResponse{}, errors.New("start date must be before end date")
+	if !req.StartDate.Before(req.EndDate) {
+		return model.Booking{}, model.Room{}, errors.New("start date must be before end date")
 	}
 
 	if req.StartDate.Before(time.Now()) {
-		return model.BookingResponse{}, errors.New("start date must be in the future")
+		return model.Booking{}, model.Room{}, errors.New("start date must be in the future")
 	}
 
 	// Check if room exists
 	room, err := s.roomRepo.GetByID(req.RoomID)
 	if err != nil {
-		return model.BookingResponse{}, err
+		return model.Booking{}, model.Room{}, err
+	}
+
+	// Check if room is in service; maintenance windows are checked with the dates
+	outOfService, err := s.roomRepo.IsOutOfService(room.ID)
+	if err != nil {
+		return model.Booking{}, model.Room{}, err
 	}
 
-	// Check if room is available
-	if room.Status != "available" {
-		return model.BookingResponse{}, errors.New("room is not available")
+	if outOfService {
+		return model.Booking{}, model.Room{}, errors.New("room is not available")
 	}
 
-	// Check if room is available for the given dates
-	available, err := s.bookingRepo.CheckRoomAvailability(req.RoomID, req.StartDate, req.EndDate)
+	// Price the stay; the breakdown is stored with the booking
+	breakdown, err := s.pricingService.PriceStay(room, req.StartDate, req.EndDate)
 	if err != nil {
-		return model.BookingResponse{}, err
+		return model.Booking{}, model.Room{}, err
 	}
 
-	if !available {
-		return model.BookingResponse{}, errors.New("room is not available for the given dates")
+	// Room types for high-value rooms take a deposit, due within the type's deadline
+	roomType, err := roomTypeOf(s.roomTypeRepo, room)
+	if err != nil {
+		return model.Booking{}, model.Room{}, err
 	}
+	deposit, depositDueAt := stayDeposit(roomType, breakdown, req.StartDate, time.Now())
 
-	// Calculate total price
-	days := int(math.Ceil(req.EndDate.Sub(req.StartDate).Hours() / 24))
-	totalPrice := room.PricePerDay * float64(days)
+	// The guest's currency is recorded at today's rate, so later rate changes do not
+	// alter what they were shown
+	var rate model.CurrencyRate
+	if req.Currency != "" {
+		rate, err = s.currencyService.GetRate(req.Currency)
+		if err != nil {
+			return model.Booking{}, model.Room{}, err
+		}
+	}
 
 	// Create booking
 	booking := model.Booking{
-		RoomID:     req.RoomID,
-		UserID:     userID,
-		StartDate:  req.StartDate,
-		EndDate:    req.EndDate,
-		TotalPrice: totalPrice,
-		Status:     "confirmed",
+		RoomID:          req.RoomID,
+		UserID:          userID,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		TotalPrice:      breakdown.Total,
+		DisplayCurrency: rate.Currency,
+		ExchangeRate:    rate.Rate,
+		Status:          status,
+		PriceBreakdown:  breakdown,
+		HoldExpiresAt:   holdExpiresAt,
+		DepositAmount:   deposit,
+		DepositDueAt:    depositDueAt,
 	}
 
-	createdBooking, err := s.bookingRepo.Create(booking)
+	// Availability is checked and the booking inserted in one transaction, so a
+	// concurrent request for the same dates fails with model.ErrBookingConflict
+	createdBooking, err := s.bookingRepo.CreateIfAvailable(booking)
 	if err != nil {
-		return model.BookingResponse{}, err
+		return model.Booking{}, model.Room{}, err
 	}
 
-	// Create response
-	response := model.BookingResponse{
-		ID:         createdBooking.ID,
-		Room:       room.ToResponse(),
-		UserID:     createdBooking.UserID,
-		StartDate:  createdBooking.StartDate,
-		EndDate:    createdBooking.EndDate,
-		TotalPrice: createdBooking.TotalPrice,
-		Status:     createdBooking.Status,
-		CreatedAt:  createdBooking.CreatedAt,
-	}
+	return createdBooking, room, nil
+}
 
-	return response, nil
+// newBookingResponse builds the response for a booking in the given room
+func newBookingResponse(booking model.Booking, room model.Room) model.BookingResponse {
+	return model.BookingResponse{
+		ID:              booking.ID,
+		Room:            room.ToResponse(),
+		UserID:          booking.UserID,
+		StartDate:       booking.StartDate,
+		EndDate:         booking.EndDate,
+		TotalPrice:      booking.TotalPrice,
+		TaxTotal:        booking.TaxTotal,
+		DisplayCurrency: booking.DisplayCurrency,
+		ExchangeRate:    booking.ExchangeRate,
+		DisplayTotal:    booking.DisplayTotal,
+		Status:          booking.Status,
+		PaymentStatus:   booking.PaymentStatus,
+		DepositAmount:   booking.DepositAmount,
+		DepositDueAt:    booking.DepositDueAt,
+		AmountPaid:      booking.AmountPaid,
+		BalanceDue:      booking.BalanceDue,
+		GroupID:         booking.GroupID,
+		PriceBreakdown:  booking.PriceBreakdown,
+		CheckedInAt:     booking.CheckedInAt,
+		CheckedOutAt:    booking.CheckedOutAt,
+		HoldExpiresAt:   booking.HoldExpiresAt,
+		ExternalSource:  booking.ExternalSource,
+		CreatedAt:       booking.CreatedAt,
+	}
 }
 
 // GetBookingByID gets a booking by ID
@@ -101,18 +272,7 @@ func (s *BookingService) GetBookingByID(id string) (model.BookingResponse, error
 		return model.BookingResponse{}, err
 	}
 
-	response := model.BookingResponse{
-		ID:         booking.ID,
-		Room:       room.ToResponse(),
-		UserID:     booking.UserID,
-		StartDate:  booking.StartDate,
-		EndDate:    booking.EndDate,
-		TotalPrice: booking.TotalPrice,
-		Status:     booking.Status,
-		CreatedAt:  booking.CreatedAt,
-	}
-
-	return response, nil
+	return newBookingResponse(booking, room), nil
 }
 
 // GetBookingsByUserID gets bookings by user ID
@@ -129,39 +289,453 @@ func (s *BookingService) GetBookingsByUserID(userID string, limit, offset int) (
 			return nil, err
 		}
 
-		response := model.BookingResponse{
-			ID:         booking.ID,
-			Room:       room.ToResponse(),
-			UserID:     booking.UserID,
-			StartDate:  booking.StartDate,
-			EndDate:    booking.EndDate,
-			TotalPrice: booking.TotalPrice,
-			Status:     booking.Status,
-			CreatedAt:  booking.CreatedAt,
+		responses = append(responses, newBookingResponse(booking, room))
+	}
+
+	return responses, nil
+}
+
+// CancelBooking cancels a booking, charging the penalty of the cancellation policy
+// that applies. The refund, penalty and the policy rule used are recorded and returned,
+// and the freed room is offered to the waitlist.
+func (s *BookingService) CancelBooking(id, cancelledBy string) (model.BookingCancellation, error) {
+	booking, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+
+	if booking.Status != "confirmed" {
+		return model.BookingCancellation{}, errors.New("booking is not in a confirmed state")
+	}
+
+	now := time.Now()
+	if booking.StartDate.Before(now) {
+		return model.BookingCancellation{}, errors.New("cannot cancel a booking that has already started")
+	}
+
+	return s.cancel(booking, cancelledBy, now, "cancelled", s.bookingRepo.Cancel)
+}
+
+// cancel cancels a confirmed booking at the given time under its cancellation policy,
+// recording the cancellation with record, which leaves the booking in status. The nights
+// from today on are offered to the waitlist.
+func (s *BookingService) cancel(booking model.Booking, cancelledBy string, now time.Time, status string, record func(model.BookingCancellation) (model.BookingCancellation, error)) (model.BookingCancellation, error) {
+	room, err := s.roomRepo.GetByID(booking.RoomID)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+
+	cancellation, err := s.policyService.Evaluate(booking, room.TypeID, now)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+	cancellation.CancelledBy = cancelledBy
+
+	cancellation, err = record(cancellation)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+	booking.Status = status
+	notifyBooking(s.hub, bookingStatusChanged, booking)
+
+	// The penalty is taken from the stay's authorization and the rest released; the
+	// cancellation stands if the gateway fails, and the payment can be settled by hand
+	if err := s.paymentService.SettleCancellation(booking.ID, cancellation.Penalty); err != nil {
+		log.Printf("Failed to settle payment for cancelled booking %s: %v", booking.ID, err)
+	}
+
+	// Nights already past cannot be sold again
+	freedFrom := booking.StartDate
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC); today.After(freedFrom) {
+		freedFrom = today
+	}
+	if !freedFrom.Before(booking.EndDate) {
+		return cancellation, nil
+	}
+
+	// The cancellation stands even if the waitlist cannot be served
+	if err := s.waitlistService.OfferSlot(booking.RoomID, freedFrom, booking.EndDate); err != nil {
+		log.Printf("Failed to offer room %s to the waitlist: %v", booking.RoomID, err)
+	}
+
+	return cancellation, nil
+}
+
+// GetCancellation gets the recorded cancellation of a booking
+func (s *BookingService) GetCancellation(id string) (model.BookingCancellation, error) {
+	return s.bookingRepo.GetCancellation(id)
+}
+
+// AmendBooking changes a booking's dates and/or room. The new stay is re-checked for
+// availability, ignoring the booking itself, and re-priced; the old and new values are
+// recorded in the booking history. When the balance changes the stay is authorized again
+// for the new amount.
+func (s *BookingService) AmendBooking(id, changedBy string, req model.BookingAmendRequest) (model.BookingResponse, error) {
+	booking, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	// Fields left empty keep their current value
+	amended := booking
+	if req.RoomID != "" {
+		amended.RoomID = req.RoomID
+	}
+	if !req.StartDate.IsZero() {
+		amended.StartDate = req.StartDate
+	}
+	if !req.EndDate.IsZero() {
+		amended.EndDate = req.EndDate
+	}
+
+	now := time.Now()
+	if err := checkAmendment(booking, amended, now); err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	room, err := s.roomRepo.GetByID(amended.RoomID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	if amended.RoomID != booking.RoomID {
+		outOfService, err := s.roomRepo.IsOutOfService(room.ID)
+		if err != nil {
+			return model.BookingResponse{}, err
 		}
 
-		responses = append(responses, response)
+		if outOfService {
+			return model.BookingResponse{}, errors.New("room is not available")
+		}
 	}
 
-	return responses, nil
+	// Re-price the whole stay with the current rules
+	breakdown, err := s.pricingService.PriceStay(room, amended.StartDate, amended.EndDate)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+	amended.TotalPrice = breakdown.Total
+	amended.PriceBreakdown = breakdown
+
+	roomType, err := roomTypeOf(s.roomTypeRepo, room)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+	amendDeposit(&amended, roomType, now)
+
+	history := amendmentHistory(booking, amended, changedBy)
+
+	// The new balance is authorized before the amendment is saved, and released if it cannot be
+	transaction, err := s.paymentService.ReauthorizeBooking(amended, req.PaymentToken)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	updatedBooking, err := s.bookingRepo.Amend(amended, history)
+	if err != nil {
+		if transaction.ID != "" {
+			s.paymentService.VoidTransaction(transaction)
+		}
+		return model.BookingResponse{}, err
+	}
+	notifyBooking(s.hub, bookingAmended, updatedBooking)
+
+	// The amendment stands if the old authorization cannot be swapped for the new one; the
+	// payments can be settled by hand
+	if err := s.paymentService.ApplyReauthorization(updatedBooking, transaction); err != nil {
+		log.Printf("Failed to update payment for amended booking %s: %v", updatedBooking.ID, err)
+	}
+
+	return newBookingResponse(updatedBooking, room), nil
 }
 
-// CancelBooking cancels a booking
-func (s *BookingService) CancelBooking(id string) error {
+// checkAmendment validates the changes an amendment makes to a booking at the given time.
+// Confirmed bookings can be amended freely until their stay starts; once it has, and for
+// guests who have checked in, only the end date can move.
+func checkAmendment(booking, amended model.Booking, now time.Time) error {
+	if booking.Status != "confirmed" && booking.Status != "checked_in" {
+		return errors.New("booking is not in a confirmed or checked-in state")
+	}
+
+	if amended.RoomID == booking.RoomID && amended.StartDate.Equal(booking.StartDate) && amended.EndDate.Equal(booking.EndDate) {
+		return errors.New("no changes requested")
+	}
+
+	// Validate dates
+	if !amended.StartDate.Before(amended.EndDate) {
+		return errors.New("start date must be before end date")
+	}
+
+	if booking.Status == "checked_in" || booking.StartDate.Before(now) {
+		if !amended.StartDate.Equal(booking.StartDate) || amended.RoomID != booking.RoomID {
+			return errors.New("only the end date of a stay that has started can be changed")
+		}
+		if amended.EndDate.Before(now) {
+			return errors.New("end date must be in the future")
+		}
+	} else if amended.StartDate.Before(now) {
+		return errors.New("start date must be in the future")
+	}
+
+	return nil
+}
+
+// amendDeposit sets the deposit of an amended booking from its new price. A deposit already
+// due keeps its deadline unless the stay now starts before it; one the amendment
+// introduces is due within the room type's deadline from now.
+func amendDeposit(booking *model.Booking, roomType model.RoomType, now time.Time) {
+	deposit, dueAt := stayDeposit(roomType, booking.PriceBreakdown, booking.StartDate, now)
+	booking.DepositAmount = deposit
+	if dueAt == nil || booking.DepositDueAt == nil || dueAt.Before(*booking.DepositDueAt) {
+		booking.DepositDueAt = dueAt
+	}
+}
+
+// amendmentHistory records the old and new values of an amended booking
+func amendmentHistory(booking, amended model.Booking, changedBy string) model.BookingHistory {
+	return model.BookingHistory{
+		BookingID:     booking.ID,
+		ChangedBy:     changedBy,
+		Action:        "amended",
+		OldRoomID:     booking.RoomID,
+		NewRoomID:     amended.RoomID,
+		OldStartDate:  booking.StartDate,
+		NewStartDate:  amended.StartDate,
+		OldEndDate:    booking.EndDate,
+		NewEndDate:    amended.EndDate,
+		OldTotalPrice: booking.TotalPrice,
+		NewTotalPrice: amended.TotalPrice,
+	}
+}
+
+// GetBookingHistory gets the change history of a booking
+func (s *BookingService) GetBookingHistory(id string) ([]model.BookingHistory, error) {
+	return s.bookingRepo.GetHistory(id)
+}
+
+// CheckIn checks a guest in, which is only allowed on the booking's start date; a guest
+// who has not arrived by the end of that day is a no-show. The room must be ready and is
+// marked occupied, and the stay's folio is opened.
+func (s *BookingService) CheckIn(id string) (model.BookingResponse, error) {
+	booking, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	if booking.Status != "confirmed" {
+		return model.BookingResponse{}, errors.New("booking is not in a confirmed state")
+	}
+
+	// Validate the check-in window
+	today := time.Now().Format("2006-01-02")
+	if today < booking.StartDate.Format("2006-01-02") {
+		return model.BookingResponse{}, errors.New("check-in is not open before the start date")
+	}
+
+	if today > booking.StartDate.Format("2006-01-02") {
+		return model.BookingResponse{}, errors.New("check-in closed at the end of the arrival day")
+	}
+
+	// The balance is settled over the stay, but the deposit must be in first
+	if booking.DepositOutstanding() {
+		return model.BookingResponse{}, errors.New("deposit must be paid before check-in")
+	}
+
+	checkedIn, err := s.bookingRepo.CheckIn(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, checkedIn)
+
+	room, err := s.roomRepo.GetByID(checkedIn.RoomID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(checkedIn, room), nil
+}
+
+// CheckOut checks a guest out, completing the booking, closing its folio into the final
+// invoice and flagging the room for housekeeping
+func (s *BookingService) CheckOut(id string) (model.BookingResponse, error) {
 	booking, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	if booking.Status != "checked_in" {
+		return model.BookingResponse{}, errors.New("booking is not checked in")
+	}
+
+	// The last room-service orders are charged to the folio as it closes; without them
+	// the folio cannot be closed, and nothing is captured
+	orders, err := s.folioService.RoomCharges(id)
+	if err != nil {
+		return model.BookingResponse{}, fmt.Errorf("failed to get room-service charges: %w", err)
+	}
+
+	// The card on file is charged before the folio closes so the payment shows on the
+	// invoice; if the gateway fails the balance is left to settle at the desk
+	if err := s.paymentService.SettleCheckOut(booking, orders); err != nil {
+		log.Printf("Failed to capture payment for booking %s: %v", id, err)
+	}
+
+	checkedOut, err := s.bookingRepo.CheckOut(id, orders)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, checkedOut)
+
+	// The guest has left either way; an invoice that fails here is issued when first requested
+	if _, err := s.invoiceService.IssueForBooking(id); err != nil {
+		log.Printf("Failed to issue invoice for booking %s: %v", id, err)
+	}
+
+	room, err := s.roomRepo.GetByID(checkedOut.RoomID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(checkedOut, room), nil
+}
+
+// MarkNoShows marks confirmed bookings whose arrival day has passed without a check-in as
+// no-shows. Each is charged under its cancellation policy as a cancellation would be, and
+// the rest of its nights are offered to the waitlist.
+func (s *BookingService) MarkNoShows() error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	bookings, err := s.bookingRepo.ListNoShows(today)
 	if err != nil {
 		return err
 	}
 
+	marked := 0
+	for _, booking := range bookings {
+		if _, err := s.cancel(booking, "system", now, "no_show", s.bookingRepo.MarkNoShow); err != nil {
+			log.Printf("Failed to mark booking %s as a no-show: %v", booking.ID, err)
+			continue
+		}
+		marked++
+	}
+
+	if marked > 0 {
+		log.Printf("Marked %d bookings as no-shows", marked)
+	}
+
+	return nil
+}
+
+// ConfirmHold turns a checkout hold into a confirmed booking at the price it was held at,
+// once the stay is authorized on the guest's payment method
+func (s *BookingService) ConfirmHold(id string, req model.PaymentRequest) (model.BookingResponse, error) {
+	hold, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	if hold.Status != "held" {
+		return model.BookingResponse{}, errors.New("booking is not held")
+	}
+
+	booking, err := s.confirm(hold, req.PaymentToken)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	room, err := s.roomRepo.GetByID(booking.RoomID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(booking, room), nil
+}
+
+// PayDeposit pays what remains of a confirmed booking's deposit
+func (s *BookingService) PayDeposit(id string, req model.PaymentRequest) (model.BookingResponse, error) {
+	booking, err := s.bookingRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
 	if booking.Status != "confirmed" {
-		return errors.New("booking is not in a confirmed state")
+		return model.BookingResponse{}, errors.New("booking is not in a confirmed state")
+	}
+
+	if !booking.DepositOutstanding() {
+		return model.BookingResponse{}, errors.New("booking has no deposit to pay")
 	}
 
-	if booking.StartDate.Before(time.Now()) {
-		return errors.New("cannot cancel a booking that has already started")
+	if _, err := s.paymentService.PayDeposit(booking, req.PaymentToken); err != nil {
+		return model.BookingResponse{}, err
 	}
 
-	return s.bookingRepo.UpdateStatus(id, "cancelled")
+	return s.GetBookingByID(id)
+}
+
+// CancelUnpaidDeposits cancels the confirmed bookings whose deposit is past due, as if
+// the guest had cancelled, so the cancellation policy applies and the room goes back to
+// the waitlist. A deposit falls due by the start of the stay at the latest, so bookings
+// that have just started are cancelled too; their guests cannot check in without it. A
+// booking whose deposit is paid while the job runs is left alone.
+func (s *BookingService) CancelUnpaidDeposits() error {
+	bookings, err := s.bookingRepo.ListUnpaidDeposits(time.Now())
+	if err != nil {
+		return err
+	}
+
+	cancelled := 0
+	for _, booking := range bookings {
+		if _, err := s.cancel(booking, "system", time.Now(), "cancelled", s.bookingRepo.CancelUnpaidDeposit); err != nil {
+			log.Printf("Failed to cancel booking %s with an unpaid deposit: %v", booking.ID, err)
+			continue
+		}
+		cancelled++
+	}
+
+	if cancelled > 0 {
+		log.Printf("Cancelled %d bookings with unpaid deposits", cancelled)
+	}
+
+	return nil
+}
+
+// ReleaseHold gives up a checkout hold and offers the room to the waitlist
+func (s *BookingService) ReleaseHold(id string) error {
+	booking, err := s.bookingRepo.ReleaseHold(id)
+	if err != nil {
+		return err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, booking)
+
+	if err := s.waitlistService.OfferSlot(booking.RoomID, booking.StartDate, booking.EndDate); err != nil {
+		log.Printf("Failed to offer room %s to the waitlist: %v", booking.RoomID, err)
+	}
+
+	return nil
+}
+
+// ReapHolds expires the checkout holds that have run out and offers their rooms to the
+// waitlist. Waitlist offers run out through WaitlistService.ExpireOffers.
+func (s *BookingService) ReapHolds() error {
+	expired, err := s.bookingRepo.ExpireHolds()
+	if err != nil {
+		return err
+	}
+
+	if len(expired) > 0 {
+		log.Printf("Expired %d booking holds", len(expired))
+	}
+
+	for _, booking := range expired {
+		notifyBooking(s.hub, bookingStatusChanged, booking)
+		if err := s.waitlistService.OfferSlot(booking.RoomID, booking.StartDate, booking.EndDate); err != nil {
+			log.Printf("Failed to offer room %s to the waitlist: %v", booking.RoomID, err)
+		}
+	}
+
+	return nil
 }
 
 // ListBookings lists all bookings
@@ -178,18 +752,7 @@ func (s *BookingService) ListBookings(limit, offset int) ([]model.BookingRespons
 			return nil, err
 		}
 
-		response := model.BookingResponse{
-			ID:         booking.ID,
-			Room:       room.ToResponse(),
-			UserID:     booking.UserID,
-			StartDate:  booking.StartDate,
-			EndDate:    booking.EndDate,
-			TotalPrice: booking.TotalPrice,
-			Status:     booking.Status,
-			CreatedAt:  booking.CreatedAt,
-		}
-
-		responses = append(responses, response)
+		responses = append(responses, newBookingResponse(booking, room))
 	}
 
 	return responses, nil
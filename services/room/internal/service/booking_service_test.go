@@ -0,0 +1,60 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+func TestCheckAmendmentExtendsCheckedInStay(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	booking := model.Booking{
+		RoomID:    "room-1",
+		StartDate: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+		Status:    "checked_in",
+	}
+
+	extended := booking
+	extended.EndDate = time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	if err := checkAmendment(booking, extended, now); err != nil {
+		t.Fatalf("extending a checked-in stay was refused: %v", err)
+	}
+
+	moved := extended
+	moved.StartDate = time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	if err := checkAmendment(booking, moved, now); err == nil {
+		t.Error("moving the start of a checked-in stay was accepted")
+	}
+
+	changedRoom := extended
+	changedRoom.RoomID = "room-2"
+	if err := checkAmendment(booking, changedRoom, now); err == nil {
+		t.Error("moving a checked-in stay to another room was accepted")
+	}
+
+	ended := booking
+	ended.EndDate = time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	if err := checkAmendment(booking, ended, now); err == nil {
+		t.Error("ending a checked-in stay in the past was accepted")
+	}
+}
+
+func TestCheckAmendmentRefusesClosedBookings(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, status := range []string{"held", "completed", "cancelled", "no_show", "expired", "released", "external"} {
+		booking := model.Booking{
+			RoomID:    "room-1",
+			StartDate: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC),
+			Status:    status,
+		}
+
+		amended := booking
+		amended.EndDate = time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+		if err := checkAmendment(booking, amended, now); err == nil {
+			t.Errorf("amending a %s booking was accepted", status)
+		}
+	}
+}
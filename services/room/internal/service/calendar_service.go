@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/ical"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// CalendarService handles iCalendar export and import of room availability
+type CalendarService struct {
+	bookingRepo     *repository.BookingRepository
+	maintenanceRepo *repository.MaintenanceRepository
+	roomRepo        *repository.RoomRepository
+	roomTypeRepo    *repository.RoomTypeRepository
+}
+
+// NewCalendarService creates a new CalendarService
+func NewCalendarService(bookingRepo *repository.BookingRepository, maintenanceRepo *repository.MaintenanceRepository, roomRepo *repository.RoomRepository, roomTypeRepo *repository.RoomTypeRepository) *CalendarService {
+	return &CalendarService{
+		bookingRepo:     bookingRepo,
+		maintenanceRepo: maintenanceRepo,
+		roomRepo:        roomRepo,
+		roomTypeRepo:    roomTypeRepo,
+	}
+}
+
+// ExportRoom renders the iCalendar feed of a room's current and upcoming stays and blocks
+func (s *CalendarService) ExportRoom(roomID string) ([]byte, error) {
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.roomEvents(room, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, "Room "+room.Number, events); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExportRoomType renders one iCalendar feed of the stays and blocks of every room of a type
+func (s *CalendarService) ExportRoomType(roomTypeID string) ([]byte, error) {
+	roomType, err := s.roomTypeRepo.GetByID(roomTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := s.roomRepo.ListByType(roomType.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ical.Event
+	for _, room := range rooms {
+		roomEvents, err := s.roomEvents(room, "Room "+room.Number+": ")
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, roomEvents...)
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, roomType.Name, events); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// roomEvents turns everything that takes a room today or later into calendar events: stays,
+// holds, external blocks and maintenance windows, so that nothing can be booked elsewhere
+// for dates the room could not be booked for here. Only the dates are published, never who
+// is staying or why the room is blocked.
+func (s *CalendarService) roomEvents(room model.Room, prefix string) ([]ical.Event, error) {
+	from := today()
+
+	bookings, err := s.bookingRepo.ListBlocking(room.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := s.maintenanceRepo.ListBlocking(room.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ical.Event
+	for _, booking := range bookings {
+		summary := "Blocked"
+		switch booking.Status {
+		case "confirmed", "checked_in":
+			summary = "Reserved"
+		case "external":
+			summary = "Blocked (" + booking.ExternalSource + ")"
+		}
+
+		events = append(events, ical.Event{
+			UID:     booking.ID + "@address.ai",
+			Summary: prefix + summary,
+			Start:   booking.StartDate,
+			End:     booking.EndDate,
+			Stamp:   booking.UpdatedAt,
+		})
+	}
+
+	for _, window := range windows {
+		events = append(events, ical.Event{
+			UID:     window.ID + "@address.ai",
+			Summary: prefix + "Blocked",
+			Start:   window.StartDate,
+			End:     window.EndDate,
+			Stamp:   window.UpdatedAt,
+		})
+	}
+
+	return events, nil
+}
+
+// ImportRoom reads an iCalendar file of external blocks from source, such as an OTA's
+// reservations, and synchronizes them into the room's bookings. Blocks that have already
+// ended are ignored.
+func (s *CalendarService) ImportRoom(roomID, source string, r io.Reader) (model.CalendarImportResult, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return model.CalendarImportResult{}, errors.New("source is required")
+	}
+
+	if len(source) > 50 {
+		return model.CalendarImportResult{}, errors.New("source cannot exceed 50 characters")
+	}
+
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return model.CalendarImportResult{}, err
+	}
+
+	events, err := ical.Parse(r)
+	if err != nil {
+		return model.CalendarImportResult{}, fmt.Errorf("invalid calendar: %v", err)
+	}
+
+	from := today()
+
+	summaries := make(map[string]string)
+	var blocks []model.Booking
+	for _, event := range events {
+		if !event.End.After(from) {
+			continue
+		}
+
+		if _, duplicate := summaries[event.UID]; duplicate {
+			return model.CalendarImportResult{}, fmt.Errorf("invalid calendar: duplicate event %s", event.UID)
+		}
+		summaries[event.UID] = event.Summary
+
+		blocks = append(blocks, model.Booking{
+			StartDate:   event.Start,
+			EndDate:     event.End,
+			ExternalUID: event.UID,
+		})
+	}
+
+	result, err := s.bookingRepo.ImportExternal(room.ID, source, blocks)
+	if err != nil {
+		return model.CalendarImportResult{}, err
+	}
+
+	for i := range result.Conflicts {
+		result.Conflicts[i].Summary = summaries[result.Conflicts[i].UID]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+func TestRoomEventsBlockEverythingThatTakesTheRoom(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	start := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	bookings := sqlmock.NewRows([]string{"id", "room_id", "user_id", "start_date", "end_date", "total_price", "tax_total", "display_currency", "exchange_rate",
+		"status", "payment_status", "deposit_amount", "deposit_due_at", "amount_paid", "group_id", "price_breakdown", "checked_in_at", "checked_out_at", "hold_expires_at",
+		"external_source", "external_uid", "created_at", "updated_at"})
+	for _, b := range []struct{ id, status, source string }{
+		{"booking-1", "confirmed", ""},
+		{"booking-2", "checked_in", ""},
+		{"booking-3", "held", ""},
+		{"booking-4", "external", "ota"},
+	} {
+		bookings.AddRow(b.id, "room-1", "", start, end, "100.00", "0.00", "", 0.0,
+			b.status, "unpaid", "0.00", nil, "0.00", "", []byte(`{}`), nil, nil, nil,
+			b.source, "", start, start)
+	}
+
+	mock.ExpectQuery(`FROM bookings`).
+		WithArgs("room-1", sqlmock.AnyArg()).
+		WillReturnRows(bookings)
+	mock.ExpectQuery(`FROM maintenance_windows`).
+		WithArgs("room-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "start_date", "end_date", "reason", "assigned_to", "status", "created_by", "created_at", "updated_at"}).
+			AddRow("window-1", "room-1", start, end, "Repainting", "", "scheduled", "user-1", start, start))
+
+	s := NewCalendarService(repository.NewBookingRepository(db), repository.NewMaintenanceRepository(db), nil, nil)
+	events, err := s.roomEvents(model.Room{ID: "room-1", Number: "101"}, "Room 101: ")
+	if err != nil {
+		t.Fatalf("listing the room's events failed: %v", err)
+	}
+
+	// Only the dates are published, so holds and maintenance are as blocked as an OTA's stay
+	want := []string{"Room 101: Reserved", "Room 101: Reserved", "Room 101: Blocked", "Room 101: Blocked (ota)", "Room 101: Blocked"}
+	if len(events) != len(want) {
+		t.Fatalf("exported %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Summary != want[i] {
+			t.Errorf("event %d is %q, want %q", i, event.Summary, want[i])
+		}
+	}
+	if events[4].UID != "window-1@address.ai" {
+		t.Errorf("maintenance window exported as %s", events[4].UID)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// CancellationPolicyService handles business logic for cancellation policies
+type CancellationPolicyService struct {
+	policyRepo *repository.CancellationPolicyRepository
+}
+
+// NewCancellationPolicyService creates a new CancellationPolicyService
+func NewCancellationPolicyService(policyRepo *repository.CancellationPolicyRepository) *CancellationPolicyService {
+	return &CancellationPolicyService{
+		policyRepo: policyRepo,
+	}
+}
+
+// validateCancellationPolicy checks a policy's name and tiers
+func validateCancellationPolicy(policy model.CancellationPolicy) error {
+	if strings.TrimSpace(policy.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if len(policy.Tiers) == 0 {
+		return errors.New("at least one tier is required")
+	}
+
+	seen := make(map[int]bool)
+	for _, tier := range policy.Tiers {
+		if tier.HoursBeforeStart < 0 {
+			return errors.New("hours before start cannot be negative")
+		}
+
+		if seen[tier.HoursBeforeStart] {
+			return errors.New("tiers must have distinct hours before start")
+		}
+		seen[tier.HoursBeforeStart] = true
+
+		if tier.PenaltyValue < 0 {
+			return errors.New("penalty value cannot be negative")
+		}
+
+		switch tier.PenaltyType {
+		case model.PenaltyNone, model.PenaltyNights, model.PenaltyAmount:
+		case model.PenaltyPercent:
+			if tier.PenaltyValue > 100 {
+				return errors.New("percent penalty cannot exceed 100")
+			}
+		default:
+			return errors.New("penalty type must be none, nights, percent or amount")
+		}
+	}
+
+	return nil
+}
+
+// CreatePolicy creates a new cancellation policy
+func (s *CancellationPolicyService) CreatePolicy(policy model.CancellationPolicy) (model.CancellationPolicy, error) {
+	if err := validateCancellationPolicy(policy); err != nil {
+		return model.CancellationPolicy{}, err
+	}
+
+	return s.policyRepo.Create(policy)
+}
+
+// GetPolicyByID gets a cancellation policy by ID
+func (s *CancellationPolicyService) GetPolicyByID(id string) (model.CancellationPolicy, error) {
+	return s.policyRepo.GetByID(id)
+}
+
+// UpdatePolicy updates a cancellation policy
+func (s *CancellationPolicyService) UpdatePolicy(id string, policy model.CancellationPolicy) (model.CancellationPolicy, error) {
+	if err := validateCancellationPolicy(policy); err != nil {
+		return model.CancellationPolicy{}, err
+	}
+
+	// Check if policy exists
+	existingPolicy, err := s.policyRepo.GetByID(id)
+	if err != nil {
+		return model.CancellationPolicy{}, err
+	}
+
+	// Update fields
+	existingPolicy.Name = policy.Name
+	existingPolicy.Description = policy.Description
+	existingPolicy.Tiers = policy.Tiers
+	existingPolicy.IsDefault = policy.IsDefault
+
+	return s.policyRepo.Update(existingPolicy)
+}
+
+// DeletePolicy deletes a cancellation policy
+func (s *CancellationPolicyService) DeletePolicy(id string) error {
+	return s.policyRepo.Delete(id)
+}
+
+// ListPolicies lists all cancellation policies
+func (s *CancellationPolicyService) ListPolicies(limit, offset int) ([]model.CancellationPolicy, error) {
+	return s.policyRepo.List(limit, offset)
+}
+
+// Evaluate works out the penalty and refund for cancelling a booking in a room of the
+// given type at the given time, and which policy rule applies. Both come out of what the
+// guest pays for the stay, taxes included. The policy of a rate rule the booking was
+// priced with takes precedence over the room type's, which takes precedence over the
+// default policy; with no policy at all cancellation is free.
+func (s *CancellationPolicyService) Evaluate(booking model.Booking, roomTypeID string, at time.Time) (model.BookingCancellation, error) {
+	var ruleIDs []string
+	seen := make(map[string]bool)
+	for _, night := range booking.PriceBreakdown.Nights {
+		for _, adjustment := range night.Adjustments {
+			if !seen[adjustment.RuleID] {
+				seen[adjustment.RuleID] = true
+				ruleIDs = append(ruleIDs, adjustment.RuleID)
+			}
+		}
+	}
+
+	hours := booking.StartDate.Sub(at).Hours()
+
+	cancellation := model.BookingCancellation{
+		BookingID:        booking.ID,
+		HoursBeforeStart: math.Round(hours*100) / 100,
+		RefundAmount:     bookingAmount(booking),
+	}
+
+	policy, found, err := s.policyRepo.Resolve(ruleIDs, roomTypeID)
+	if err != nil {
+		return model.BookingCancellation{}, err
+	}
+
+	if !found || len(policy.Tiers) == 0 {
+		cancellation.PolicyName = "Free cancellation"
+		cancellation.RuleApplied = "No cancellation policy applies: full refund"
+		return cancellation, nil
+	}
+
+	tier := applicableTier(policy.Tiers, hours)
+	penalty := cancellationPenalty(tier, booking).Min(bookingAmount(booking))
+
+	cancellation.PolicyID = policy.ID
+	cancellation.PolicyName = policy.Name
+	cancellation.Penalty = penalty
+	cancellation.RefundAmount = bookingAmount(booking).Sub(penalty)
+	cancellation.RuleApplied = fmt.Sprintf(
+		"%s: cancelled %.0f hours before check-in, the %d+ hours tier applies: %s",
+		policy.Name, hours, tier.HoursBeforeStart, describePenalty(tier),
+	)
+
+	return cancellation, nil
+}
+
+// applicableTier returns the tier with the highest threshold that the cancellation
+// reaches, or the lowest tier if it reaches none
+func applicableTier(tiers model.CancellationTiers, hours float64) model.CancellationTier {
+	sorted := make([]model.CancellationTier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].HoursBeforeStart > sorted[j].HoursBeforeStart
+	})
+
+	for _, tier := range sorted {
+		if hours >= float64(tier.HoursBeforeStart) {
+			return tier
+		}
+	}
+
+	return sorted[len(sorted)-1]
+}
+
+// cancellationPenalty computes what a tier charges for cancelling a booking, taxes included
+func cancellationPenalty(tier model.CancellationTier, booking model.Booking) money.Money {
+	amount := bookingAmount(booking)
+	none := money.Zero(amount.Currency)
+
+	switch tier.PenaltyType {
+	case model.PenaltyNights:
+		nights := int(tier.PenaltyValue)
+		if len(booking.PriceBreakdown.Nights) == 0 {
+			// Bookings made before per-night pricing only have a total
+			stay := stayNights(booking.StartDate, booking.EndDate)
+			if stay == 0 {
+				return none
+			}
+			return amount.Scale(float64(nights) / float64(stay))
+		}
+
+		penalty := none
+		for i, night := range booking.PriceBreakdown.Nights {
+			if i >= nights {
+				break
+			}
+			penalty = penalty.Add(night.Price)
+		}
+
+		// The nights are charged with their share of the stay's taxes
+		if !booking.TotalPrice.IsPositive() {
+			return penalty
+		}
+		return penalty.Scale(float64(amount.Amount) / float64(booking.TotalPrice.Amount))
+	case model.PenaltyPercent:
+		return amount.Percent(tier.PenaltyValue)
+	case model.PenaltyAmount:
+		return money.FromFloat(tier.PenaltyValue, amount.Currency)
+	default:
+		return none
+	}
+}
+
+// describePenalty explains a tier's charge in words
+func describePenalty(tier model.CancellationTier) string {
+	switch tier.PenaltyType {
+	case model.PenaltyNights:
+		return fmt.Sprintf("%g night(s) charged", tier.PenaltyValue)
+	case model.PenaltyPercent:
+		return fmt.Sprintf("%g%% of the stay charged", tier.PenaltyValue)
+	case model.PenaltyAmount:
+		return fmt.Sprintf("%.2f charged", tier.PenaltyValue)
+	default:
+		return "no charge"
+	}
+}
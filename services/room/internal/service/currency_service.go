@@ -0,0 +1,177 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// CurrencyService handles business logic for the exchange rates prices are shown at
+type CurrencyService struct {
+	currencyRateRepo *repository.CurrencyRateRepository
+}
+
+// NewCurrencyService creates a new CurrencyService
+func NewCurrencyService(currencyRateRepo *repository.CurrencyRateRepository) *CurrencyService {
+	return &CurrencyService{
+		currencyRateRepo: currencyRateRepo,
+	}
+}
+
+// normalizeCurrency upper-cases a currency code and checks it is three letters
+func normalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return "", errors.New("currency must be a three-letter ISO 4217 code")
+	}
+
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return "", errors.New("currency must be a three-letter ISO 4217 code")
+		}
+	}
+
+	return currency, nil
+}
+
+// validateRate checks an exchange rate is a positive number
+func validateRate(rate float64) error {
+	if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+		return errors.New("rate must be positive")
+	}
+	return nil
+}
+
+// GetRate gets the rate to convert base-currency prices into currency at. No currency, or
+// the base currency itself, converts at 1.
+func (s *CurrencyService) GetRate(currency string) (model.CurrencyRate, error) {
+	if strings.TrimSpace(currency) == "" {
+		return model.CurrencyRate{Currency: money.DefaultCurrency, Rate: 1}, nil
+	}
+
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return model.CurrencyRate{}, err
+	}
+
+	if currency == money.DefaultCurrency {
+		return model.CurrencyRate{Currency: currency, Rate: 1}, nil
+	}
+
+	rate, err := s.currencyRateRepo.Get(currency)
+	if err != nil {
+		return model.CurrencyRate{}, fmt.Errorf("currency %s is not supported", currency)
+	}
+
+	return rate, nil
+}
+
+// SetRate sets the rate of a currency
+func (s *CurrencyService) SetRate(currency, updatedBy string, req model.CurrencyRateRequest) (model.CurrencyRate, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return model.CurrencyRate{}, err
+	}
+
+	if currency == money.DefaultCurrency {
+		return model.CurrencyRate{}, errors.New("the base currency has no rate")
+	}
+
+	if err := validateRate(req.Rate); err != nil {
+		return model.CurrencyRate{}, err
+	}
+
+	return s.currencyRateRepo.Upsert(model.CurrencyRate{
+		Currency:  currency,
+		Rate:      req.Rate,
+		UpdatedBy: updatedBy,
+	})
+}
+
+// ListRates lists every currency rate
+func (s *CurrencyService) ListRates() ([]model.CurrencyRate, error) {
+	return s.currencyRateRepo.List()
+}
+
+// DeleteRate stops prices being shown in a currency
+func (s *CurrencyService) DeleteRate(currency string) error {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	return s.currencyRateRepo.Delete(currency)
+}
+
+// ImportCSV sets rates from a CSV file of currency,rate rows, which may start with a
+// header row. The file is checked in full before any rate is changed.
+func (s *CurrencyService) ImportCSV(r io.Reader, updatedBy string) (model.CurrencyImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rates []model.CurrencyRate
+	seen := make(map[string]bool)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.CurrencyImportResult{}, fmt.Errorf("invalid CSV file: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) != 2 {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: expected currency and rate", line)
+		}
+
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "currency") {
+			continue
+		}
+
+		currency, err := normalizeCurrency(record[0])
+		if err != nil {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		if currency == money.DefaultCurrency {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: the base currency has no rate", line)
+		}
+
+		if seen[currency] {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: %s is listed more than once", line, currency)
+		}
+		seen[currency] = true
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: rate must be a number", line)
+		}
+
+		if err := validateRate(rate); err != nil {
+			return model.CurrencyImportResult{}, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		rates = append(rates, model.CurrencyRate{Currency: currency, Rate: rate, UpdatedBy: updatedBy})
+	}
+
+	if len(rates) == 0 {
+		return model.CurrencyImportResult{}, errors.New("file has no rates")
+	}
+
+	imported, err := s.currencyRateRepo.Import(rates)
+	if err != nil {
+		return model.CurrencyImportResult{}, err
+	}
+
+	return model.CurrencyImportResult{Imported: len(imported), Rates: imported}, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/foodclient"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// FolioService handles business logic for guest folios
+type FolioService struct {
+	folioRepo *repository.FolioRepository
+	food      *foodclient.Client
+}
+
+// NewFolioService creates a new FolioService reading room-service orders from the food service
+func NewFolioService(folioRepo *repository.FolioRepository, food *foodclient.Client) *FolioService {
+	return &FolioService{
+		folioRepo: folioRepo,
+		food:      food,
+	}
+}
+
+// RoomCharges gets the room-service orders delivered to the room of a booking's open folio
+// since it was opened, from the food service. A booking without an open folio has none.
+func (s *FolioService) RoomCharges(bookingID string) ([]model.FoodOrder, error) {
+	folio, err := s.folioRepo.GetByBookingID(bookingID)
+	if err != nil || folio.Status != "open" {
+		return nil, nil
+	}
+
+	return s.food.RoomCharges(folio.RoomID, folio.OpenedAt, time.Now())
+}
+
+// Sync brings an open folio up to date with the room-service orders delivered to its room
+func (s *FolioService) Sync(folio model.Folio) (model.Folio, error) {
+	if folio.Status != "open" {
+		return folio, nil
+	}
+
+	orders, err := s.food.RoomCharges(folio.RoomID, folio.OpenedAt, time.Now())
+	if err != nil {
+		return model.Folio{}, err
+	}
+
+	return s.Charge(folio, orders)
+}
+
+// Charge charges room-service orders already fetched from the food service to an open
+// folio and returns the folio as it then stands
+func (s *FolioService) Charge(folio model.Folio, orders []model.FoodOrder) (model.Folio, error) {
+	if folio.Status != "open" {
+		return folio, nil
+	}
+
+	if err := s.folioRepo.SyncFoodOrders(folio.ID, orders); err != nil {
+		return model.Folio{}, err
+	}
+
+	return s.folioRepo.GetByID(folio.ID)
+}
+
+// refresh brings an open folio up to date for reading. If the food service cannot be
+// reached the folio is shown as it stands.
+func (s *FolioService) refresh(folio model.Folio) (model.Folio, error) {
+	synced, err := s.Sync(folio)
+	if err != nil {
+		log.Printf("Failed to sync room-service orders to folio %s: %v", folio.ID, err)
+		return folio, nil
+	}
+
+	return synced, nil
+}
+
+// GetFolio gets the folio of a booking
+func (s *FolioService) GetFolio(bookingID string) (model.Folio, error) {
+	folio, err := s.folioRepo.GetByBookingID(bookingID)
+	if err != nil {
+		return model.Folio{}, err
+	}
+
+	return s.refresh(folio)
+}
+
+// GetFolioByID gets a folio by ID
+func (s *FolioService) GetFolioByID(id string) (model.Folio, error) {
+	folio, err := s.folioRepo.GetByID(id)
+	if err != nil {
+		return model.Folio{}, err
+	}
+
+	return s.refresh(folio)
+}
+
+// ListFolios lists folios, optionally by status
+func (s *FolioService) ListFolios(status string, limit, offset int) ([]model.Folio, error) {
+	if status != "" && status != "open" && status != "closed" {
+		return nil, errors.New("invalid status")
+	}
+
+	return s.folioRepo.List(status, limit, offset)
+}
+
+// ChargeMinibar charges minibar consumption to an open folio
+func (s *FolioService) ChargeMinibar(folioID, postedBy string, req model.MinibarChargeRequest) (model.FolioLine, error) {
+	if req.Quantity <= 0 {
+		return model.FolioLine{}, errors.New("quantity must be positive")
+	}
+
+	if req.UnitPrice.IsNegative() {
+		return model.FolioLine{}, errors.New("unit price cannot be negative")
+	}
+
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return model.FolioLine{}, errors.New("description is required")
+	}
+
+	serviceDate := today()
+	return s.folioRepo.AddLine(model.FolioLine{
+		FolioID:     folioID,
+		Type:        model.LineMinibar,
+		Description: description,
+		Quantity:    req.Quantity,
+		UnitPrice:   req.UnitPrice,
+		Amount:      req.UnitPrice.Mul(int64(req.Quantity)),
+		SourceID:    req.ItemID,
+		ServiceDate: &serviceDate,
+		PostedBy:    postedBy,
+	})
+}
+
+// RecordPayment records a payment against an open folio
+func (s *FolioService) RecordPayment(folioID, postedBy string, req model.FolioPaymentRequest) (model.FolioLine, error) {
+	if !req.Amount.IsPositive() {
+		return model.FolioLine{}, errors.New("amount must be positive")
+	}
+
+	validMethods := map[string]bool{
+		"cash":     true,
+		"card":     true,
+		"transfer": true,
+	}
+
+	if !validMethods[req.Method] {
+		return model.FolioLine{}, errors.New("invalid payment method")
+	}
+
+	amount := req.Amount
+	serviceDate := today()
+	return s.folioRepo.AddLine(model.FolioLine{
+		FolioID:     folioID,
+		Type:        model.LinePayment,
+		Description: "Payment by " + req.Method,
+		Quantity:    1,
+		UnitPrice:   amount,
+		Amount:      amount,
+		ServiceDate: &serviceDate,
+		Method:      req.Method,
+		Reference:   req.Reference,
+		PostedBy:    postedBy,
+	})
+}
+
+// AdjustFolio posts a correction to an open folio
+func (s *FolioService) AdjustFolio(folioID, postedBy string, req model.FolioAdjustmentRequest) (model.FolioLine, error) {
+	amount := req.Amount
+	if amount.IsZero() {
+		return model.FolioLine{}, errors.New("amount is required")
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return model.FolioLine{}, errors.New("reason is required")
+	}
+
+	serviceDate := today()
+	return s.folioRepo.AddLine(model.FolioLine{
+		FolioID:     folioID,
+		Type:        model.LineAdjustment,
+		Description: reason,
+		Quantity:    1,
+		UnitPrice:   amount,
+		Amount:      amount,
+		ServiceDate: &serviceDate,
+		PostedBy:    postedBy,
+	})
+}
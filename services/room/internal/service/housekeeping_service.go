@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// HousekeepingService handles business logic for housekeeping tasks
+type HousekeepingService struct {
+	housekeepingRepo *repository.HousekeepingRepository
+	roomRepo         *repository.RoomRepository
+}
+
+// NewHousekeepingService creates a new HousekeepingService
+func NewHousekeepingService(housekeepingRepo *repository.HousekeepingRepository, roomRepo *repository.RoomRepository) *HousekeepingService {
+	return &HousekeepingService{
+		housekeepingRepo: housekeepingRepo,
+		roomRepo:         roomRepo,
+	}
+}
+
+// validateTask checks a housekeeping task's type and priority
+func validateTask(task model.HousekeepingTask) error {
+	switch task.Type {
+	case model.TaskClean, model.TaskInspect, model.TaskTurndown:
+	default:
+		return errors.New("invalid task type")
+	}
+
+	switch task.Priority {
+	case "", model.PriorityHigh, model.PriorityNormal, model.PriorityLow:
+	default:
+		return errors.New("invalid task priority")
+	}
+
+	return nil
+}
+
+// CreateTask creates a housekeeping task by hand
+func (s *HousekeepingService) CreateTask(task model.HousekeepingTask) (model.HousekeepingTask, error) {
+	if err := validateTask(task); err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	// Check if room exists
+	if _, err := s.roomRepo.GetByID(task.RoomID); err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	if task.DueDate.IsZero() {
+		task.DueDate = today()
+	}
+	if task.DueDate.Before(today()) {
+		return model.HousekeepingTask{}, errors.New("due date cannot be in the past")
+	}
+
+	// Tasks created by hand always start pending and unattached to a stay
+	task.ID = ""
+	task.BookingID = ""
+	task.Status = "pending"
+
+	return s.housekeepingRepo.Create(task)
+}
+
+// GetTask gets a housekeeping task by ID
+func (s *HousekeepingService) GetTask(id string) (model.HousekeepingTask, error) {
+	return s.housekeepingRepo.GetByID(id)
+}
+
+// ListTasks lists housekeeping tasks, optionally filtered by status and room
+func (s *HousekeepingService) ListTasks(status, roomID string, limit, offset int) ([]model.HousekeepingTask, error) {
+	return s.housekeepingRepo.List(status, roomID, limit, offset)
+}
+
+// ListMyTasks lists the pending tasks assigned to a staff member
+func (s *HousekeepingService) ListMyTasks(userID string, limit, offset int) ([]model.HousekeepingTask, error) {
+	return s.housekeepingRepo.ListByAssignee(userID, limit, offset)
+}
+
+// UpdateTask reassigns, reprioritises or annotates a pending task
+func (s *HousekeepingService) UpdateTask(id string, req model.HousekeepingTask) (model.HousekeepingTask, error) {
+	existingTask, err := s.housekeepingRepo.GetByID(id)
+	if err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	// Update fields
+	existingTask.AssignedTo = req.AssignedTo
+	if req.Priority != "" {
+		existingTask.Priority = req.Priority
+	}
+	existingTask.Notes = req.Notes
+
+	if err := validateTask(existingTask); err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	return s.housekeepingRepo.Update(existingTask)
+}
+
+// CompleteTask marks a task done on behalf of a staff member. Staff may only complete
+// tasks assigned to them or to nobody; admins may complete any task. Inspections pass
+// unless the request says otherwise.
+func (s *HousekeepingService) CompleteTask(id, userID string, isAdmin bool, completion model.TaskCompletion) (model.HousekeepingTask, error) {
+	task, err := s.housekeepingRepo.GetByID(id)
+	if err != nil {
+		return model.HousekeepingTask{}, err
+	}
+
+	if !isAdmin && task.AssignedTo != "" && task.AssignedTo != userID {
+		return model.HousekeepingTask{}, errors.New("task is assigned to someone else")
+	}
+
+	if task.Type == model.TaskInspect {
+		if completion.Passed == nil {
+			passed := true
+			completion.Passed = &passed
+		}
+		if !*completion.Passed && completion.Notes == "" {
+			return model.HousekeepingTask{}, errors.New("notes are required when an inspection fails")
+		}
+	} else {
+		completion.Passed = nil
+	}
+
+	return s.housekeepingRepo.Complete(id, userID, completion)
+}
+
+// CancelTask cancels a pending task
+func (s *HousekeepingService) CancelTask(id string) error {
+	return s.housekeepingRepo.Cancel(id)
+}
+
+// GenerateDailyTasks creates the day's stayover cleans and turndowns for occupied rooms
+func (s *HousekeepingService) GenerateDailyTasks() error {
+	created, err := s.housekeepingRepo.GenerateDaily(today())
+	if err != nil {
+		return err
+	}
+
+	if created > 0 {
+		log.Printf("Created %d daily housekeeping tasks", created)
+	}
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/services/room/internal/foodclient"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/pdf"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// InvoiceService handles business logic for invoices
+type InvoiceService struct {
+	invoiceRepo *repository.InvoiceRepository
+	folioRepo   *repository.FolioRepository
+	bookingRepo *repository.BookingRepository
+	roomRepo    *repository.RoomRepository
+	food        *foodclient.Client
+	property    string
+}
+
+// NewInvoiceService creates a new InvoiceService issuing invoices numbered for property.
+// Food orders are read from the food service.
+func NewInvoiceService(invoiceRepo *repository.InvoiceRepository, folioRepo *repository.FolioRepository, bookingRepo *repository.BookingRepository, roomRepo *repository.RoomRepository, food *foodclient.Client, property string) *InvoiceService {
+	return &InvoiceService{
+		invoiceRepo: invoiceRepo,
+		folioRepo:   folioRepo,
+		bookingRepo: bookingRepo,
+		roomRepo:    roomRepo,
+		food:        food,
+		property:    property,
+	}
+}
+
+// totalInvoice works out an invoice's subtotal, tax, total and balance from its lines,
+// taxes and the amount already paid
+func totalInvoice(invoice *model.Invoice) {
+	invoice.Subtotal = money.Zero(money.DefaultCurrency)
+	for _, line := range invoice.Lines {
+		invoice.Subtotal = invoice.Subtotal.Add(line.Amount)
+	}
+
+	invoice.TaxTotal = money.Zero(money.DefaultCurrency)
+	for _, tax := range invoice.Taxes {
+		invoice.TaxTotal = invoice.TaxTotal.Add(tax.Amount)
+	}
+
+	invoice.Total = invoice.Subtotal.Add(invoice.TaxTotal)
+	invoice.BalanceDue = invoice.Total.Sub(invoice.AmountPaid)
+}
+
+// addInvoiceTax adds a levy to an invoice's taxes, combining levies of the same name
+// and rate, as when each food order on a stay carries its own VAT
+func addInvoiceTax(taxes model.InvoiceTaxes, name string, rate float64, base, amount money.Money) model.InvoiceTaxes {
+	for i := range taxes {
+		if taxes[i].Name == name && taxes[i].Rate == rate {
+			taxes[i].Base = taxes[i].Base.Add(base)
+			taxes[i].Amount = taxes[i].Amount.Add(amount)
+			return taxes
+		}
+	}
+
+	return append(taxes, model.InvoiceTax{Name: name, Rate: rate, Base: base, Amount: amount})
+}
+
+// IssueForBooking issues the invoice of a stay from its closed folio. A stay is only
+// invoiced once; asking again returns the invoice already issued.
+func (s *InvoiceService) IssueForBooking(bookingID string) (model.Invoice, error) {
+	if invoice, err := s.invoiceRepo.GetBySource(model.InvoiceBooking, bookingID); err == nil {
+		return invoice, nil
+	}
+
+	folio, err := s.folioRepo.GetByBookingID(bookingID)
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	if folio.Status != "closed" {
+		return model.Invoice{}, errors.New("invoice is issued at check-out")
+	}
+
+	booking, err := s.bookingRepo.GetByID(bookingID)
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	room, err := s.roomRepo.GetByID(folio.RoomID)
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	invoice := model.Invoice{
+		Property:   s.property,
+		SourceType: model.InvoiceBooking,
+		SourceID:   booking.ID,
+		UserID:     folio.UserID,
+		RoomID:     room.ID,
+		RoomNumber: room.Number,
+		StartDate:  &booking.StartDate,
+		EndDate:    &booking.EndDate,
+		AmountPaid: folio.Payments,
+	}
+
+	for _, line := range folio.Lines {
+		if line.Type == model.LinePayment {
+			continue
+		}
+		if line.Type == model.LineTax {
+			invoice.Taxes = addInvoiceTax(invoice.Taxes, line.Description, line.Rate, line.Base, line.Amount)
+			continue
+		}
+		invoice.Lines = append(invoice.Lines, model.InvoiceLine{
+			Type:        line.Type,
+			Description: line.Description,
+			Date:        line.ServiceDate,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+			Amount:      line.Amount,
+		})
+	}
+
+	totalInvoice(&invoice)
+
+	return s.invoiceRepo.Issue(invoice)
+}
+
+// foodOrderLines turns the items of a food order into invoice lines, dated the day the
+// order was placed
+func foodOrderLines(order model.FoodOrder) model.InvoiceLines {
+	orderDate := order.CreatedAt
+	var lines model.InvoiceLines
+	for _, item := range order.Items {
+		description := item.MenuItem.Name
+		if description == "" {
+			description = "Menu item"
+		}
+
+		line := model.InvoiceLine{
+			Type:        "food",
+			Description: description,
+			Date:        &orderDate,
+			Quantity:    item.Quantity,
+			Amount:      item.Price,
+		}
+		// Order items carry the line total
+		if item.Quantity > 0 {
+			line.UnitPrice = item.Price.Div(int64(item.Quantity))
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// IssueForFoodOrder issues the invoice of a delivered food order. Orders delivered to a
+// room during a stay are charged to its folio and appear on the stay's invoice instead.
+func (s *InvoiceService) IssueForFoodOrder(orderID string) (model.Invoice, error) {
+	if invoice, err := s.invoiceRepo.GetBySource(model.InvoiceFoodOrder, orderID); err == nil {
+		return invoice, nil
+	}
+
+	order, err := s.food.GetOrder(orderID)
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	if order.Status != "delivered" {
+		return model.Invoice{}, errors.New("invoice is issued once the order is delivered")
+	}
+
+	onFolio, err := s.folioRepo.HasFoodOrder(order.ID)
+	if err != nil {
+		return model.Invoice{}, err
+	}
+
+	if onFolio {
+		return model.Invoice{}, errors.New("order is billed on the stay's invoice")
+	}
+
+	if len(order.Items) == 0 {
+		return model.Invoice{}, errors.New("order has no items")
+	}
+
+	invoice := model.Invoice{
+		Property:   s.property,
+		SourceType: model.InvoiceFoodOrder,
+		SourceID:   order.ID,
+		UserID:     order.UserID,
+		Lines:      foodOrderLines(order),
+	}
+
+	for _, line := range order.TaxBreakdown.Lines {
+		invoice.Taxes = addInvoiceTax(invoice.Taxes, line.Name, line.Rate, line.Base, line.Amount)
+	}
+
+	if order.RoomID != "" {
+		if room, err := s.roomRepo.GetByID(order.RoomID); err == nil {
+			invoice.RoomID = room.ID
+			invoice.RoomNumber = room.Number
+		}
+	}
+
+	totalInvoice(&invoice)
+
+	return s.invoiceRepo.Issue(invoice)
+}
+
+// GetInvoice gets an invoice by ID
+func (s *InvoiceService) GetInvoice(id string) (model.Invoice, error) {
+	return s.invoiceRepo.GetByID(id)
+}
+
+// ListUserInvoices lists the invoices of a guest
+func (s *InvoiceService) ListUserInvoices(userID string, limit, offset int) ([]model.Invoice, error) {
+	return s.invoiceRepo.ListByUserID(userID, limit, offset)
+}
+
+// ListInvoices lists all invoices
+func (s *InvoiceService) ListInvoices(limit, offset int) ([]model.Invoice, error) {
+	return s.invoiceRepo.List(limit, offset)
+}
+
+// Invoice PDF layout, in points
+const (
+	invoiceMarginLeft  = 50.0
+	invoiceMarginRight = pdf.PageWidth - 50
+	invoiceMarginTop   = 60.0
+	invoiceMarginEnd   = pdf.PageHeight - 70
+	invoiceRowHeight   = 16.0
+)
+
+// formatAmount formats an amount for print
+func formatAmount(amount money.Money) string {
+	return amount.Decimal()
+}
+
+// RenderPDF renders an invoice as a printable PDF
+func (s *InvoiceService) RenderPDF(invoice model.Invoice) []byte {
+	doc := pdf.New("Invoice " + invoice.Number)
+	page := doc.AddPage()
+
+	// Header
+	page.Text(invoiceMarginLeft, invoiceMarginTop, pdf.Bold, 22, "INVOICE")
+	page.TextRight(invoiceMarginRight, invoiceMarginTop-8, pdf.Bold, 11, invoice.Number)
+	page.TextRight(invoiceMarginRight, invoiceMarginTop+8, pdf.Regular, 10, "Issued "+invoice.IssuedAt.Format("2 January 2006"))
+
+	y := invoiceMarginTop + 40
+	details := []string{"Guest: " + invoice.UserID}
+	if invoice.RoomNumber != "" {
+		details = append(details, "Room: "+invoice.RoomNumber)
+	}
+	if invoice.StartDate != nil && invoice.EndDate != nil {
+		details = append(details, "Stay: "+invoice.StartDate.Format("2006-01-02")+" to "+invoice.EndDate.Format("2006-01-02"))
+	}
+	if invoice.SourceType == model.InvoiceFoodOrder {
+		details = append(details, "Food order: "+invoice.SourceID)
+	}
+	details = append(details, "Currency: "+invoice.Total.Currency)
+	for _, detail := range details {
+		page.Text(invoiceMarginLeft, y, pdf.Regular, 10, detail)
+		y += 14
+	}
+
+	// Line items
+	columns := func(page *pdf.Page, y float64) float64 {
+		page.Text(invoiceMarginLeft, y, pdf.Bold, 10, "Date")
+		page.Text(invoiceMarginLeft+75, y, pdf.Bold, 10, "Description")
+		page.TextRight(invoiceMarginRight-150, y, pdf.Bold, 10, "Qty")
+		page.TextRight(invoiceMarginRight-75, y, pdf.Bold, 10, "Unit price")
+		page.TextRight(invoiceMarginRight, y, pdf.Bold, 10, "Amount")
+		page.Line(invoiceMarginLeft, y+5, invoiceMarginRight, y+5, 0.5)
+		return y + invoiceRowHeight + 4
+	}
+
+	y = columns(page, y+20)
+	for _, line := range invoice.Lines {
+		if y > invoiceMarginEnd {
+			page = doc.AddPage()
+			y = columns(page, invoiceMarginTop)
+		}
+
+		if line.Date != nil {
+			page.Text(invoiceMarginLeft, y, pdf.Regular, 10, line.Date.Format("2006-01-02"))
+		}
+		page.Text(invoiceMarginLeft+75, y, pdf.Regular, 10, line.Description)
+		page.TextRight(invoiceMarginRight-150, y, pdf.Regular, 10, fmt.Sprintf("%d", line.Quantity))
+		page.TextRight(invoiceMarginRight-75, y, pdf.Regular, 10, formatAmount(line.UnitPrice))
+		page.TextRight(invoiceMarginRight, y, pdf.Regular, 10, formatAmount(line.Amount))
+		y += invoiceRowHeight
+	}
+
+	// Totals
+	type total struct {
+		label  string
+		amount money.Money
+		font   pdf.Font
+	}
+
+	totals := []total{{"Subtotal", invoice.Subtotal, pdf.Regular}}
+	for _, tax := range invoice.Taxes {
+		totals = append(totals, total{fmt.Sprintf("%s (%g%%)", tax.Name, tax.Rate), tax.Amount, pdf.Regular})
+	}
+	totals = append(totals,
+		total{"Total", invoice.Total, pdf.Bold},
+		total{"Amount paid", invoice.AmountPaid, pdf.Regular},
+		total{"Balance due", invoice.BalanceDue, pdf.Bold},
+	)
+
+	if y+float64(len(totals)+1)*invoiceRowHeight > invoiceMarginEnd {
+		page = doc.AddPage()
+		y = invoiceMarginTop
+	}
+
+	page.Line(invoiceMarginRight-220, y-6, invoiceMarginRight, y-6, 0.5)
+	y += 6
+	for _, t := range totals {
+		page.TextRight(invoiceMarginRight-90, y, t.font, 10, t.label)
+		page.TextRight(invoiceMarginRight, y, t.font, 10, formatAmount(t.amount))
+		y += invoiceRowHeight
+	}
+
+	return doc.Bytes()
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// MaintenanceService handles business logic for room maintenance windows
+type MaintenanceService struct {
+	maintenanceRepo *repository.MaintenanceRepository
+	roomRepo        *repository.RoomRepository
+}
+
+// NewMaintenanceService creates a new MaintenanceService
+func NewMaintenanceService(maintenanceRepo *repository.MaintenanceRepository, roomRepo *repository.RoomRepository) *MaintenanceService {
+	return &MaintenanceService{
+		maintenanceRepo: maintenanceRepo,
+		roomRepo:        roomRepo,
+	}
+}
+
+// today returns the current date at midnight UTC, the form dates are stored in
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// validateMaintenanceRequest checks a maintenance request's dates and reason
+func validateMaintenanceRequest(req model.MaintenanceRequest) error {
+	if strings.TrimSpace(req.Reason) == "" {
+		return errors.New("reason is required")
+	}
+
+	if !req.StartDate.Before(req.EndDate) {
+		return errors.New("start date must be before end date")
+	}
+
+	if !req.EndDate.After(today()) {
+		return errors.New("end date must be in the future")
+	}
+
+	return nil
+}
+
+// ScheduleMaintenance schedules a maintenance window for a room. The room must have no
+// bookings during the window; a window starting today takes the room out of service at once.
+func (s *MaintenanceService) ScheduleMaintenance(createdBy string, req model.MaintenanceRequest) (model.MaintenanceWindow, error) {
+	if err := validateMaintenanceRequest(req); err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	if req.StartDate.Before(today()) {
+		return model.MaintenanceWindow{}, errors.New("start date cannot be in the past")
+	}
+
+	// Check if room exists
+	room, err := s.roomRepo.GetByID(req.RoomID)
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	window, err := s.maintenanceRepo.Create(model.MaintenanceWindow{
+		RoomID:     room.ID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Reason:     req.Reason,
+		AssignedTo: req.AssignedTo,
+		Status:     "scheduled",
+		CreatedBy:  createdBy,
+	})
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	if err := s.SyncRoomStatuses(); err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	return s.maintenanceRepo.GetByID(window.ID)
+}
+
+// GetMaintenanceByID gets a maintenance window by ID
+func (s *MaintenanceService) GetMaintenanceByID(id string) (model.MaintenanceWindow, error) {
+	return s.maintenanceRepo.GetByID(id)
+}
+
+// ListMaintenance lists maintenance windows, optionally for a single room
+func (s *MaintenanceService) ListMaintenance(roomID string, limit, offset int) ([]model.MaintenanceWindow, error) {
+	return s.maintenanceRepo.List(roomID, limit, offset)
+}
+
+// ListAssignedMaintenance lists the open maintenance windows assigned to a staff member
+func (s *MaintenanceService) ListAssignedMaintenance(userID string, limit, offset int) ([]model.MaintenanceWindow, error) {
+	return s.maintenanceRepo.ListByAssignee(userID, limit, offset)
+}
+
+// UpdateMaintenance reschedules or reassigns a maintenance window. Once a window has
+// started only its end date, reason and assignee can change.
+func (s *MaintenanceService) UpdateMaintenance(id string, req model.MaintenanceRequest) (model.MaintenanceWindow, error) {
+	existingWindow, err := s.maintenanceRepo.GetByID(id)
+	if err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	// The room cannot change
+	req.RoomID = existingWindow.RoomID
+	if err := validateMaintenanceRequest(req); err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	switch existingWindow.Status {
+	case "scheduled":
+		if req.StartDate.Before(today()) {
+			return model.MaintenanceWindow{}, errors.New("start date cannot be in the past")
+		}
+	case "active":
+		if !req.StartDate.Equal(existingWindow.StartDate) {
+			return model.MaintenanceWindow{}, errors.New("cannot move the start of a maintenance window that has started")
+		}
+	default:
+		return model.MaintenanceWindow{}, errors.New("maintenance window has already ended")
+	}
+
+	// Update fields
+	existingWindow.StartDate = req.StartDate
+	existingWindow.EndDate = req.EndDate
+	existingWindow.Reason = req.Reason
+	existingWindow.AssignedTo = req.AssignedTo
+
+	if _, err := s.maintenanceRepo.Update(existingWindow); err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	if err := s.SyncRoomStatuses(); err != nil {
+		return model.MaintenanceWindow{}, err
+	}
+
+	return s.maintenanceRepo.GetByID(id)
+}
+
+// CancelMaintenance cancels a maintenance window that has not started yet
+func (s *MaintenanceService) CancelMaintenance(id string) error {
+	return s.maintenanceRepo.Cancel(id)
+}
+
+// CompleteMaintenance ends an active maintenance window early and puts the room back in service
+func (s *MaintenanceService) CompleteMaintenance(id string) (model.MaintenanceWindow, error) {
+	return s.maintenanceRepo.Complete(id, today())
+}
+
+// SyncRoomStatuses switches rooms to maintenance when their windows begin and back to
+// available when they end
+func (s *MaintenanceService) SyncRoomStatuses() error {
+	date := today()
+
+	started, err := s.maintenanceRepo.StartDue(date)
+	if err != nil {
+		return err
+	}
+
+	finished, err := s.maintenanceRepo.FinishDue(date)
+	if err != nil {
+		return err
+	}
+
+	if len(started) > 0 || len(finished) > 0 {
+		log.Printf("Started %d and finished %d maintenance windows", len(started), len(finished))
+	}
+
+	return nil
+}
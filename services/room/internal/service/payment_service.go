@@ -0,0 +1,390 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/payment"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// PaymentService handles card payments for bookings through the payment gateway. A
+// booking's stay is authorized when it is confirmed and captured at check-out, against
+// what the guest's folio then shows is owed.
+type PaymentService struct {
+	paymentRepo  *repository.PaymentRepository
+	folioRepo    *repository.FolioRepository
+	folioService *FolioService
+	gateway      payment.Gateway
+}
+
+// NewPaymentService creates a new PaymentService
+func NewPaymentService(paymentRepo *repository.PaymentRepository, folioRepo *repository.FolioRepository, folioService *FolioService, gateway payment.Gateway) *PaymentService {
+	return &PaymentService{
+		paymentRepo:  paymentRepo,
+		folioRepo:    folioRepo,
+		folioService: folioService,
+		gateway:      gateway,
+	}
+}
+
+// bookingAmount is what a guest is charged for a stay
+func bookingAmount(booking model.Booking) money.Money {
+	return booking.TotalPrice.Add(booking.PriceBreakdown.Taxes.Total)
+}
+
+// balanceAmount is what is held on a guest's payment method for a stay: its total with
+// taxes less any deposit, which is charged separately
+func balanceAmount(booking model.Booking) money.Money {
+	return bookingAmount(booking).Sub(booking.DepositAmount)
+}
+
+// Authorize holds an amount on a payment method without recording it, for callers that
+// record the payment once the booking it pays for exists
+func (s *PaymentService) Authorize(amount money.Money, token, reference string) (payment.Transaction, error) {
+	if strings.TrimSpace(token) == "" {
+		return payment.Transaction{}, errors.New("payment token is required")
+	}
+
+	return s.gateway.Authorize(payment.AuthorizeRequest{
+		Amount:    amount,
+		Token:     token,
+		Reference: reference,
+	})
+}
+
+// Record records an authorization made with Authorize against a booking
+func (s *PaymentService) Record(bookingID string, transaction payment.Transaction) (model.Payment, error) {
+	return s.paymentRepo.Create(model.Payment{
+		BookingID:      bookingID,
+		Gateway:        s.gateway.Name(),
+		TransactionID:  transaction.ID,
+		Status:         transaction.Status,
+		Amount:         transaction.Amount,
+		CapturedAmount: transaction.Captured,
+		RefundedAmount: transaction.Refunded,
+	})
+}
+
+// VoidTransaction releases an authorization made with Authorize that was never recorded
+func (s *PaymentService) VoidTransaction(transaction payment.Transaction) {
+	if _, err := s.gateway.Void(transaction.ID); err != nil {
+		log.Printf("Failed to void payment transaction %s: %v", transaction.ID, err)
+	}
+}
+
+// authorize holds an amount on the guest's payment method for a booking. A declined
+// payment is recorded against the booking and returned as an error wrapping
+// payment.ErrDeclined.
+func (s *PaymentService) authorize(booking model.Booking, amount money.Money, token string) (payment.Transaction, error) {
+	transaction, err := s.Authorize(amount, token, booking.ID)
+	if errors.Is(err, payment.ErrDeclined) {
+		if _, recordErr := s.paymentRepo.Create(model.Payment{
+			BookingID:     booking.ID,
+			Gateway:       s.gateway.Name(),
+			Status:        payment.StatusFailed,
+			Amount:        amount,
+			FailureReason: err.Error(),
+		}); recordErr != nil {
+			log.Printf("Failed to record declined payment for booking %s: %v", booking.ID, recordErr)
+		}
+	}
+	return transaction, err
+}
+
+// AuthorizeBooking holds a booking's balance, its total with taxes less any deposit, on
+// the guest's payment method. There is nothing to hold when the deposit covers the stay.
+func (s *PaymentService) AuthorizeBooking(booking model.Booking, token string) (model.Payment, error) {
+	amount := balanceAmount(booking)
+	if !amount.IsPositive() {
+		return model.Payment{}, nil
+	}
+
+	transaction, err := s.authorize(booking, amount, token)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return s.Record(booking.ID, transaction)
+}
+
+// ReauthorizeBooking authorizes the balance of an amended booking, before the amendment is
+// saved, when it differs from what is already authorized. A higher balance must be
+// authorized on the payment method given. A lower one is authorized afresh when a payment
+// method is given; otherwise the existing authorization stands, and check-out captures
+// only what is owed. The new authorization, if any, is applied with ApplyReauthorization
+// once the amendment is saved, or released with VoidTransaction if it is not.
+func (s *PaymentService) ReauthorizeBooking(booking model.Booking, token string) (payment.Transaction, error) {
+	balance := balanceAmount(booking)
+
+	authorized := money.Zero(balance.Currency)
+	p, ok, err := s.authorized(booking.ID)
+	if err != nil {
+		return payment.Transaction{}, err
+	}
+	if ok {
+		authorized = p.Amount
+	}
+
+	switch {
+	case balance.Cmp(authorized) == 0, !balance.IsPositive():
+		return payment.Transaction{}, nil
+	case balance.Cmp(authorized) < 0 && strings.TrimSpace(token) == "":
+		return payment.Transaction{}, nil
+	}
+
+	return s.authorize(booking, balance, token)
+}
+
+// ApplyReauthorization records the authorization ReauthorizeBooking made for an amended
+// booking and releases the one it replaces. Without a new authorization, one left over
+// from a balance the deposit now covers is released.
+func (s *PaymentService) ApplyReauthorization(booking model.Booking, transaction payment.Transaction) error {
+	p, ok, err := s.authorized(booking.ID)
+	if err != nil {
+		return err
+	}
+
+	if transaction.ID == "" {
+		if ok && !balanceAmount(booking).IsPositive() {
+			_, err = s.capture(p, money.Zero(p.Amount.Currency))
+		}
+		return err
+	}
+
+	if _, err := s.Record(booking.ID, transaction); err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	_, err = s.capture(p, money.Zero(p.Amount.Currency))
+	return err
+}
+
+// PayDeposit takes what remains of a booking's deposit from the guest's payment method
+func (s *PaymentService) PayDeposit(booking model.Booking, token string) (model.Payment, error) {
+	amount := booking.DepositAmount.Sub(booking.AmountPaid)
+	if !amount.IsPositive() {
+		return model.Payment{}, errors.New("deposit is already paid")
+	}
+
+	transaction, err := s.authorize(booking, amount, token)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	captured, err := s.gateway.Capture(transaction.ID, amount)
+	if err != nil {
+		s.VoidTransaction(transaction)
+		return model.Payment{}, err
+	}
+
+	return s.Record(booking.ID, captured)
+}
+
+// authorized returns the booking's payment that is authorized but not yet captured, if any
+func (s *PaymentService) authorized(bookingID string) (model.Payment, bool, error) {
+	payments, err := s.paymentRepo.ListByBookingID(bookingID)
+	if err != nil {
+		return model.Payment{}, false, err
+	}
+
+	for _, p := range payments {
+		if p.Status == payment.StatusAuthorized {
+			return p, true, nil
+		}
+	}
+
+	return model.Payment{}, false, nil
+}
+
+// apply saves the state a gateway call left a payment in
+func (s *PaymentService) apply(p model.Payment, transaction payment.Transaction) (model.Payment, error) {
+	p.Status = transaction.Status
+	p.CapturedAmount = transaction.Captured
+	p.RefundedAmount = transaction.Refunded
+	return s.paymentRepo.Update(p)
+}
+
+// capture takes up to amount of an authorized payment, voiding it when there is nothing to take
+func (s *PaymentService) capture(p model.Payment, amount money.Money) (model.Payment, error) {
+	if !amount.IsPositive() {
+		transaction, err := s.gateway.Void(p.TransactionID)
+		if err != nil {
+			return model.Payment{}, err
+		}
+		return s.apply(p, transaction)
+	}
+
+	transaction, err := s.gateway.Capture(p.TransactionID, amount.Min(p.Amount))
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return s.apply(p, transaction)
+}
+
+// VoidBooking releases a booking's authorization, if it has one
+func (s *PaymentService) VoidBooking(bookingID string) error {
+	p, ok, err := s.authorized(bookingID)
+	if err != nil || !ok {
+		return err
+	}
+
+	_, err = s.capture(p, money.Zero(p.Amount.Currency))
+	return err
+}
+
+// SettleCancellation keeps the penalty of a cancelled booking. It comes out of what the
+// guest has paid, such as a deposit, with the rest refunded; any penalty beyond that is
+// captured from the stay's authorization, and the rest of the authorization released.
+func (s *PaymentService) SettleCancellation(bookingID string, penalty money.Money) error {
+	payments, err := s.paymentRepo.ListByBookingID(bookingID)
+	if err != nil {
+		return err
+	}
+
+	remaining := penalty
+	for _, p := range payments {
+		if p.Status != payment.StatusCaptured && p.Status != payment.StatusPartiallyRefunded {
+			continue
+		}
+
+		paid := p.CapturedAmount.Sub(p.RefundedAmount)
+		kept := paid.Min(remaining.Max(money.Zero(paid.Currency)))
+		remaining = remaining.Sub(kept)
+
+		if refund := paid.Sub(kept); refund.IsPositive() {
+			transaction, err := s.gateway.Refund(p.TransactionID, refund)
+			if err != nil {
+				return err
+			}
+			if _, err := s.apply(p, transaction); err != nil {
+				return err
+			}
+		}
+	}
+
+	p, ok, err := s.authorized(bookingID)
+	if err != nil || !ok {
+		return err
+	}
+
+	_, err = s.capture(p, remaining)
+	return err
+}
+
+// SettleCheckOut captures what a departing guest owes from their authorization, up to the
+// amount authorized, and posts it to their folio. It is called with the room-service
+// orders the folio closes with, before it is closed. Only an authorization not yet
+// captured is captured, and the capture is recorded with its folio line in one
+// transaction, so checking out again after a failure never charges the card twice.
+func (s *PaymentService) SettleCheckOut(booking model.Booking, orders []model.FoodOrder) error {
+	p, ok, err := s.authorized(booking.ID)
+	if err != nil || !ok {
+		return err
+	}
+
+	// What is owed is the folio's balance, which includes room service and minibar charges
+	// as well as payments made at the desk
+	owed := bookingAmount(booking).Sub(booking.AmountPaid)
+	folio, err := s.folioRepo.GetByBookingID(booking.ID)
+	if err == nil {
+		if folio, err = s.folioService.Charge(folio, orders); err != nil {
+			return err
+		}
+		owed = folio.Balance
+	}
+
+	if !owed.IsPositive() || folio.ID == "" {
+		_, err = s.capture(p, owed)
+		return err
+	}
+
+	transaction, err := s.gateway.Capture(p.TransactionID, owed.Min(p.Amount))
+	if err != nil {
+		return err
+	}
+	p.Status = transaction.Status
+	p.CapturedAmount = transaction.Captured
+	p.RefundedAmount = transaction.Refunded
+
+	serviceDate := today()
+	_, err = s.paymentRepo.UpdateAndPost(p, model.FolioLine{
+		FolioID:     folio.ID,
+		Type:        model.LinePayment,
+		Description: "Payment by card",
+		Quantity:    1,
+		UnitPrice:   transaction.Captured,
+		Amount:      transaction.Captured,
+		ServiceDate: &serviceDate,
+		Method:      "card",
+		Reference:   p.TransactionID,
+	})
+	if err != nil {
+		return fmt.Errorf("captured %s on transaction %s but failed to record it: %w", transaction.Captured, p.TransactionID, err)
+	}
+
+	return nil
+}
+
+// RefundPayment refunds some or all of a captured payment
+func (s *PaymentService) RefundPayment(id string, req model.RefundRequest) (model.Payment, error) {
+	p, err := s.paymentRepo.GetByID(id)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	if p.Status != payment.StatusCaptured && p.Status != payment.StatusPartiallyRefunded {
+		return model.Payment{}, errors.New("only captured payments can be refunded")
+	}
+
+	amount, err := refundAmount(p, req.Amount)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	transaction, err := s.gateway.Refund(p.TransactionID, amount)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	return s.apply(p, transaction)
+}
+
+// refundAmount works out how much of a captured payment to refund: the amount requested,
+// or everything not yet refunded when none is. The requested amount comes from the client,
+// so its currency is checked before it is compared with the payment.
+func refundAmount(p model.Payment, requested *money.Money) (money.Money, error) {
+	refundable := p.CapturedAmount.Sub(p.RefundedAmount)
+	if requested == nil {
+		return refundable, nil
+	}
+
+	if requested.Currency != p.CapturedAmount.Currency {
+		return money.Money{}, errors.New("refund must be in " + p.CapturedAmount.Currency)
+	}
+
+	if !requested.IsPositive() || requested.Cmp(refundable) > 0 {
+		return money.Money{}, errors.New("refund must be positive and no more than " + refundable.String())
+	}
+
+	return *requested, nil
+}
+
+// GetPayment gets a payment by ID
+func (s *PaymentService) GetPayment(id string) (model.Payment, error) {
+	return s.paymentRepo.GetByID(id)
+}
+
+// ListBookingPayments lists the payments of a booking
+func (s *PaymentService) ListBookingPayments(bookingID string) ([]model.Payment, error) {
+	return s.paymentRepo.ListByBookingID(bookingID)
+}
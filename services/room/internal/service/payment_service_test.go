@@ -0,0 +1,50 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+)
+
+func TestRefundAmountRejectsMismatchedCurrency(t *testing.T) {
+	p := model.Payment{
+		CapturedAmount: money.New(10000, "USD"),
+		RefundedAmount: money.Zero("USD"),
+	}
+
+	requested := money.New(500, "EUR")
+	if _, err := refundAmount(p, &requested); err == nil {
+		t.Fatal("expected a refund in EUR against a USD payment to be rejected")
+	}
+}
+
+func TestRefundAmount(t *testing.T) {
+	p := model.Payment{
+		CapturedAmount: money.New(10000, "USD"),
+		RefundedAmount: money.New(2500, "USD"),
+	}
+
+	amount, err := refundAmount(p, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount != money.New(7500, "USD") {
+		t.Errorf("refund without an amount = %v, want USD 75.00", amount)
+	}
+
+	for _, requested := range []money.Money{money.New(0, "USD"), money.New(-100, "USD"), money.New(7501, "USD")} {
+		requested := requested
+		if _, err := refundAmount(p, &requested); err == nil {
+			t.Errorf("refund of %v was accepted", requested)
+		}
+	}
+}
+
+func TestRefundRequestRejectsOtherCurrencies(t *testing.T) {
+	var req model.RefundRequest
+	if err := json.Unmarshal([]byte(`{"amount":{"amount":"5","currency":"EUR"}}`), &req); err == nil {
+		t.Fatal("expected a refund request in EUR to be rejected")
+	}
+}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
+	"github.com/flaminshinjan/address.ai/pkg/common/tax"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// PricingService prices stays from the room's base rate and the active rate rules, and
+// levies the taxes and service charges in force on each night
+type PricingService struct {
+	rateRuleRepo *repository.RateRuleRepository
+	roomRepo     *repository.RoomRepository
+	taxRuleRepo  *repository.TaxRuleRepository
+}
+
+// NewPricingService creates a new PricingService
+func NewPricingService(rateRuleRepo *repository.RateRuleRepository, roomRepo *repository.RoomRepository, taxRuleRepo *repository.TaxRuleRepository) *PricingService {
+	return &PricingService{
+		rateRuleRepo: rateRuleRepo,
+		roomRepo:     roomRepo,
+		taxRuleRepo:  taxRuleRepo,
+	}
+}
+
+// Quote prices a prospective stay in a room without booking it
+func (s *PricingService) Quote(roomID string, startDate, endDate time.Time) (model.PriceQuote, error) {
+	// Validate dates
+	if !startDate.Before(endDate) {
+		return model.PriceQuote{}, errors.New("start date must be before end date")
+	}
+
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return model.PriceQuote{}, err
+	}
+
+	breakdown, err := s.PriceStay(room, startDate, endDate)
+	if err != nil {
+		return model.PriceQuote{}, err
+	}
+
+	return model.PriceQuote{
+		RoomID:         room.ID,
+		RoomTypeID:     room.TypeID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		NumberOfNights: len(breakdown.Nights),
+		Breakdown:      breakdown,
+		TotalPrice:     breakdown.Total,
+		TaxTotal:       breakdown.Taxes.Total,
+	}, nil
+}
+
+// PriceStay prices a stay in the room from startDate to endDate night by night
+func (s *PricingService) PriceStay(room model.Room, startDate, endDate time.Time) (model.PriceBreakdown, error) {
+	rules, err := s.rateRuleRepo.ListApplicable(room.TypeID, startDate, endDate)
+	if err != nil {
+		return model.PriceBreakdown{}, err
+	}
+
+	// Occupancy is only looked up when a rule depends on it
+	var occupancy map[string]float64
+	for _, rule := range rules {
+		if rule.RuleType == model.RateRuleOccupancy {
+			occupancy, err = s.rateRuleRepo.OccupancyByDate(room.TypeID, startDate, endDate)
+			if err != nil {
+				return model.PriceBreakdown{}, err
+			}
+			break
+		}
+	}
+
+	taxRules, err := s.taxRuleRepo.ListEffective(startDate, endDate)
+	if err != nil {
+		return model.PriceBreakdown{}, err
+	}
+
+	breakdown := priceStay(room, rules, occupancy, startDate, endDate)
+	breakdown.Taxes = taxStay(breakdown, taxRules)
+
+	return breakdown, nil
+}
+
+// taxStay levies the tax rules on each night of a priced stay at the rates in force on
+// that night, so a rate change part way through a stay applies from the night it starts
+func taxStay(breakdown model.PriceBreakdown, rules []tax.Rule) tax.Breakdown {
+	taxes := tax.Breakdown{Lines: []tax.Line{}, Total: money.Zero(breakdown.Total.Currency)}
+	for _, night := range breakdown.Nights {
+		taxes = taxes.Add(tax.Apply(rules, night.Price, night.Date))
+	}
+
+	return taxes
+}
+
+// priceStay applies the rate rules to each night of a stay. Percent adjustments are
+// taken from the room's base price, so rules never compound. Every matching weekday,
+// weekend and season rule applies; of the occupancy and length-of-stay rules only the
+// one with the highest threshold reached applies.
+func priceStay(room model.Room, rules []model.RateRule, occupancy map[string]float64, startDate, endDate time.Time) model.PriceBreakdown {
+	nights := stayNights(startDate, endDate)
+	first := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Pick the length-of-stay rule with the highest threshold the stay reaches
+	var lengthOfStay *model.RateRule
+	for i, rule := range rules {
+		if rule.RuleType == model.RateRuleLengthOfStay && nights >= rule.MinNights {
+			if lengthOfStay == nil || rule.MinNights > lengthOfStay.MinNights {
+				lengthOfStay = &rules[i]
+			}
+		}
+	}
+
+	breakdown := model.PriceBreakdown{Nights: []model.NightlyPrice{}, Total: money.Zero(room.PricePerDay.Currency)}
+	for i := 0; i < nights; i++ {
+		date := first.AddDate(0, 0, i)
+		night := model.NightlyPrice{
+			Date:      date,
+			BasePrice: room.PricePerDay,
+		}
+
+		var occupancyRule *model.RateRule
+		for j, rule := range rules {
+			switch rule.RuleType {
+			case model.RateRuleWeekday:
+				if !isWeekendNight(date) {
+					night.Adjustments = append(night.Adjustments, adjust(rule, room.PricePerDay))
+				}
+			case model.RateRuleWeekend:
+				if isWeekendNight(date) {
+					night.Adjustments = append(night.Adjustments, adjust(rule, room.PricePerDay))
+				}
+			case model.RateRuleSeason:
+				if inSeason(rule, date) {
+					night.Adjustments = append(night.Adjustments, adjust(rule, room.PricePerDay))
+				}
+			case model.RateRuleOccupancy:
+				if occupancy[date.Format("2006-01-02")] >= rule.MinOccupancy {
+					if occupancyRule == nil || rule.MinOccupancy > occupancyRule.MinOccupancy {
+						occupancyRule = &rules[j]
+					}
+				}
+			}
+		}
+
+		if occupancyRule != nil {
+			night.Adjustments = append(night.Adjustments, adjust(*occupancyRule, room.PricePerDay))
+		}
+
+		if lengthOfStay != nil {
+			night.Adjustments = append(night.Adjustments, adjust(*lengthOfStay, room.PricePerDay))
+		}
+
+		price := night.BasePrice
+		for _, adjustment := range night.Adjustments {
+			price = price.Add(adjustment.Amount)
+		}
+		night.Price = price.Max(money.Zero(price.Currency))
+
+		breakdown.Nights = append(breakdown.Nights, night)
+		breakdown.Total = breakdown.Total.Add(night.Price)
+	}
+
+	return breakdown
+}
+
+// stayNights returns the number of nights between startDate and endDate
+func stayNights(startDate, endDate time.Time) int {
+	return int(math.Ceil(endDate.Sub(startDate).Hours() / 24))
+}
+
+// isWeekendNight reports whether the night starting on date is a Friday or Saturday night
+func isWeekendNight(date time.Time) bool {
+	return date.Weekday() == time.Friday || date.Weekday() == time.Saturday
+}
+
+// inSeason reports whether date falls within a season rule's inclusive date range
+func inSeason(rule model.RateRule, date time.Time) bool {
+	if rule.StartDate == nil || rule.EndDate == nil {
+		return false
+	}
+
+	day := date.Format("2006-01-02")
+	return day >= rule.StartDate.Format("2006-01-02") && day <= rule.EndDate.Format("2006-01-02")
+}
+
+// adjust computes a rule's adjustment to a night priced at basePrice
+func adjust(rule model.RateRule, basePrice money.Money) model.PriceAdjustment {
+	amount := money.FromFloat(rule.Adjustment, basePrice.Currency)
+	if rule.AdjustmentType == model.AdjustmentPercent {
+		amount = basePrice.Percent(rule.Adjustment)
+	}
+
+	return model.PriceAdjustment{
+		RuleID:   rule.ID,
+		RuleName: rule.Name,
+		RuleType: rule.RuleType,
+		Amount:   amount,
+	}
+}
+
+// validateRateRule checks that a rate rule has the fields its type needs
+func validateRateRule(rule model.RateRule) error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	switch rule.AdjustmentType {
+	case model.AdjustmentPercent, model.AdjustmentAmount:
+	default:
+		return errors.New("adjustment type must be percent or amount")
+	}
+
+	switch rule.RuleType {
+	case model.RateRuleWeekday, model.RateRuleWeekend:
+	case model.RateRuleSeason:
+		if rule.StartDate == nil || rule.EndDate == nil {
+			return errors.New("season rules require a start date and an end date")
+		}
+		if rule.EndDate.Before(*rule.StartDate) {
+			return errors.New("end date cannot be before start date")
+		}
+	case model.RateRuleLengthOfStay:
+		if rule.MinNights <= 0 {
+			return errors.New("length of stay rules require min nights")
+		}
+	case model.RateRuleOccupancy:
+		if rule.MinOccupancy <= 0 || rule.MinOccupancy > 100 {
+			return errors.New("occupancy rules require a min occupancy between 0 and 100")
+		}
+	default:
+		return errors.New("invalid rule type")
+	}
+
+	return nil
+}
+
+// CreateRateRule creates a new rate rule
+func (s *PricingService) CreateRateRule(rule model.RateRule) (model.RateRule, error) {
+	if err := validateRateRule(rule); err != nil {
+		return model.RateRule{}, err
+	}
+
+	return s.rateRuleRepo.Create(rule)
+}
+
+// GetRateRuleByID gets a rate rule by ID
+func (s *PricingService) GetRateRuleByID(id string) (model.RateRule, error) {
+	return s.rateRuleRepo.GetByID(id)
+}
+
+// UpdateRateRule updates a rate rule. Existing bookings keep the price they were made at.
+func (s *PricingService) UpdateRateRule(id string, rule model.RateRule) (model.RateRule, error) {
+	if err := validateRateRule(rule); err != nil {
+		return model.RateRule{}, err
+	}
+
+	// Check if rate rule exists
+	existingRule, err := s.rateRuleRepo.GetByID(id)
+	if err != nil {
+		return model.RateRule{}, err
+	}
+
+	rule.ID = existingRule.ID
+	rule.CreatedAt = existingRule.CreatedAt
+
+	return s.rateRuleRepo.Update(rule)
+}
+
+// DeleteRateRule deletes a rate rule
+func (s *PricingService) DeleteRateRule(id string) error {
+	return s.rateRuleRepo.Delete(id)
+}
+
+// ListRateRules lists all rate rules
+func (s *PricingService) ListRateRules(limit, offset int) ([]model.RateRule, error) {
+	return s.rateRuleRepo.List(limit, offset)
+}
+
+// CreateTaxRule creates a new tax or service charge rule
+func (s *PricingService) CreateTaxRule(rule tax.Rule) (tax.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return tax.Rule{}, err
+	}
+
+	return s.taxRuleRepo.Create(rule)
+}
+
+// GetTaxRuleByID gets a tax rule by ID
+func (s *PricingService) GetTaxRuleByID(id string) (tax.Rule, error) {
+	return s.taxRuleRepo.GetByID(id)
+}
+
+// UpdateTaxRule updates a tax rule. Existing bookings keep the taxes they were priced with.
+func (s *PricingService) UpdateTaxRule(id string, rule tax.Rule) (tax.Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return tax.Rule{}, err
+	}
+
+	// Check if tax rule exists
+	existingRule, err := s.taxRuleRepo.GetByID(id)
+	if err != nil {
+		return tax.Rule{}, err
+	}
+
+	rule.ID = existingRule.ID
+	rule.CreatedAt = existingRule.CreatedAt
+
+	return s.taxRuleRepo.Update(rule)
+}
+
+// DeleteTaxRule deletes a tax rule
+func (s *PricingService) DeleteTaxRule(id string) error {
+	return s.taxRuleRepo.Delete(id)
+}
+
+// ListTaxRules lists all tax rules
+func (s *PricingService) ListTaxRules(limit, offset int) ([]tax.Rule, error) {
+	return s.taxRuleRepo.List(limit, offset)
+}
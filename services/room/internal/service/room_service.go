@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/flaminshinjan/address.ai/services/room/internal/model"
@@ -10,18 +11,53 @@ import (
 
 // RoomService handles business logic for rooms
 type RoomService struct {
-	roomRepo    *repository.RoomRepository
-	bookingRepo *repository.BookingRepository
+	roomRepo         *repository.RoomRepository
+	bookingRepo      *repository.BookingRepository
+	roomTypeRepo     *repository.RoomTypeRepository
+	housekeepingRepo *repository.HousekeepingRepository
 }
 
 // NewRoomService creates a new RoomService
-func NewRoomService(roomRepo *repository.RoomRepository, bookingRepo *repository.BookingRepository) *RoomService {
+func NewRoomService(roomRepo *repository.RoomRepository, bookingRepo *repository.BookingRepository, roomTypeRepo *repository.RoomTypeRepository, housekeepingRepo *repository.HousekeepingRepository) *RoomService {
 	return &RoomService{
-		roomRepo:    roomRepo,
-		bookingRepo: bookingRepo,
+		roomRepo:         roomRepo,
+		bookingRepo:      bookingRepo,
+		roomTypeRepo:     roomTypeRepo,
+		housekeepingRepo: housekeepingRepo,
 	}
 }
 
+// applyRoomType links a room to its room type, looked up by TypeID or, failing that,
+// by the Type name. Capacity and price fall back to the type's defaults when unset.
+func (s *RoomService) applyRoomType(room *model.Room) error {
+	var roomType model.RoomType
+	var err error
+	switch {
+	case room.TypeID != "":
+		roomType, err = s.roomTypeRepo.GetByID(room.TypeID)
+	case room.Type != "":
+		roomType, err = s.roomTypeRepo.GetByName(room.Type)
+	default:
+		return errors.New("room type is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	room.TypeID = roomType.ID
+	room.Type = roomType.Name
+
+	if room.Capacity == 0 {
+		room.Capacity = roomType.DefaultCapacity
+	}
+
+	if room.PricePerDay.IsZero() {
+		room.PricePerDay = roomType.BasePrice
+	}
+
+	return nil
+}
+
 // CreateRoom creates a new room
 func (s *RoomService) CreateRoom(room model.Room) (model.RoomResponse, error) {
 	// Check if room number already exists
@@ -30,6 +66,10 @@ func (s *RoomService) CreateRoom(room model.Room) (model.RoomResponse, error) {
 		return model.RoomResponse{}, errors.New("room number already exists")
 	}
 
+	if err := s.applyRoomType(&room); err != nil {
+		return model.RoomResponse{}, err
+	}
+
 	// Create room
 	createdRoom, err := s.roomRepo.Create(room)
 	if err != nil {
@@ -65,8 +105,13 @@ func (s *RoomService) UpdateRoom(id string, room model.Room) (model.RoomResponse
 		}
 	}
 
+	if err := s.applyRoomType(&room); err != nil {
+		return model.RoomResponse{}, err
+	}
+
 	// Update fields
 	existingRoom.Number = room.Number
+	existingRoom.TypeID = room.TypeID
 	existingRoom.Type = room.Type
 	existingRoom.Floor = room.Floor
 	existingRoom.Description = room.Description
@@ -138,10 +183,51 @@ func (s *RoomService) ListAvailableRooms(startDate, endDate time.Time, limit, of
 	return roomResponses, nil
 }
 
-// UpdateRoomStatus updates a room's status
+// maxCalendarNights caps the window of the occupancy calendar
+const maxCalendarNights = 93
+
+// GetOccupancyCalendar builds the rooms × dates occupancy matrix for [startDate, endDate),
+// optionally limited to one room type
+func (s *RoomService) GetOccupancyCalendar(startDate, endDate time.Time, roomTypeID string) (model.OccupancyCalendar, error) {
+	// Validate dates
+	if !startDate.Before(endDate) {
+		return model.OccupancyCalendar{}, errors.New("start date must be before end date")
+	}
+
+	nights := stayNights(startDate, endDate)
+	if nights > maxCalendarNights {
+		return model.OccupancyCalendar{}, fmt.Errorf("calendar window cannot exceed %d nights", maxCalendarNights)
+	}
+
+	if roomTypeID != "" {
+		if _, err := s.roomTypeRepo.GetByID(roomTypeID); err != nil {
+			return model.OccupancyCalendar{}, err
+		}
+	}
+
+	rooms, err := s.roomRepo.Occupancy(startDate, endDate, roomTypeID)
+	if err != nil {
+		return model.OccupancyCalendar{}, err
+	}
+
+	dates := make([]string, 0, nights)
+	for day := 0; day < nights; day++ {
+		dates = append(dates, startDate.AddDate(0, 0, day).Format("2006-01-02"))
+	}
+
+	return model.OccupancyCalendar{
+		StartDate: startDate,
+		EndDate:   endDate,
+		Dates:     dates,
+		Rooms:     rooms,
+	}, nil
+}
+
+// UpdateRoomStatus updates a room's status. A room being cleaned cannot be made available
+// while its clean or inspection is still pending.
 func (s *RoomService) UpdateRoomStatus(id, status string) error {
 	// Check if room exists
-	_, err := s.roomRepo.GetByID(id)
+	room, err := s.roomRepo.GetByID(id)
 	if err != nil {
 		return err
 	}
@@ -150,6 +236,7 @@ func (s *RoomService) UpdateRoomStatus(id, status string) error {
 	validStatuses := map[string]bool{
 		"available":   true,
 		"occupied":    true,
+		"cleaning":    true,
 		"maintenance": true,
 	}
 
@@ -157,5 +244,15 @@ func (s *RoomService) UpdateRoomStatus(id, status string) error {
 		return errors.New("invalid status")
 	}
 
+	if room.Status == "cleaning" && status == "available" {
+		open, err := s.housekeepingRepo.HasOpenCleaning(id)
+		if err != nil {
+			return err
+		}
+		if open {
+			return errors.New("room cleaning has not been inspected")
+		}
+	}
+
 	return s.roomRepo.UpdateStatus(id, status)
 }
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// RoomTypeService handles business logic for room types
+type RoomTypeService struct {
+	roomTypeRepo *repository.RoomTypeRepository
+}
+
+// NewRoomTypeService creates a new RoomTypeService
+func NewRoomTypeService(roomTypeRepo *repository.RoomTypeRepository) *RoomTypeService {
+	return &RoomTypeService{
+		roomTypeRepo: roomTypeRepo,
+	}
+}
+
+// validateRoomType checks the fields every room type must have
+func validateRoomType(roomType model.RoomType) error {
+	if strings.TrimSpace(roomType.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	if roomType.BasePrice.IsNegative() {
+		return errors.New("base price cannot be negative")
+	}
+
+	if roomType.DefaultCapacity <= 0 {
+		return errors.New("default capacity must be greater than zero")
+	}
+
+	switch roomType.DepositType {
+	case "":
+	case model.DepositPercent:
+		if roomType.DepositPercent <= 0 || roomType.DepositPercent > 100 {
+			return errors.New("deposit percent must be between 0 and 100")
+		}
+	case model.DepositFixed:
+		if !roomType.DepositAmount.IsPositive() {
+			return errors.New("deposit amount must be positive")
+		}
+	default:
+		return errors.New("deposit type must be percent or fixed")
+	}
+
+	if roomType.DepositType != "" && roomType.DepositDueHours <= 0 {
+		return errors.New("deposit due hours must be greater than zero")
+	}
+
+	return nil
+}
+
+// CreateRoomType creates a new room type
+func (s *RoomTypeService) CreateRoomType(roomType model.RoomType) (model.RoomType, error) {
+	if err := validateRoomType(roomType); err != nil {
+		return model.RoomType{}, err
+	}
+
+	// Check if name already exists
+	_, err := s.roomTypeRepo.GetByName(roomType.Name)
+	if err == nil {
+		return model.RoomType{}, errors.New("room type name already exists")
+	}
+
+	return s.roomTypeRepo.Create(roomType)
+}
+
+// GetRoomTypeByID gets a room type by ID
+func (s *RoomTypeService) GetRoomTypeByID(id string) (model.RoomType, error) {
+	return s.roomTypeRepo.GetByID(id)
+}
+
+// UpdateRoomType updates a room type
+func (s *RoomTypeService) UpdateRoomType(id string, roomType model.RoomType) (model.RoomType, error) {
+	if err := validateRoomType(roomType); err != nil {
+		return model.RoomType{}, err
+	}
+
+	// Check if room type exists
+	existingRoomType, err := s.roomTypeRepo.GetByID(id)
+	if err != nil {
+		return model.RoomType{}, err
+	}
+
+	// Check if name already exists (if changing name)
+	if roomType.Name != existingRoomType.Name {
+		_, err := s.roomTypeRepo.GetByName(roomType.Name)
+		if err == nil {
+			return model.RoomType{}, errors.New("room type name already exists")
+		}
+	}
+
+	// Update fields
+	existingRoomType.Name = roomType.Name
+	existingRoomType.Description = roomType.Description
+	existingRoomType.BasePrice = roomType.BasePrice
+	existingRoomType.DefaultCapacity = roomType.DefaultCapacity
+	existingRoomType.Amenities = roomType.Amenities
+	existingRoomType.CancellationPolicyID = roomType.CancellationPolicyID
+	existingRoomType.DepositType = roomType.DepositType
+	existingRoomType.DepositPercent = roomType.DepositPercent
+	existingRoomType.DepositAmount = roomType.DepositAmount
+	existingRoomType.DepositDueHours = roomType.DepositDueHours
+
+	return s.roomTypeRepo.Update(existingRoomType)
+}
+
+// DeleteRoomType deletes a room type
+func (s *RoomTypeService) DeleteRoomType(id string) error {
+	// Check if any room still uses the type
+	count, err := s.roomTypeRepo.CountRooms(id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("cannot delete room type with rooms")
+	}
+
+	return s.roomTypeRepo.Delete(id)
+}
+
+// ListRoomTypes lists all room types
+func (s *RoomTypeService) ListRoomTypes(limit, offset int) ([]model.RoomType, error) {
+	return s.roomTypeRepo.List(limit, offset)
+}
+
+// GetAvailability counts how many rooms of a type are free for the given dates.
+// The type is looked up by ID, or by name when id is empty.
+func (s *RoomTypeService) GetAvailability(id, name string, startDate, endDate time.Time) (model.RoomTypeAvailability, error) {
+	// Validate dates
+	if !startDate.Before(endDate) {
+		return model.RoomTypeAvailability{}, errors.New("start date must be before end date")
+	}
+
+	var roomType model.RoomType
+	var err error
+	switch {
+	case id != "":
+		roomType, err = s.roomTypeRepo.GetByID(id)
+	case name != "":
+		roomType, err = s.roomTypeRepo.GetByName(name)
+	default:
+		return model.RoomTypeAvailability{}, errors.New("type_id or name is required")
+	}
+	if err != nil {
+		return model.RoomTypeAvailability{}, err
+	}
+
+	total, available, err := s.roomTypeRepo.CountAvailable(roomType.ID, startDate, endDate)
+	if err != nil {
+		return model.RoomTypeAvailability{}, err
+	}
+
+	return model.RoomTypeAvailability{
+		RoomTypeID:     roomType.ID,
+		Name:           roomType.Name,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		TotalRooms:     total,
+		AvailableRooms: available,
+	}, nil
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/notify"
+	"github.com/flaminshinjan/address.ai/services/room/internal/model"
+	"github.com/flaminshinjan/address.ai/services/room/internal/repository"
+)
+
+// WaitlistService handles business logic for the waitlist
+type WaitlistService struct {
+	waitlistRepo   *repository.WaitlistRepository
+	bookingRepo    *repository.BookingRepository
+	roomRepo       *repository.RoomRepository
+	roomTypeRepo   *repository.RoomTypeRepository
+	pricingService *PricingService
+	paymentService *PaymentService
+	hub            *notify.Hub
+	holdTTL        time.Duration
+}
+
+// NewWaitlistService creates a new WaitlistService. Offered rooms are held for holdTTL,
+// and guests are told of changes to the bookings held for them through hub.
+func NewWaitlistService(
+	waitlistRepo *repository.WaitlistRepository,
+	bookingRepo *repository.BookingRepository,
+	roomRepo *repository.RoomRepository,
+	roomTypeRepo *repository.RoomTypeRepository,
+	pricingService *PricingService,
+	paymentService *PaymentService,
+	hub *notify.Hub,
+	holdTTL time.Duration,
+) *WaitlistService {
+	return &WaitlistService{
+		waitlistRepo:   waitlistRepo,
+		bookingRepo:    bookingRepo,
+		roomRepo:       roomRepo,
+		roomTypeRepo:   roomTypeRepo,
+		pricingService: pricingService,
+		paymentService: paymentService,
+		hub:            hub,
+		holdTTL:        holdTTL,
+	}
+}
+
+// JoinWaitlist registers a guest's interest in a room or a room type for dates that are
+// currently fully booked
+func (s *WaitlistService) JoinWaitlist(userID string, req model.WaitlistRequest) (model.WaitlistEntry, error) {
+	// Validate dates
+	if !req.StartDate.Before(req.EndDate) {
+		return model.WaitlistEntry{}, errors.New("start date must be before end date")
+	}
+
+	if req.StartDate.Before(time.Now()) {
+		return model.WaitlistEntry{}, errors.New("start date must be in the future")
+	}
+
+	switch {
+	case req.RoomID != "" && req.RoomTypeID != "":
+		return model.WaitlistEntry{}, errors.New("only one of room_id and room_type_id can be given")
+	case req.RoomID != "":
+		room, err := s.roomRepo.GetByID(req.RoomID)
+		if err != nil {
+			return model.WaitlistEntry{}, err
+		}
+
+		available, err := s.bookingRepo.CheckRoomAvailability(room.ID, req.StartDate, req.EndDate)
+		if err != nil {
+			return model.WaitlistEntry{}, err
+		}
+
+		outOfService, err := s.roomRepo.IsOutOfService(room.ID)
+		if err != nil {
+			return model.WaitlistEntry{}, err
+		}
+
+		if available && !outOfService {
+			return model.WaitlistEntry{}, errors.New("room is available for the given dates; book it directly")
+		}
+	case req.RoomTypeID != "":
+		roomType, err := s.roomTypeRepo.GetByID(req.RoomTypeID)
+		if err != nil {
+			return model.WaitlistEntry{}, err
+		}
+
+		_, available, err := s.roomTypeRepo.CountAvailable(roomType.ID, req.StartDate, req.EndDate)
+		if err != nil {
+			return model.WaitlistEntry{}, err
+		}
+
+		if available > 0 {
+			return model.WaitlistEntry{}, errors.New("rooms of this type are available for the given dates; book one directly")
+		}
+	default:
+		return model.WaitlistEntry{}, errors.New("room_id or room_type_id is required")
+	}
+
+	return s.waitlistRepo.Create(model.WaitlistEntry{
+		UserID:     userID,
+		RoomID:     req.RoomID,
+		RoomTypeID: req.RoomTypeID,
+		StartDate:  req.StartDate,
+		EndDate:    req.EndDate,
+		Status:     "waiting",
+	})
+}
+
+// GetEntryByID gets a waitlist entry by ID
+func (s *WaitlistService) GetEntryByID(id string) (model.WaitlistEntry, error) {
+	return s.waitlistRepo.GetByID(id)
+}
+
+// ListUserEntries lists a guest's waitlist entries
+func (s *WaitlistService) ListUserEntries(userID string, limit, offset int) ([]model.WaitlistEntry, error) {
+	return s.waitlistRepo.ListByUserID(userID, limit, offset)
+}
+
+// ListEntries lists all waitlist entries
+func (s *WaitlistService) ListEntries(limit, offset int) ([]model.WaitlistEntry, error) {
+	return s.waitlistRepo.List(limit, offset)
+}
+
+// CancelEntry takes a waiting entry off the waitlist
+func (s *WaitlistService) CancelEntry(id string) error {
+	return s.waitlistRepo.Cancel(id)
+}
+
+// OfferSlot offers a room that has just been freed for [startDate, endDate) to the
+// matching waitlist entries in the order they joined. Each entry whose dates are still
+// free is given a held booking that expires after the hold TTL; entries clashing with
+// an earlier offer are left waiting.
+func (s *WaitlistService) OfferSlot(roomID string, startDate, endDate time.Time) error {
+	room, err := s.roomRepo.GetByID(roomID)
+	if err != nil {
+		return err
+	}
+
+	outOfService, err := s.roomRepo.IsOutOfService(room.ID)
+	if err != nil {
+		return err
+	}
+
+	if outOfService {
+		return nil
+	}
+
+	entries, err := s.waitlistRepo.ListWaiting(room.ID, room.TypeID, startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	roomType, err := roomTypeOf(s.roomTypeRepo, room)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		breakdown, err := s.pricingService.PriceStay(room, entry.StartDate, entry.EndDate)
+		if err != nil {
+			return err
+		}
+
+		// The deposit falls due from when the offer is made, as for a checkout hold
+		now := time.Now()
+		deposit, depositDueAt := stayDeposit(roomType, breakdown, entry.StartDate, now)
+		expiresAt := now.Add(s.holdTTL)
+		booking := model.Booking{
+			RoomID:         room.ID,
+			UserID:         entry.UserID,
+			StartDate:      entry.StartDate,
+			EndDate:        entry.EndDate,
+			TotalPrice:     breakdown.Total,
+			Status:         "held",
+			PriceBreakdown: breakdown,
+			HoldExpiresAt:  &expiresAt,
+			DepositAmount:  deposit,
+			DepositDueAt:   depositDueAt,
+		}
+
+		_, held, err := s.waitlistRepo.Offer(entry, booking)
+		if errors.Is(err, model.ErrBookingConflict) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		notifyBooking(s.hub, bookingStatusChanged, held)
+	}
+
+	return nil
+}
+
+// AcceptOffer confirms the room held for a waitlist entry once the stay is authorized on
+// the guest's payment method
+func (s *WaitlistService) AcceptOffer(id string, req model.PaymentRequest) (model.BookingResponse, error) {
+	entry, err := s.waitlistRepo.GetByID(id)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	if entry.Status != "offered" || entry.OfferedBookingID == "" {
+		return model.BookingResponse{}, errors.New("no open offer for this waitlist entry")
+	}
+
+	hold, err := s.bookingRepo.GetByID(entry.OfferedBookingID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	// The stay is authorized before the offer is accepted, and released if it cannot be
+	if _, err := s.paymentService.AuthorizeBooking(hold, req.PaymentToken); err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	_, booking, err := s.waitlistRepo.Accept(id)
+	if err != nil {
+		if voidErr := s.paymentService.VoidBooking(hold.ID); voidErr != nil {
+			log.Printf("Failed to void payment for booking %s: %v", hold.ID, voidErr)
+		}
+		return model.BookingResponse{}, err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, booking)
+
+	// A deposit the stay takes is charged to the same payment method; if that fails the
+	// booking stands, and the deposit can be paid until it is due
+	if booking.DepositOutstanding() {
+		if _, err := s.paymentService.PayDeposit(booking, req.PaymentToken); err != nil {
+			log.Printf("Failed to take deposit for booking %s: %v", booking.ID, err)
+		} else if booking, err = s.bookingRepo.GetByID(booking.ID); err != nil {
+			return model.BookingResponse{}, err
+		}
+	}
+
+	room, err := s.roomRepo.GetByID(booking.RoomID)
+	if err != nil {
+		return model.BookingResponse{}, err
+	}
+
+	return newBookingResponse(booking, room), nil
+}
+
+// DeclineOffer gives up the room held for a waitlist entry and offers it to the next in line
+func (s *WaitlistService) DeclineOffer(id string) error {
+	released, err := s.waitlistRepo.Release(id, "declined")
+	if err != nil {
+		return err
+	}
+	notifyBooking(s.hub, bookingStatusChanged, released)
+
+	return s.OfferSlot(released.RoomID, released.StartDate, released.EndDate)
+}
+
+// ExpireOffers releases the rooms of offers that ran out and offers them to the next in line
+func (s *WaitlistService) ExpireOffers() error {
+	entries, err := s.waitlistRepo.ListExpiredOffers()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		released, err := s.waitlistRepo.Release(entry.ID, "expired")
+		if err != nil {
+			// Accepted or released concurrently
+			log.Printf("Failed to expire waitlist offer %s: %v", entry.ID, err)
+			continue
+		}
+		notifyBooking(s.hub, bookingStatusChanged, released)
+
+		if err := s.OfferSlot(released.RoomID, released.StartDate, released.EndDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
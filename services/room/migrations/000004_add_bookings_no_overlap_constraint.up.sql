@@ -0,0 +1,11 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Reject overlapping stays for the same room at the database level. Ranges are
+-- half-open so a new stay may start on the day the previous one ends.
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status = 'confirmed');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bookings_group_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS booking_groups;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS booking_groups (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'confirmed',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_group_dates CHECK (end_date > start_date)
+);
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES booking_groups(id);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_group_id ON bookings(group_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_rooms_room_type_id;
+
+ALTER TABLE rooms DROP COLUMN IF EXISTS room_type_id;
+
+DROP TABLE IF EXISTS room_types;
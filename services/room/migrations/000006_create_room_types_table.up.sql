@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS room_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT,
+    base_price DECIMAL(10,2) NOT NULL,
+    default_capacity INT NOT NULL DEFAULT 1,
+    amenities TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS room_type_id UUID REFERENCES room_types(id);
+
+CREATE INDEX IF NOT EXISTS idx_rooms_room_type_id ON rooms(room_type_id);
+
+-- Build the catalogue from the free-text types already in use and link existing rooms to it
+INSERT INTO room_types (name, base_price, default_capacity)
+SELECT type, MIN(price_per_day), MAX(capacity)
+FROM rooms
+GROUP BY type
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE rooms r
+SET room_type_id = rt.id
+FROM room_types rt
+WHERE rt.name = r.type
+AND r.room_type_id IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS price_breakdown;
+
+DROP TABLE IF EXISTS rate_rules;
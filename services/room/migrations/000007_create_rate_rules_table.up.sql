@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS rate_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    rule_type VARCHAR(20) NOT NULL CHECK (rule_type IN ('weekday', 'weekend', 'season', 'length_of_stay', 'occupancy')),
+    room_type_id UUID REFERENCES room_types(id) ON DELETE CASCADE,
+    start_date DATE,
+    end_date DATE,
+    min_nights INT NOT NULL DEFAULT 0,
+    min_occupancy DECIMAL(5,2) NOT NULL DEFAULT 0,
+    adjustment_type VARCHAR(10) NOT NULL CHECK (adjustment_type IN ('percent', 'amount')),
+    adjustment DECIMAL(10,2) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_season_dates CHECK (rule_type <> 'season' OR (start_date IS NOT NULL AND end_date >= start_date))
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_rules_room_type_id ON rate_rules(room_type_id);
+
+-- The per-night pricing a booking was made with
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS price_breakdown JSONB;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS booking_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    changed_by VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    old_room_id UUID NOT NULL,
+    new_room_id UUID NOT NULL,
+    old_start_date DATE NOT NULL,
+    new_start_date DATE NOT NULL,
+    old_end_date DATE NOT NULL,
+    new_end_date DATE NOT NULL,
+    old_total_price DECIMAL(10,2) NOT NULL,
+    new_total_price DECIMAL(10,2) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_history_booking_id ON booking_history(booking_id);
@@ -0,0 +1,11 @@
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status = 'confirmed');
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS checked_out_at;
+ALTER TABLE bookings DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,12 @@
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS checked_out_at TIMESTAMP WITH TIME ZONE;
+
+-- A checked-in stay keeps holding its room
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('confirmed', 'checked_in'));
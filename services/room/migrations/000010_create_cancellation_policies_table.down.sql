@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS booking_cancellations;
+
+ALTER TABLE rate_rules DROP COLUMN IF EXISTS cancellation_policy_id;
+ALTER TABLE room_types DROP COLUMN IF EXISTS cancellation_policy_id;
+
+DROP TABLE IF EXISTS cancellation_policies;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS cancellation_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    tiers JSONB NOT NULL DEFAULT '[]',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- At most one default policy
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cancellation_policies_default ON cancellation_policies(is_default) WHERE is_default;
+
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS cancellation_policy_id UUID REFERENCES cancellation_policies(id) ON DELETE SET NULL;
+ALTER TABLE rate_rules ADD COLUMN IF NOT EXISTS cancellation_policy_id UUID REFERENCES cancellation_policies(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS booking_cancellations (
+    booking_id UUID PRIMARY KEY REFERENCES bookings(id) ON DELETE CASCADE,
+    policy_id UUID REFERENCES cancellation_policies(id) ON DELETE SET NULL,
+    policy_name VARCHAR(100) NOT NULL,
+    rule_applied TEXT NOT NULL,
+    hours_before_start DECIMAL(10,2) NOT NULL,
+    penalty DECIMAL(10,2) NOT NULL DEFAULT 0,
+    refund_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    cancelled_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Seed a default policy: free until 48 hours before check-in, then one night charged
+INSERT INTO cancellation_policies (name, description, tiers, is_default)
+VALUES ('Flexible', 'Free cancellation until 48 hours before check-in, then one night is charged',
+    '[{"hours_before_start": 48, "penalty_type": "none", "penalty_value": 0}, {"hours_before_start": 0, "penalty_type": "nights", "penalty_value": 1}]',
+    TRUE)
+ON CONFLICT DO NOTHING;
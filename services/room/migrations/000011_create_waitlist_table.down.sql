@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS waitlist_entries;
+
+UPDATE bookings SET status = 'released' WHERE status = 'held';
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('confirmed', 'checked_in'));
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS hold_expires_at;
@@ -0,0 +1,30 @@
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS hold_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- A held room is taken until the hold is confirmed, released or expires
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('held', 'confirmed', 'checked_in'));
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id),
+    room_id UUID REFERENCES rooms(id) ON DELETE CASCADE,
+    room_type_id UUID REFERENCES room_types(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    offered_booking_id UUID REFERENCES bookings(id) ON DELETE SET NULL,
+    offer_expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT waitlist_target CHECK (room_id IS NOT NULL OR room_type_id IS NOT NULL),
+    CONSTRAINT valid_waitlist_dates CHECK (end_date > start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_status ON waitlist_entries(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_user_id ON waitlist_entries(user_id);
@@ -0,0 +1,13 @@
+UPDATE bookings SET status = 'cancelled' WHERE status = 'external';
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('held', 'confirmed', 'checked_in'));
+
+DROP INDEX IF EXISTS idx_bookings_external_uid;
+ALTER TABLE bookings DROP COLUMN IF EXISTS external_uid;
+ALTER TABLE bookings DROP COLUMN IF EXISTS external_source;
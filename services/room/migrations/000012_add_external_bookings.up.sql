@@ -0,0 +1,18 @@
+-- External blocks imported from another calendar have no guest
+ALTER TABLE bookings ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS external_source VARCHAR(50);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS external_uid VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookings_external_uid
+    ON bookings(room_id, external_source, external_uid)
+    WHERE status = 'external';
+
+-- External blocks take the room like bookings do
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_no_overlap;
+ALTER TABLE bookings
+    ADD CONSTRAINT bookings_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('held', 'confirmed', 'checked_in', 'external'));
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS maintenance_windows (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT NOT NULL,
+    assigned_to VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_maintenance_dates CHECK (end_date > start_date)
+);
+
+-- Open windows of a room may not overlap each other
+ALTER TABLE maintenance_windows
+    ADD CONSTRAINT maintenance_windows_no_overlap
+    EXCLUDE USING gist (
+        room_id WITH =,
+        daterange(start_date, end_date, '[)') WITH &&
+    )
+    WHERE (status IN ('scheduled', 'active'));
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_status ON maintenance_windows(status, start_date);
+CREATE INDEX IF NOT EXISTS idx_maintenance_windows_assigned_to ON maintenance_windows(assigned_to);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS housekeeping_tasks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+    booking_id UUID REFERENCES bookings(id) ON DELETE SET NULL,
+    type VARCHAR(20) NOT NULL,
+    assigned_to VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    priority VARCHAR(10) NOT NULL DEFAULT 'normal',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    notes TEXT,
+    due_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    inspection_passed BOOLEAN,
+    completed_by VARCHAR(36),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_housekeeping_type CHECK (type IN ('clean', 'inspect', 'turndown')),
+    CONSTRAINT valid_housekeeping_priority CHECK (priority IN ('high', 'normal', 'low'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_housekeeping_tasks_assigned_to ON housekeeping_tasks(assigned_to, status);
+CREATE INDEX IF NOT EXISTS idx_housekeeping_tasks_room_id ON housekeeping_tasks(room_id, status);
+CREATE INDEX IF NOT EXISTS idx_housekeeping_tasks_due_date ON housekeeping_tasks(due_date, type);
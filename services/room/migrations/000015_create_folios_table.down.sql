@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS folio_lines;
+DROP TABLE IF EXISTS folios;
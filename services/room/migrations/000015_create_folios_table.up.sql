@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS folios (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) REFERENCES users(id),
+    room_id UUID NOT NULL REFERENCES rooms(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS folio_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    folio_id UUID NOT NULL REFERENCES folios(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    unit_price DECIMAL(10,2) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    source_id VARCHAR(36),
+    service_date DATE,
+    method VARCHAR(20),
+    reference TEXT,
+    posted_by VARCHAR(36),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_folio_line_type CHECK (type IN ('room', 'food', 'minibar', 'payment', 'adjustment'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_folios_status ON folios(status, opened_at);
+CREATE INDEX IF NOT EXISTS idx_folio_lines_folio_id ON folio_lines(folio_id);
+
+-- A food order is charged to at most one folio, once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_folio_lines_food_order ON folio_lines(source_id) WHERE type = 'food';
@@ -0,0 +1,34 @@
+-- One counter per property; incremented in the same transaction that stores the
+-- invoice, so a failed issue gives its number back and the sequence has no gaps
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    property VARCHAR(20) PRIMARY KEY,
+    last_number BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    number VARCHAR(40) NOT NULL UNIQUE,
+    property VARCHAR(20) NOT NULL,
+    sequence BIGINT NOT NULL,
+    source_type VARCHAR(20) NOT NULL,
+    source_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) REFERENCES users(id),
+    room_id UUID REFERENCES rooms(id),
+    room_number VARCHAR(20),
+    start_date DATE,
+    end_date DATE,
+    lines JSONB NOT NULL DEFAULT '[]'::jsonb,
+    taxes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    subtotal DECIMAL(10,2) NOT NULL,
+    tax_total DECIMAL(10,2) NOT NULL DEFAULT 0,
+    total DECIMAL(10,2) NOT NULL,
+    amount_paid DECIMAL(10,2) NOT NULL DEFAULT 0,
+    balance_due DECIMAL(10,2) NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT invoices_property_sequence_key UNIQUE (property, sequence),
+    CONSTRAINT invoices_source_key UNIQUE (source_type, source_id),
+    CONSTRAINT valid_invoice_source CHECK (source_type IN ('booking', 'food_order'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices(user_id, issued_at);
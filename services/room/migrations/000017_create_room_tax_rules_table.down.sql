@@ -0,0 +1,10 @@
+DELETE FROM folio_lines WHERE type = 'tax';
+ALTER TABLE folio_lines DROP CONSTRAINT IF EXISTS valid_folio_line_type;
+ALTER TABLE folio_lines
+    ADD CONSTRAINT valid_folio_line_type CHECK (type IN ('room', 'food', 'minibar', 'payment', 'adjustment'));
+ALTER TABLE folio_lines DROP COLUMN IF EXISTS base;
+ALTER TABLE folio_lines DROP COLUMN IF EXISTS rate;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS tax_total;
+
+DROP TABLE IF EXISTS room_tax_rules;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS room_tax_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('tax', 'service_charge')),
+    rate DECIMAL(5,2) NOT NULL CHECK (rate > 0 AND rate <= 100),
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_room_tax_rule_dates CHECK (effective_to IS NULL OR effective_to > effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_tax_rules_effective ON room_tax_rules(effective_from, effective_to);
+
+-- The taxes a booking was priced with are kept in its price breakdown
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS tax_total DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+-- Taxes and service charges are posted to folios with the rate and base they were levied at
+ALTER TABLE folio_lines ADD COLUMN IF NOT EXISTS rate DECIMAL(5,2);
+ALTER TABLE folio_lines ADD COLUMN IF NOT EXISTS base DECIMAL(10,2);
+ALTER TABLE folio_lines DROP CONSTRAINT IF EXISTS valid_folio_line_type;
+ALTER TABLE folio_lines
+    ADD CONSTRAINT valid_folio_line_type CHECK (type IN ('room', 'food', 'minibar', 'tax', 'payment', 'adjustment'));
@@ -0,0 +1,4 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE bookings DROP COLUMN IF EXISTS display_currency;
+
+DROP TABLE IF EXISTS currency_rates;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS currency_rates (
+    currency VARCHAR(3) PRIMARY KEY,
+    rate DECIMAL(18,8) NOT NULL CHECK (rate > 0),
+    updated_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Bookings keep the currency the guest viewed the price in and the rate it was converted at
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS display_currency VARCHAR(3);
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18,8);
@@ -0,0 +1,3 @@
+ALTER TABLE bookings DROP COLUMN IF EXISTS payment_status;
+
+DROP TABLE IF EXISTS booking_payments;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS booking_payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    gateway VARCHAR(50) NOT NULL,
+    transaction_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL,
+    amount DECIMAL(10,2) NOT NULL,
+    captured_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    refunded_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT valid_booking_payment_status CHECK (status IN ('authorized', 'captured', 'partially_refunded', 'refunded', 'voided', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_payments_booking_id ON booking_payments(booking_id);
+
+-- The status of a booking's latest payment, kept with the booking so it shows wherever the booking does
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS payment_status VARCHAR(20) NOT NULL DEFAULT 'unpaid';
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_bookings_deposit_due_at;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS deposit_due_at;
+ALTER TABLE bookings DROP COLUMN IF EXISTS amount_paid;
+ALTER TABLE bookings DROP COLUMN IF EXISTS deposit_amount;
+
+ALTER TABLE room_types DROP CONSTRAINT IF EXISTS valid_room_type_deposit_type;
+ALTER TABLE room_types DROP COLUMN IF EXISTS deposit_due_hours;
+ALTER TABLE room_types DROP COLUMN IF EXISTS deposit_amount;
+ALTER TABLE room_types DROP COLUMN IF EXISTS deposit_percent;
+ALTER TABLE room_types DROP COLUMN IF EXISTS deposit_type;
@@ -0,0 +1,13 @@
+-- The deposit a room type takes at booking time, as a percentage of the stay or a fixed amount
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS deposit_type VARCHAR(10);
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS deposit_percent DECIMAL(5,2) NOT NULL DEFAULT 0;
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS deposit_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS deposit_due_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE room_types ADD CONSTRAINT valid_room_type_deposit_type CHECK (deposit_type IS NULL OR deposit_type IN ('percent', 'fixed'));
+
+-- A booking's deposit and what has been paid towards the stay; the balance is the rest
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS deposit_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS amount_paid DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS deposit_due_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_deposit_due_at ON bookings(deposit_due_at) WHERE deposit_amount > 0;
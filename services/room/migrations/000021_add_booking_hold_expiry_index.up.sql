@@ -0,0 +1,2 @@
+-- Holds are swept for expiry by status and deadline
+CREATE INDEX IF NOT EXISTS idx_bookings_hold_expires_at ON bookings(hold_expires_at) WHERE status = 'held';
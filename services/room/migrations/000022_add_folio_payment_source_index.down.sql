@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_folio_lines_payment_source;
@@ -0,0 +1,2 @@
+-- A payment is credited to a folio at most once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_folio_lines_payment_source ON folio_lines(source_id) WHERE type = 'payment';
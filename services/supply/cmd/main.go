@@ -3,9 +3,11 @@ package main
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/flaminshinjan/address.ai/pkg/common/db"
 	"github.com/flaminshinjan/address.ai/pkg/common/logger"
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
 	"github.com/flaminshinjan/address.ai/services/supply/internal/handler"
 	"github.com/flaminshinjan/address.ai/services/supply/internal/repository"
 	"github.com/flaminshinjan/address.ai/services/supply/internal/service"
@@ -36,6 +38,11 @@ func main() {
 		log.Fatal("JWT_SECRET environment variable is required")
 	}
 
+	// Prices are stored in the property's base currency
+	if baseCurrency := os.Getenv("BASE_CURRENCY"); baseCurrency != "" {
+		money.DefaultCurrency = strings.ToUpper(baseCurrency)
+	}
+
 	logLevel := os.Getenv("LOG_LEVEL")
 	if logLevel == "" {
 		logLevel = "info" // Default log level
@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
 )
 
 // Supplier represents a supplier
@@ -19,41 +21,41 @@ type Supplier struct {
 
 // InventoryItem represents an inventory item
 type InventoryItem struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category"`
-	Description string    `json:"description,omitempty"`
-	Quantity    int       `json:"quantity"`
-	Unit        string    `json:"unit"`
-	MinQuantity int       `json:"min_quantity"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Category    string      `json:"category"`
+	Description string      `json:"description,omitempty"`
+	Quantity    int         `json:"quantity"`
+	Unit        string      `json:"unit"`
+	MinQuantity int         `json:"min_quantity"`
+	Price       money.Money `json:"price"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
 }
 
 // PurchaseOrder represents a purchase order
 type PurchaseOrder struct {
-	ID           string    `json:"id"`
-	SupplierID   string    `json:"supplier_id"`
-	Status       string    `json:"status"` // pending, approved, received, cancelled
-	TotalPrice   float64   `json:"total_price"`
-	Notes        string    `json:"notes,omitempty"`
-	OrderDate    time.Time `json:"order_date"`
-	DeliveryDate time.Time `json:"delivery_date,omitempty"`
-	CreatedBy    string    `json:"created_by"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           string      `json:"id"`
+	SupplierID   string      `json:"supplier_id"`
+	Status       string      `json:"status"` // pending, approved, received, cancelled
+	TotalPrice   money.Money `json:"total_price"`
+	Notes        string      `json:"notes,omitempty"`
+	OrderDate    time.Time   `json:"order_date"`
+	DeliveryDate time.Time   `json:"delivery_date,omitempty"`
+	CreatedBy    string      `json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 }
 
 // OrderItem represents an item in a purchase order
 type OrderItem struct {
-	ID              string    `json:"id"`
-	PurchaseOrderID string    `json:"purchase_order_id"`
-	InventoryItemID string    `json:"inventory_item_id"`
-	Quantity        int       `json:"quantity"`
-	Price           float64   `json:"price"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              string      `json:"id"`
+	PurchaseOrderID string      `json:"purchase_order_id"`
+	InventoryItemID string      `json:"inventory_item_id"`
+	Quantity        int         `json:"quantity"`
+	Price           money.Money `json:"price"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
 }
 
 // InventoryTransaction represents an inventory transaction
@@ -83,15 +85,15 @@ type SupplierResponse struct {
 
 // InventoryItemResponse represents the inventory item data returned in responses
 type InventoryItemResponse struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Category    string    `json:"category"`
-	Description string    `json:"description,omitempty"`
-	Quantity    int       `json:"quantity"`
-	Unit        string    `json:"unit"`
-	MinQuantity int       `json:"min_quantity"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Category    string      `json:"category"`
+	Description string      `json:"description,omitempty"`
+	Quantity    int         `json:"quantity"`
+	Unit        string      `json:"unit"`
+	MinQuantity int         `json:"min_quantity"`
+	Price       money.Money `json:"price"`
+	CreatedAt   time.Time   `json:"created_at"`
 }
 
 // OrderItemResponse represents the order item data returned in responses
@@ -99,7 +101,7 @@ type OrderItemResponse struct {
 	ID            string                `json:"id"`
 	InventoryItem InventoryItemResponse `json:"inventory_item"`
 	Quantity      int                   `json:"quantity"`
-	Price         float64               `json:"price"`
+	Price         money.Money           `json:"price"`
 }
 
 // PurchaseOrderResponse represents the purchase order data returned in responses
@@ -107,7 +109,7 @@ type PurchaseOrderResponse struct {
 	ID           string              `json:"id"`
 	Supplier     SupplierResponse    `json:"supplier"`
 	Status       string              `json:"status"`
-	TotalPrice   float64             `json:"total_price"`
+	TotalPrice   money.Money         `json:"total_price"`
 	Notes        string              `json:"notes,omitempty"`
 	OrderDate    time.Time           `json:"order_date"`
 	DeliveryDate time.Time           `json:"delivery_date,omitempty"`
@@ -146,18 +146,66 @@ func (r *InventoryRepository) Update(item model.InventoryItem) (model.InventoryI
 	return item, nil
 }
 
-// UpdateQuantity updates an inventory item's quantity
-func (r *InventoryRepository) UpdateQuantity(id string, quantity int) error {
-	query := `
+// addQuantity adds to an inventory item's quantity in place, so concurrent changes to
+// the item are never lost, and records the movement, using q
+func addQuantity(q querier, transaction model.InventoryTransaction) error {
+	change := transaction.Quantity
+	if transaction.Type == "out" {
+		change = -change
+	}
+
+	result, err := q.Exec(`
 		UPDATE inventory_items
-		SET quantity = $1, updated_at = $2
+		SET quantity = quantity + $1, updated_at = $2
 		WHERE id = $3
-	`
+	`, change, time.Now(), transaction.InventoryItemID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("inventory item not found")
+	}
 
-	_, err := r.db.Exec(query, quantity, time.Now(), id)
+	_, err = insertTransaction(q, transaction)
 	return err
 }
 
+// SetQuantity sets an inventory item's quantity after a stock count and records the
+// difference as an adjustment, in one transaction. The item is locked while its current
+// quantity is read, so a receipt landing at the same time is not overwritten unrecorded.
+func (r *InventoryRepository) SetQuantity(id string, quantity int, transaction model.InventoryTransaction) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		var current int
+		err := tx.QueryRow(`SELECT quantity FROM inventory_items WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("inventory item not found")
+			}
+			return err
+		}
+
+		if quantity == current {
+			return nil
+		}
+
+		transaction.InventoryItemID = id
+		transaction.Type = "in"
+		transaction.Quantity = quantity - current
+		if transaction.Quantity < 0 {
+			transaction.Type = "out"
+			transaction.Quantity = -transaction.Quantity // Make positive for the transaction
+		}
+
+		return addQuantity(tx, transaction)
+	})
+}
+
 // Delete deletes an inventory item
 func (r *InventoryRepository) Delete(id string) error {
 	query := `
@@ -346,6 +394,11 @@ func (r *InventoryRepository) ListCategories() ([]string, error) {
 
 // CreateTransaction creates a new inventory transaction
 func (r *InventoryRepository) CreateTransaction(transaction model.InventoryTransaction) (model.InventoryTransaction, error) {
+	return insertTransaction(r.db, transaction)
+}
+
+// insertTransaction inserts an inventory transaction using q
+func insertTransaction(q querier, transaction model.InventoryTransaction) (model.InventoryTransaction, error) {
 	query := `
 		INSERT INTO inventory_transactions (id, inventory_item_id, quantity, type, source, source_id, notes, created_by, created_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
@@ -360,7 +413,7 @@ func (r *InventoryRepository) CreateTransaction(transaction model.InventoryTrans
 	// Set timestamp
 	transaction.CreatedAt = time.Now()
 
-	err := r.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		transaction.ID,
 		transaction.InventoryItemID,
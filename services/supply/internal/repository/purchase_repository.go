@@ -21,6 +21,47 @@ func NewPurchaseRepository(db *sql.DB) *PurchaseRepository {
 
 // CreateOrder creates a new purchase order
 func (r *PurchaseRepository) CreateOrder(order model.PurchaseOrder) (model.PurchaseOrder, error) {
+	return insertPurchaseOrder(r.db, order)
+}
+
+// CreateOrderItem creates a new order item
+func (r *PurchaseRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem, error) {
+	return insertPurchaseOrderItem(r.db, item)
+}
+
+// CreateOrderWithItems creates a purchase order and its items in one transaction, so an
+// order is never left without its items or its total. The items are given the order's ID.
+func (r *PurchaseRepository) CreateOrderWithItems(order model.PurchaseOrder, items []model.OrderItem) (model.PurchaseOrder, []model.OrderItem, error) {
+	var createdOrder model.PurchaseOrder
+	var createdItems []model.OrderItem
+	err := withTx(r.db, func(tx *sql.Tx) error {
+		var err error
+		createdOrder, err = insertPurchaseOrder(tx, order)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			item.PurchaseOrderID = createdOrder.ID
+			createdItem, err := insertPurchaseOrderItem(tx, item)
+			if err != nil {
+				return err
+			}
+			createdItems = append(createdItems, createdItem)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return model.PurchaseOrder{}, nil, err
+	}
+
+	return createdOrder, createdItems, nil
+}
+
+// insertPurchaseOrder inserts a purchase order using q
+func insertPurchaseOrder(q querier, order model.PurchaseOrder) (model.PurchaseOrder, error) {
 	query := `
 		INSERT INTO purchase_orders (id, supplier_id, status, total_price, notes, order_date, delivery_date, created_by, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
@@ -47,7 +88,7 @@ func (r *PurchaseRepository) CreateOrder(order model.PurchaseOrder) (model.Purch
 		order.OrderDate = now
 	}
 
-	err := r.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		order.ID,
 		order.SupplierID,
@@ -79,8 +120,8 @@ func (r *PurchaseRepository) CreateOrder(order model.PurchaseOrder) (model.Purch
 	return order, nil
 }
 
-// CreateOrderItem creates a new order item
-func (r *PurchaseRepository) CreateOrderItem(item model.OrderItem) (model.OrderItem, error) {
+// insertPurchaseOrderItem inserts a purchase order item using q
+func insertPurchaseOrderItem(q querier, item model.OrderItem) (model.OrderItem, error) {
 	query := `
 		INSERT INTO order_items (id, purchase_order_id, inventory_item_id, quantity, price, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
@@ -97,7 +138,7 @@ func (r *PurchaseRepository) CreateOrderItem(item model.OrderItem) (model.OrderI
 	item.CreatedAt = now
 	item.UpdatedAt = now
 
-	err := r.db.QueryRow(
+	err := q.QueryRow(
 		query,
 		item.ID,
 		item.PurchaseOrderID,
@@ -195,16 +236,32 @@ func (r *PurchaseRepository) GetOrderItemsByOrderID(orderID string) ([]model.Ord
 	return items, nil
 }
 
-// UpdateOrderStatus updates a purchase order's status
+// UpdateOrderStatus updates a purchase order's status. Received and cancelled orders are
+// final, and the status is checked in the same statement that changes it, so an order
+// received concurrently is never moved on afterwards.
 func (r *PurchaseRepository) UpdateOrderStatus(id, status string) error {
 	query := `
 		UPDATE purchase_orders
 		SET status = $1, updated_at = $2
 		WHERE id = $3
+		AND status NOT IN ('received', 'cancelled')
 	`
 
-	_, err := r.db.Exec(query, status, time.Now(), id)
-	return err
+	result, err := r.db.Exec(query, status, time.Now(), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("purchase order not found or already received or cancelled")
+	}
+
+	return nil
 }
 
 // UpdateDeliveryDate updates a purchase order's delivery date
@@ -219,6 +276,96 @@ func (r *PurchaseRepository) UpdateDeliveryDate(id string, deliveryDate time.Tim
 	return err
 }
 
+// Receive marks a purchase order as received and adds its items to stock in one
+// transaction. The order is moved to received before any stock is added and only from a
+// state that has not been received or cancelled, so a second receipt, even a concurrent
+// one, fails instead of counting the goods again.
+func (r *PurchaseRepository) Receive(id, receivedBy string) error {
+	return withTx(r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		var status string
+		err := tx.QueryRow(`SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errors.New("purchase order not found")
+			}
+			return err
+		}
+
+		switch status {
+		case "received":
+			return errors.New("purchase order has already been received")
+		case "cancelled":
+			return errors.New("cannot change status of a cancelled order")
+		}
+
+		_, err = tx.Exec(`
+			UPDATE purchase_orders
+			SET status = 'received', delivery_date = $1, updated_at = $1
+			WHERE id = $2
+		`, now, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(`
+			SELECT inventory_item_id, quantity
+			FROM order_items
+			WHERE purchase_order_id = $1
+			ORDER BY inventory_item_id, created_at
+		`, id)
+		if err != nil {
+			return err
+		}
+
+		var transactions []model.InventoryTransaction
+		for rows.Next() {
+			transaction := model.InventoryTransaction{
+				Type:      "in",
+				Source:    "purchase_order",
+				SourceID:  id,
+				CreatedBy: receivedBy,
+			}
+			if err := rows.Scan(&transaction.InventoryItemID, &transaction.Quantity); err != nil {
+				rows.Close()
+				return err
+			}
+			transactions = append(transactions, transaction)
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// Lock the stock in a fixed order before touching it, so receipts that share
+		// items cannot deadlock by locking them in different orders
+		_, err = tx.Exec(`
+			SELECT id
+			FROM inventory_items
+			WHERE id IN (
+				SELECT inventory_item_id
+				FROM order_items
+				WHERE purchase_order_id = $1
+			)
+			ORDER BY id
+			FOR UPDATE
+		`, id)
+		if err != nil {
+			return err
+		}
+
+		for _, transaction := range transactions {
+			if err := addQuantity(tx, transaction); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 // ListOrders lists all purchase orders
 func (r *PurchaseRepository) ListOrders(limit, offset int) ([]model.PurchaseOrder, error) {
 	query := `
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestUpdateOrderStatusRefusesFinalOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// A receipt that lands between the service's read and this write leaves nothing to update
+	mock.ExpectExec(regexp.QuoteMeta(`AND status NOT IN ('received', 'cancelled')`)).
+		WithArgs("cancelled", sqlmock.AnyArg(), "po-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := NewPurchaseRepository(db).UpdateOrderStatus("po-1", "cancelled"); err == nil {
+		t.Error("cancelling a purchase order that was received meanwhile was accepted")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta(`AND status NOT IN ('received', 'cancelled')`)).
+		WithArgs("approved", sqlmock.AnyArg(), "po-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := NewPurchaseRepository(db).UpdateOrderStatus("po-1", "approved"); err != nil {
+		t.Errorf("approving a pending purchase order failed: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"database/sql"
+)
+
+// querier is the subset of *sql.DB and *sql.Tx used by the repositories, so the
+// same query helpers can run either standalone or inside a transaction
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// withTx runs fn inside a transaction, committing if fn succeeds and rolling back otherwise
+func withTx(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
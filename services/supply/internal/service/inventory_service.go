@@ -39,7 +39,7 @@ func (s *InventoryService) CreateInventoryItem(item model.InventoryItem) (model.
 		return model.InventoryItemResponse{}, errors.New("min quantity must be non-negative")
 	}
 
-	if item.Price < 0 {
+	if item.Price.IsNegative() {
 		return model.InventoryItemResponse{}, errors.New("price must be non-negative")
 	}
 
@@ -88,41 +88,20 @@ func (s *InventoryService) UpdateInventoryItem(id string, item model.InventoryIt
 	return updatedItem.ToResponse(), nil
 }
 
-// UpdateInventoryQuantity updates an inventory item's quantity
+// UpdateInventoryQuantity sets an inventory item's quantity after a stock count,
+// recording the difference as an adjustment
 func (s *InventoryService) UpdateInventoryQuantity(id string, quantity int, userID, notes string) error {
-	// Check if item exists
-	existingItem, err := s.inventoryRepo.GetByID(id)
-	if err != nil {
-		return err
-	}
-
-	// Calculate quantity change
-	change := quantity - existingItem.Quantity
-
-	// Create transaction
-	transactionType := "in"
-	if change < 0 {
-		transactionType = "out"
-		change = -change // Make positive for the transaction
+	if quantity < 0 {
+		return errors.New("quantity must be non-negative")
 	}
 
 	transaction := model.InventoryTransaction{
-		InventoryItemID: id,
-		Quantity:        change,
-		Type:            transactionType,
-		Source:          "adjustment",
-		Notes:           notes,
-		CreatedBy:       userID,
-	}
-
-	// Create transaction
-	_, err = s.inventoryRepo.CreateTransaction(transaction)
-	if err != nil {
-		return err
+		Source:    "adjustment",
+		Notes:     notes,
+		CreatedBy: userID,
 	}
 
-	// Update quantity
-	return s.inventoryRepo.UpdateQuantity(id, quantity)
+	return s.inventoryRepo.SetQuantity(id, quantity, transaction)
 }
 
 // DeleteInventoryItem deletes an inventory item
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/flaminshinjan/address.ai/pkg/common/money"
 	"github.com/flaminshinjan/address.ai/services/supply/internal/model"
 	"github.com/flaminshinjan/address.ai/services/supply/internal/repository"
 )
@@ -24,7 +25,9 @@ func NewPurchaseService(purchaseRepo *repository.PurchaseRepository, supplierRep
 	}
 }
 
-// CreatePurchaseOrder creates a new purchase order
+// CreatePurchaseOrder creates a new purchase order. The items are priced and the order
+// totalled before anything is written, and the order is stored with its items in one
+// transaction.
 func (s *PurchaseService) CreatePurchaseOrder(userID string, req model.CreatePurchaseOrderRequest) (model.PurchaseOrderResponse, error) {
 	// Validate request
 	if len(req.Items) == 0 {
@@ -37,26 +40,16 @@ func (s *PurchaseService) CreatePurchaseOrder(userID string, req model.CreatePur
 		return model.PurchaseOrderResponse{}, err
 	}
 
-	// Create order
-	order := model.PurchaseOrder{
-		SupplierID: req.SupplierID,
-		Status:     "pending",
-		TotalPrice: 0,
-		Notes:      req.Notes,
-		OrderDate:  time.Now(),
-		CreatedBy:  userID,
-	}
-
-	createdOrder, err := s.purchaseRepo.CreateOrder(order)
-	if err != nil {
-		return model.PurchaseOrderResponse{}, err
-	}
-
-	// Create order items
-	var totalPrice float64
-	var orderItems []model.OrderItemResponse
+	// Price the items
+	totalPrice := money.Zero(money.DefaultCurrency)
+	var items []model.OrderItem
+	var inventoryItems []model.InventoryItem
 
 	for _, itemReq := range req.Items {
+		if itemReq.Quantity <= 0 {
+			return model.PurchaseOrderResponse{}, errors.New("quantity must be greater than zero")
+		}
+
 		// Get inventory item
 		inventoryItem, err := s.inventoryRepo.GetByID(itemReq.InventoryItemID)
 		if err != nil {
@@ -64,44 +57,48 @@ func (s *PurchaseService) CreatePurchaseOrder(userID string, req model.CreatePur
 		}
 
 		// Calculate price
-		itemPrice := inventoryItem.Price * float64(itemReq.Quantity)
-		totalPrice += itemPrice
+		itemPrice := inventoryItem.Price.Mul(int64(itemReq.Quantity))
+		totalPrice = totalPrice.Add(itemPrice)
 
-		// Create order item
-		orderItem := model.OrderItem{
-			PurchaseOrderID: createdOrder.ID,
+		items = append(items, model.OrderItem{
 			InventoryItemID: itemReq.InventoryItemID,
 			Quantity:        itemReq.Quantity,
 			Price:           itemPrice,
-		}
+		})
+		inventoryItems = append(inventoryItems, inventoryItem)
+	}
 
-		createdItem, err := s.purchaseRepo.CreateOrderItem(orderItem)
-		if err != nil {
-			return model.PurchaseOrderResponse{}, err
-		}
+	// Create order
+	order := model.PurchaseOrder{
+		SupplierID: req.SupplierID,
+		Status:     "pending",
+		TotalPrice: totalPrice,
+		Notes:      req.Notes,
+		OrderDate:  time.Now(),
+		CreatedBy:  userID,
+	}
 
-		// Add to response
+	createdOrder, createdItems, err := s.purchaseRepo.CreateOrderWithItems(order, items)
+	if err != nil {
+		return model.PurchaseOrderResponse{}, err
+	}
+
+	// Create response
+	var orderItems []model.OrderItemResponse
+	for i, createdItem := range createdItems {
 		orderItems = append(orderItems, model.OrderItemResponse{
 			ID:            createdItem.ID,
-			InventoryItem: inventoryItem.ToResponse(),
+			InventoryItem: inventoryItems[i].ToResponse(),
 			Quantity:      createdItem.Quantity,
 			Price:         createdItem.Price,
 		})
 	}
 
-	// Update order total price
-	createdOrder.TotalPrice = totalPrice
-	_, err = s.purchaseRepo.CreateOrder(createdOrder)
-	if err != nil {
-		return model.PurchaseOrderResponse{}, err
-	}
-
-	// Create response
 	response := model.PurchaseOrderResponse{
 		ID:           createdOrder.ID,
 		Supplier:     supplier.ToResponse(),
 		Status:       createdOrder.Status,
-		TotalPrice:   totalPrice,
+		TotalPrice:   createdOrder.TotalPrice,
 		Notes:        createdOrder.Notes,
 		OrderDate:    createdOrder.OrderDate,
 		DeliveryDate: createdOrder.DeliveryDate,
@@ -191,51 +188,17 @@ func (s *PurchaseService) UpdatePurchaseOrderStatus(id, status, userID string) e
 		return errors.New("cannot change status of a cancelled order")
 	}
 
-	if order.Status == "received" && status != "received" {
+	if order.Status == "received" {
+		if status == "received" {
+			return errors.New("purchase order has already been received")
+		}
 		return errors.New("cannot change status of a received order")
 	}
 
-	// If status is changing to received, update inventory
-	if status == "received" && order.Status != "received" {
-		// Get order items
-		items, err := s.purchaseRepo.GetOrderItemsByOrderID(id)
-		if err != nil {
-			return err
-		}
-
-		// Update inventory for each item
-		for _, item := range items {
-			// Get inventory item
-			inventoryItem, err := s.inventoryRepo.GetByID(item.InventoryItemID)
-			if err != nil {
-				return err
-			}
-
-			// Update quantity
-			newQuantity := inventoryItem.Quantity + item.Quantity
-			if err := s.inventoryRepo.UpdateQuantity(item.InventoryItemID, newQuantity); err != nil {
-				return err
-			}
-
-			// Create transaction
-			transaction := model.InventoryTransaction{
-				InventoryItemID: item.InventoryItemID,
-				Quantity:        item.Quantity,
-				Type:            "in",
-				Source:          "purchase_order",
-				SourceID:        id,
-				CreatedBy:       userID,
-			}
-
-			if _, err := s.inventoryRepo.CreateTransaction(transaction); err != nil {
-				return err
-			}
-		}
-
-		// Set delivery date
-		if err := s.purchaseRepo.UpdateDeliveryDate(id, time.Now()); err != nil {
-			return err
-		}
+	// Receiving adds the items to stock along with the status change; the repository
+	// re-checks the status under a lock so a concurrent receipt cannot count twice
+	if status == "received" {
+		return s.purchaseRepo.Receive(id, userID)
 	}
 
 	// Update status